@@ -0,0 +1,134 @@
+package client
+
+import "context"
+
+// The interfaces below group the Client's methods by area, so consumers can
+// depend on (and mock) only what they use. *Client implements all of them. See
+// the glabsmock package for ready-made mocks.
+
+// ConversationAPI covers starting conversations and recording what happens in
+// them.
+type ConversationAPI interface {
+	StartConversation(ctx context.Context, p StartConversationParams, opts ...CallOption) (*Conversation, error)
+	ReadConversation(ctx context.Context, conversationID string, p *ReadParams, opts ...CallOption) (*Conversation, error)
+	ListConversations(ctx context.Context, p *ListConversationsParams, opts ...CallOption) (*ListConversationsResponse, error)
+	ReadConversationTranscript(ctx context.Context, conversationID string, p *ReadTranscriptParams, opts ...CallOption) (*Transcript, error)
+	AddMessage(ctx context.Context, conversationID string, p AddMessageParams, opts ...CallOption) (*Message, error)
+	AddConversationEvent(ctx context.Context, conversationID string, p *EventParams, opts ...CallOption) error
+	AddResource(ctx context.Context, conversationID string, name string, resource any, opts ...CallOption) error
+	AssignConversation(ctx context.Context, conversationID string, p *AssignmentParams, opts ...CallOption) error
+	ResumeConversation(ctx context.Context, conversationID string, p *ConversationResumeParams, opts ...CallOption) error
+	ReturnAsyncToolResult(ctx context.Context, conversationID string, p ReturnAsyncToolResultParams, opts ...CallOption) error
+	RateConversation(ctx context.Context, conversationID string, p *RatingParams, opts ...CallOption) error
+	FinishConversation(ctx context.Context, conversationID string, p FinishParams, opts ...CallOption) error
+	CancelConversation(ctx context.Context, conversationID string, p CancelParams, opts ...CallOption) error
+	StartOutboundConversation(ctx context.Context, p StartOutboundConversationParams, opts ...CallOption) (*StartOutboundConversationResponse, error)
+	ReadLatestVoiceCallContext(ctx context.Context, phoneNumber string, p *ReadVoiceCallContextParams, opts ...CallOption) (*VoiceCallContext, error)
+}
+
+// HandOffTargetAPI covers managing where conversations can be handed off to.
+type HandOffTargetAPI interface {
+	ListHandOffTargets(ctx context.Context, opts ...CallOption) (*HandOffTargets, error)
+	UpsertHandOffTarget(ctx context.Context, p *UpsertHandOffTargetParams, opts ...CallOption) error
+	DeleteHandOffTarget(ctx context.Context, p *HandOffTargetDeleteParams, opts ...CallOption) error
+	GetDefaultHandOffTarget(ctx context.Context, p *GetDefaultHandOffTargetParams, opts ...CallOption) (*GetDefaultHandOffTargetResponse, error)
+	SetDefaultHandOffTarget(ctx context.Context, p *SetDefaultHandOffTargetParams, opts ...CallOption) error
+}
+
+// ToolAPI covers managing the tools available to the AI agent.
+type ToolAPI interface {
+	CreateTool(ctx context.Context, p *Tool, opts ...CallOption) (*Tool, error)
+	ReadTool(ctx context.Context, toolID string, p *ReadToolParams, opts ...CallOption) (*Tool, error)
+	ListTools(ctx context.Context, opts ...CallOption) ([]*Tool, error)
+	UpdateTool(ctx context.Context, p *UpdateToolParams, opts ...CallOption) (*Tool, error)
+	DeleteTool(ctx context.Context, toolID string, opts ...CallOption) error
+	ExecuteTool(ctx context.Context, p *ExecutionParams, opts ...CallOption) (*ExecuteResult, error)
+}
+
+// ResourceAPI covers managing resource types and the sources they are pulled
+// from.
+type ResourceAPI interface {
+	CreateResourceType(ctx context.Context, req *ResourceTypeCreateParams, opts ...CallOption) (*ResourceType, error)
+	ReadResourceType(ctx context.Context, id string, opts ...CallOption) (*ResourceType, error)
+	ListResourceTypes(ctx context.Context, opts ...CallOption) (*ResourceTypeListResponse, error)
+	UpdateResourceType(ctx context.Context, id string, req *ResourceTypeUpdateParams, opts ...CallOption) (*ResourceType, error)
+	DeleteResourceType(ctx context.Context, id string, opts ...CallOption) error
+	CreateResourceSource(ctx context.Context, req *ResourceSourceCreateParams, opts ...CallOption) (*ResourceSource, error)
+	ReadResourceSource(ctx context.Context, id string, opts ...CallOption) (*ResourceSource, error)
+	ListResourceSources(ctx context.Context, opts ...CallOption) (*ResourceSourceListResponse, error)
+	UpdateResourceSource(ctx context.Context, id string, req *ResourceSourceUpdateParams, opts ...CallOption) (*ResourceSource, error)
+	UpdateResourceSourceSchemaByExamples(ctx context.Context, id string, req *UpdateResourceSourceSchemaByExamplesParams, opts ...CallOption) (*ResourceSource, error)
+	DeleteResourceSource(ctx context.Context, id string, opts ...CallOption) error
+}
+
+// KnowledgeAPI covers managing the AI agent's knowledge: articles, topics,
+// notes and terminology substitutions.
+type KnowledgeAPI interface {
+	UpsertArticle(ctx context.Context, p *UpsertArticleParams, opts ...CallOption) error
+	SetArticleUsageStatus(ctx context.Context, articleID string, p *SetArticleUsageStatusParams, opts ...CallOption) error
+	DeleteArticle(ctx context.Context, articleID string, opts ...CallOption) error
+	UpsertArticleTopic(ctx context.Context, p *UpsertArticleTopicParams, opts ...CallOption) error
+	ReadTopic(ctx context.Context, topicID string, p *ReadTopicParams, opts ...CallOption) (*Topic, error)
+	ListTopics(ctx context.Context, p *ListTopicsParams, opts ...CallOption) (*ListTopicsResponse, error)
+	CreateNote(ctx context.Context, p *CreateNoteParams, opts ...CallOption) (*Note, error)
+	UpdateNote(ctx context.Context, noteID string, p *UpdateNoteParams, opts ...CallOption) (*Note, error)
+	SetNoteStatus(ctx context.Context, noteID string, p *SetNoteStatusParams, opts ...CallOption) error
+	DeleteNote(ctx context.Context, noteID string, opts ...CallOption) error
+	CreateTerminologySubstitution(ctx context.Context, p *TerminologySubstitutionCreateParams, opts ...CallOption) (*TerminologySubstitution, error)
+	ReadTerminologySubstitution(ctx context.Context, id string, opts ...CallOption) (*TerminologySubstitution, error)
+	ListTerminologySubstitutions(ctx context.Context, opts ...CallOption) (*TerminologySubstitutionListResponse, error)
+	UpdateTerminologySubstitution(ctx context.Context, id string, p *TerminologySubstitutionUpdateParams, opts ...CallOption) (*TerminologySubstitution, error)
+	DeleteTerminologySubstitution(ctx context.Context, id string, opts ...CallOption) error
+}
+
+// ProcedureAPI covers managing procedures and their versions.
+type ProcedureAPI interface {
+	ListProcedures(ctx context.Context, p *ProcedureListParams, opts ...CallOption) (*ProcedureListResponse, error)
+	ReadProcedure(ctx context.Context, procedureID string, opts ...CallOption) (*Procedure, error)
+	SetProcedureLimit(ctx context.Context, procedureID string, p *ProcedureLimitParams, opts ...CallOption) (*Procedure, error)
+	ListProcedureVersions(ctx context.Context, procedureID string, opts ...CallOption) (*ListProcedureVersionsResponse, error)
+	SetProcedureLiveVersion(ctx context.Context, procedureID string, version int, opts ...CallOption) error
+	UnsetProcedureLiveVersion(ctx context.Context, procedureID string, version int, opts ...CallOption) error
+	SetProcedureGatedVersion(ctx context.Context, procedureID string, version int, p *SetProcedureGatedVersionParams, opts ...CallOption) error
+	UnsetProcedureGatedVersion(ctx context.Context, procedureID string, version int, opts ...CallOption) error
+}
+
+// TrafficGroupAPI covers managing traffic groups and their targets.
+type TrafficGroupAPI interface {
+	CreateTrafficGroup(ctx context.Context, p *CreateTrafficGroupParams, opts ...CallOption) (*TrafficGroup, error)
+	ListTrafficGroups(ctx context.Context, opts ...CallOption) ([]*TrafficGroup, error)
+	UpdateTrafficGroup(ctx context.Context, p *UpdateTrafficGroupParams, opts ...CallOption) (*TrafficGroup, error)
+	DeleteTrafficGroup(ctx context.Context, trafficGroupID string, opts ...CallOption) error
+	CreateTrafficGroupTarget(ctx context.Context, p *CreateTrafficGroupTargetParams, opts ...CallOption) (*TrafficGroupTarget, error)
+	DeleteTrafficGroupTarget(ctx context.Context, trafficGroupID string, targetID string, opts ...CallOption) error
+	CreateTrafficGroupExclusion(ctx context.Context, p *CreateTrafficGroupExclusionParams, opts ...CallOption) (*TrafficGroupTarget, error)
+	DeleteTrafficGroupExclusion(ctx context.Context, trafficGroupID string, targetID string, opts ...CallOption) error
+}
+
+// SecretAPI covers managing secrets used by tools.
+type SecretAPI interface {
+	WriteSecret(ctx context.Context, p *WriteSecretParams, opts ...CallOption) (*Secret, error)
+	ListSecrets(ctx context.Context, opts ...CallOption) (*SecretsListResponse, error)
+	RevokeSecret(ctx context.Context, p *RevokeSecretParams, opts ...CallOption) error
+}
+
+// BackOfficeTaskAPI covers creating and reading back-office tasks.
+type BackOfficeTaskAPI interface {
+	CreateBackOfficeTask(ctx context.Context, p BackOfficeTaskCreateParams, opts ...CallOption) (*BackOfficeTask, error)
+	ReadBackOfficeTask(ctx context.Context, taskID string, opts ...CallOption) (*BackOfficeTask, error)
+}
+
+// API is implemented by *Client and combines all of the per-area interfaces.
+type API interface {
+	ConversationAPI
+	HandOffTargetAPI
+	ToolAPI
+	ResourceAPI
+	KnowledgeAPI
+	ProcedureAPI
+	TrafficGroupAPI
+	SecretAPI
+	BackOfficeTaskAPI
+}
+
+var _ API = (*Client)(nil)
@@ -8,15 +8,6 @@ import (
 
 // DeleteArticle marks an article as deleted. Copies of the article are kept
 // in case they are needed to render citations.
-func (c *Client) DeleteArticle(ctx context.Context, articleID string) error {
-	rsp, err := c.makeRequest(ctx, http.MethodDelete, fmt.Sprintf("articles/%s", articleID), nil)
-	if err != nil {
-		return err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return err
-	}
-	return nil
+func (c *Client) DeleteArticle(ctx context.Context, articleID string, opts ...CallOption) error {
+	return c.do(ctx, "DeleteArticle", http.MethodDelete, fmt.Sprintf("articles/%s", articleID), nil, nil, opts...)
 }
@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 )
@@ -14,24 +13,14 @@ type ReadTopicParams struct {
 }
 
 // ReadTopic reads an article topic by ID.
-func (c *Client) ReadTopic(ctx context.Context, topicID string, p *ReadTopicParams) (*Topic, error) {
+func (c *Client) ReadTopic(ctx context.Context, topicID string, p *ReadTopicParams, opts ...CallOption) (*Topic, error) {
 	path := fmt.Sprintf("topic/%s", topicID)
 	if p != nil && p.SupportPlatform != "" {
 		path = fmt.Sprintf("topic/%s?support_platform=%s", topicID, p.SupportPlatform)
 	}
 
-	rsp, err := c.makeRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return nil, err
-	}
-
 	var topic Topic
-	if err := json.NewDecoder(rsp.Body).Decode(&topic); err != nil {
+	if err := c.do(ctx, "ReadTopic", http.MethodGet, path, nil, &topic, opts...); err != nil {
 		return nil, err
 	}
 
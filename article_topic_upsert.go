@@ -37,15 +37,6 @@ type UpsertArticleTopicParams struct {
 	LastEdited time.Time `json:"last_edited"`
 }
 
-func (c *Client) UpsertArticleTopic(ctx context.Context, p *UpsertArticleTopicParams) error {
-	rsp, err := c.makeRequest(ctx, http.MethodPost, "topics", p)
-	if err != nil {
-		return err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return err
-	}
-	return nil
+func (c *Client) UpsertArticleTopic(ctx context.Context, p *UpsertArticleTopicParams, opts ...CallOption) error {
+	return c.do(ctx, "UpsertArticleTopic", http.MethodPost, "topics", p, nil, opts...)
 }
@@ -56,24 +56,14 @@ type ListTopicsResponse struct {
 }
 
 // ListTopics lists a company's topics, optionally filtered by support platform.
-func (c *Client) ListTopics(ctx context.Context, p *ListTopicsParams) (*ListTopicsResponse, error) {
+func (c *Client) ListTopics(ctx context.Context, p *ListTopicsParams, opts ...CallOption) (*ListTopicsResponse, error) {
 	path := "topics"
 	if p != nil && p.SupportPlatform != "" {
 		path = fmt.Sprintf("topics?support_platform=%s", p.SupportPlatform)
 	}
 
-	rsp, err := c.makeRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return nil, err
-	}
-
 	var result ListTopicsResponse
-	if err := json.NewDecoder(rsp.Body).Decode(&result); err != nil {
+	if err := c.do(ctx, "ListTopics", http.MethodGet, path, nil, &result, opts...); err != nil {
 		return nil, err
 	}
 
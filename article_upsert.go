@@ -46,15 +46,6 @@ type UpsertArticleParams struct {
 }
 
 // UpsertArticle inserts or updates a help article
-func (c *Client) UpsertArticle(ctx context.Context, p *UpsertArticleParams) error {
-	rsp, err := c.makeRequest(ctx, http.MethodPost, "articles", p)
-	if err != nil {
-		return err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return err
-	}
-	return nil
+func (c *Client) UpsertArticle(ctx context.Context, p *UpsertArticleParams, opts ...CallOption) error {
+	return c.do(ctx, "UpsertArticle", http.MethodPost, "articles", p, nil, opts...)
 }
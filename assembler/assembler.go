@@ -0,0 +1,421 @@
+// Package assembler groups the messages the agent sends in a single turn
+// (see AgentMessageEvent.Total and Sequence), delivers them in order, and
+// optionally merges them into one message, so each channel integration does
+// not have to.
+package assembler
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	client "github.com/gradientlabs-ai/gradientlabs-go"
+)
+
+const (
+	defaultTimeout          = 30 * time.Second
+	defaultRedeliveryWindow = time.Hour
+	defaultSeparator        = "\n\n"
+)
+
+// HoldingAction determines what happens to a holding-response (i.e. an agent
+// message with IsHolding set).
+type HoldingAction int
+
+const (
+	// HoldingDeliver delivers holding-responses immediately, ahead of the rest
+	// of the turn, and never merges them.
+	HoldingDeliver HoldingAction = iota
+
+	// HoldingSuppress drops holding-responses (e.g. on email, where a "let
+	// me check" message is just noise).
+	HoldingSuppress
+
+	// HoldingInline treats holding-responses like any other message in the
+	// turn.
+	HoldingInline
+)
+
+// HoldingPolicy decides what to do with a holding-response, e.g. based on the
+// conversation's channel.
+type HoldingPolicy func(ev *client.AgentMessageEvent) HoldingAction
+
+// Delivery is one or more agent messages ready to be sent to the customer.
+type Delivery struct {
+	// ConversationID identifies the conversation.
+	ConversationID string
+
+	// Messages contains the delivered messages in Sequence order. When
+	// merging, it contains all of the turn's messages.
+	Messages []*client.AgentMessageEvent
+
+	// Body is the text to send: the single message's body, or the merged
+	// bodies when merging.
+	Body string
+
+	// Holding is true if the delivery is a holding-response.
+	Holding bool
+
+	// Partial is true if the turn timed out before all of its messages
+	// arrived.
+	Partial bool
+}
+
+// DeliverFunc sends a Delivery to the customer. Deliveries for the same
+// conversation are never made concurrently.
+type DeliverFunc func(ctx context.Context, d Delivery) error
+
+// Config customises an Assembler.
+type Config struct {
+	// Merge delivers each turn as a single message once all Total messages
+	// have arrived, instead of delivering each one as soon as the messages
+	// before it have been delivered.
+	Merge bool
+
+	// Separator joins merged message bodies. Defaults to a blank line.
+	Separator string
+
+	// Timeout is how long to wait for a turn's missing messages before
+	// delivering what has arrived. Defaults to 30 seconds.
+	Timeout time.Duration
+
+	// Holding decides what to do with holding-responses. Defaults to
+	// delivering them immediately.
+	Holding HoldingPolicy
+
+	// RedeliveryWindow is how long AddWebhook remembers webhook IDs, to drop
+	// redelivered webhooks. Defaults to 1 hour.
+	RedeliveryWindow time.Duration
+
+	// OnError is called when a delivery triggered by a timeout fails. Errors
+	// from deliveries triggered by Add are returned from Add instead.
+	OnError func(conversationID string, err error)
+}
+
+// Assembler groups agent messages into turns. Use New to create one.
+type Assembler struct {
+	deliver DeliverFunc
+	cfg     Config
+
+	mu            sync.Mutex
+	conversations map[string]*conversation
+	seen          map[string]bool
+	seenOrder     []seenWebhook
+}
+
+// conversation serialises the handling of a conversation's messages, so a slow
+// delivery only holds up its own conversation.
+type conversation struct {
+	id string
+
+	// mu is held while handling a message, including delivering it.
+	mu   sync.Mutex
+	turn *turn
+
+	// refs counts the goroutines using the conversation, plus one while it has
+	// a turn. It is guarded by Assembler.mu.
+	refs int
+}
+
+// turn holds the state of a conversation's current turn. It is guarded by its
+// conversation's mu.
+type turn struct {
+	total    int
+	next     int
+	received map[int]*client.AgentMessageEvent
+	pending  map[int]*client.AgentMessageEvent
+	timer    *time.Timer
+}
+
+// seenWebhook records when a webhook was added.
+type seenWebhook struct {
+	id string
+	at time.Time
+}
+
+// New creates an Assembler that sends messages with deliver.
+func New(deliver DeliverFunc, cfg Config) *Assembler {
+	if cfg.Separator == "" {
+		cfg.Separator = defaultSeparator
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	if cfg.RedeliveryWindow <= 0 {
+		cfg.RedeliveryWindow = defaultRedeliveryWindow
+	}
+	return &Assembler{
+		deliver:       deliver,
+		cfg:           cfg,
+		conversations: make(map[string]*conversation),
+		seen:          make(map[string]bool),
+	}
+}
+
+// AddWebhook adds the message from an `agent.message` webhook. Other webhook
+// types are ignored, as are redeliveries of a webhook (identified by its ID)
+// that has already been added.
+func (a *Assembler) AddWebhook(ctx context.Context, w *client.Webhook) error {
+	ev, ok := w.AgentMessage()
+	if !ok || !a.claimWebhook(w.ID) {
+		return nil
+	}
+	if err := a.Add(ctx, ev); err != nil {
+		// Let the webhook be retried.
+		a.mu.Lock()
+		delete(a.seen, w.ID)
+		a.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// claimWebhook records the webhook's ID, returning false if it has already
+// been recorded within the redelivery window.
+func (a *Assembler) claimWebhook(id string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := time.Now()
+	for len(a.seenOrder) > 0 && now.Sub(a.seenOrder[0].at) > a.cfg.RedeliveryWindow {
+		delete(a.seen, a.seenOrder[0].id)
+		a.seenOrder = a.seenOrder[1:]
+	}
+
+	if id == "" {
+		return true
+	}
+	if a.seen[id] {
+		return false
+	}
+	a.seen[id] = true
+	a.seenOrder = append(a.seenOrder, seenWebhook{id: id, at: now})
+	return true
+}
+
+// Add adds an agent message, delivering it (and any messages that were waiting
+// for it) if the turn's ordering allows. A message the current turn has
+// already received is dropped as a redelivery; use AddWebhook to also drop
+// redeliveries that arrive after their turn has been delivered.
+func (a *Assembler) Add(ctx context.Context, ev *client.AgentMessageEvent) error {
+	c := a.acquire(ev.Conversation.ID)
+	defer a.release(c)
+
+	// Messages without sequencing information are delivered as-is.
+	if ev.Total <= 0 || ev.Sequence <= 0 {
+		if ev.IsHolding && a.holdingAction(ev) == HoldingSuppress {
+			return nil
+		}
+		return a.deliver(ctx, Delivery{
+			ConversationID: c.id,
+			Messages:       []*client.AgentMessageEvent{ev},
+			Body:           ev.Body,
+			Holding:        ev.IsHolding,
+		})
+	}
+
+	t, err := a.turnFor(ctx, c, ev)
+	if t == nil || err != nil {
+		return err
+	}
+
+	if ev.IsHolding {
+		switch a.holdingAction(ev) {
+		case HoldingSuppress:
+			t.received[ev.Sequence] = ev
+			return a.flush(ctx, c, t, false)
+		case HoldingDeliver:
+			if err := a.deliver(ctx, Delivery{
+				ConversationID: c.id,
+				Messages:       []*client.AgentMessageEvent{ev},
+				Body:           ev.Body,
+				Holding:        true,
+			}); err != nil {
+				return err
+			}
+			t.received[ev.Sequence] = ev
+			return a.flush(ctx, c, t, false)
+		}
+	}
+
+	t.received[ev.Sequence] = ev
+	t.pending[ev.Sequence] = ev
+	return a.flush(ctx, c, t, false)
+}
+
+func (a *Assembler) holdingAction(ev *client.AgentMessageEvent) HoldingAction {
+	if a.cfg.Holding == nil {
+		return HoldingDeliver
+	}
+	return a.cfg.Holding(ev)
+}
+
+// Flush delivers any messages still waiting for the rest of their turn, e.g.
+// before shutting down.
+func (a *Assembler) Flush(ctx context.Context) error {
+	a.mu.Lock()
+	convs := make([]*conversation, 0, len(a.conversations))
+	for _, c := range a.conversations {
+		c.refs++
+		convs = append(convs, c)
+	}
+	a.mu.Unlock()
+
+	var err error
+	for _, c := range convs {
+		c.mu.Lock()
+		if t := c.turn; t != nil && err == nil {
+			err = a.flush(ctx, c, t, true)
+		}
+		a.release(c)
+	}
+	return err
+}
+
+// acquire returns the conversation with its lock held. Assembler.mu is never
+// held while waiting for a conversation's lock.
+func (a *Assembler) acquire(convID string) *conversation {
+	a.mu.Lock()
+	c, ok := a.conversations[convID]
+	if !ok {
+		c = &conversation{id: convID}
+		a.conversations[convID] = c
+	}
+	c.refs++
+	a.mu.Unlock()
+
+	c.mu.Lock()
+	return c
+}
+
+// release unlocks the conversation, forgetting it if it's no longer in use.
+func (a *Assembler) release(c *conversation) {
+	c.mu.Unlock()
+	a.unref(c)
+}
+
+func (a *Assembler) unref(c *conversation) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if c.refs--; c.refs == 0 && a.conversations[c.id] == c {
+		delete(a.conversations, c.id)
+	}
+}
+
+// turnFor returns the turn the message belongs to, starting a new one if it
+// belongs to a different turn, or nil if the current turn has already received
+// it. Any messages left in a turn that is replaced are delivered first. The
+// conversation's lock must be held.
+func (a *Assembler) turnFor(ctx context.Context, c *conversation, ev *client.AgentMessageEvent) (*turn, error) {
+	if t := c.turn; t != nil {
+		prev := t.received[ev.Sequence]
+		switch {
+		case t.total == ev.Total && prev == nil:
+			return t, nil
+		case t.total == ev.Total && *prev == *ev:
+			return nil, nil
+		}
+		if err := a.flush(ctx, c, t, true); err != nil {
+			return nil, err
+		}
+	}
+
+	t := &turn{
+		total:    ev.Total,
+		next:     1,
+		received: make(map[int]*client.AgentMessageEvent),
+		pending:  make(map[int]*client.AgentMessageEvent),
+	}
+	t.timer = time.AfterFunc(a.cfg.Timeout, func() {
+		a.mu.Lock()
+		c.refs++
+		a.mu.Unlock()
+
+		c.mu.Lock()
+		var err error
+		if c.turn == t {
+			err = a.flush(context.Background(), c, t, true)
+		}
+		a.release(c)
+
+		if err != nil && a.cfg.OnError != nil {
+			a.cfg.OnError(c.id, err)
+		}
+	})
+
+	a.mu.Lock()
+	c.refs++
+	a.mu.Unlock()
+	c.turn = t
+	return t, nil
+}
+
+// flush delivers whatever the turn's state allows. If force is true, all
+// pending messages are delivered. Once the turn is complete (or forced) it is
+// removed from the conversation. The conversation's lock must be held.
+func (a *Assembler) flush(ctx context.Context, c *conversation, t *turn, force bool) error {
+	complete := len(t.received) >= t.total
+	if !complete && !force && a.cfg.Merge {
+		return nil
+	}
+
+	var ready []*client.AgentMessageEvent
+	if a.cfg.Merge || force {
+		seqs := make([]int, 0, len(t.pending))
+		for seq := range t.pending {
+			seqs = append(seqs, seq)
+		}
+		sort.Ints(seqs)
+		for _, seq := range seqs {
+			ready = append(ready, t.pending[seq])
+			delete(t.pending, seq)
+		}
+	} else {
+		for ; t.received[t.next] != nil; t.next++ {
+			if ev, ok := t.pending[t.next]; ok {
+				ready = append(ready, ev)
+				delete(t.pending, t.next)
+			}
+		}
+	}
+
+	if (complete || force) && c.turn == t {
+		t.timer.Stop()
+		c.turn = nil
+		a.unref(c)
+	}
+
+	if len(ready) == 0 {
+		return nil
+	}
+
+	partial := force && !complete
+	if a.cfg.Merge {
+		bodies := make([]string, len(ready))
+		for i, ev := range ready {
+			bodies[i] = ev.Body
+		}
+		return a.deliver(ctx, Delivery{
+			ConversationID: c.id,
+			Messages:       ready,
+			Body:           strings.Join(bodies, a.cfg.Separator),
+			Partial:        partial,
+		})
+	}
+
+	for _, ev := range ready {
+		if err := a.deliver(ctx, Delivery{
+			ConversationID: c.id,
+			Messages:       []*client.AgentMessageEvent{ev},
+			Body:           ev.Body,
+			Holding:        ev.IsHolding,
+			Partial:        partial,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,363 @@
+package assembler_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	client "github.com/gradientlabs-ai/gradientlabs-go"
+	"github.com/gradientlabs-ai/gradientlabs-go/assembler"
+)
+
+// recorder collects deliveries.
+type recorder struct {
+	mu         sync.Mutex
+	deliveries []assembler.Delivery
+}
+
+func (r *recorder) deliver(_ context.Context, d assembler.Delivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.deliveries = append(r.deliveries, d)
+	return nil
+}
+
+func (r *recorder) bodies() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []string
+	for _, d := range r.deliveries {
+		out = append(out, d.Body)
+	}
+	return out
+}
+
+func message(conv string, seq, total int, body string) *client.AgentMessageEvent {
+	return &client.AgentMessageEvent{
+		Conversation: client.WebhookConversation{ID: conv},
+		Body:         body,
+		Sequence:     seq,
+		Total:        total,
+	}
+}
+
+func holding(conv string, seq, total int, body string) *client.AgentMessageEvent {
+	ev := message(conv, seq, total, body)
+	ev.IsHolding = true
+	return ev
+}
+
+func TestAssembler(t *testing.T) {
+	testCases := map[string]struct {
+		cfg      assembler.Config
+		messages []*client.AgentMessageEvent
+		want     []string
+	}{
+		"in order": {
+			messages: []*client.AgentMessageEvent{
+				message("c", 1, 2, "one"),
+				message("c", 2, 2, "two"),
+			},
+			want: []string{"one", "two"},
+		},
+		"out of order": {
+			messages: []*client.AgentMessageEvent{
+				message("c", 3, 3, "three"),
+				message("c", 2, 3, "two"),
+				message("c", 1, 3, "one"),
+			},
+			want: []string{"one", "two", "three"},
+		},
+		"merged": {
+			cfg: assembler.Config{Merge: true, Separator: " / "},
+			messages: []*client.AgentMessageEvent{
+				message("c", 2, 2, "two"),
+				message("c", 1, 2, "one"),
+			},
+			want: []string{"one / two"},
+		},
+		"unsequenced": {
+			messages: []*client.AgentMessageEvent{
+				message("c", 0, 0, "plain"),
+			},
+			want: []string{"plain"},
+		},
+		"redelivered in turn": {
+			messages: []*client.AgentMessageEvent{
+				message("c", 2, 3, "two"),
+				message("c", 2, 3, "two"),
+				message("c", 1, 3, "one"),
+				message("c", 1, 3, "one"),
+				message("c", 3, 3, "three"),
+			},
+			want: []string{"one", "two", "three"},
+		},
+		"repeated single message turns": {
+			messages: []*client.AgentMessageEvent{
+				message("c", 1, 1, "anything else?"),
+				message("c", 1, 1, "anything else?"),
+			},
+			want: []string{"anything else?", "anything else?"},
+		},
+		"incomplete turn replaced": {
+			messages: []*client.AgentMessageEvent{
+				message("c", 2, 2, "lost two"),
+				message("c", 1, 1, "new turn"),
+			},
+			want: []string{"lost two", "new turn"},
+		},
+		"next turn with the same total": {
+			messages: []*client.AgentMessageEvent{
+				message("c", 1, 1, "first turn"),
+				message("c", 1, 1, "second turn"),
+			},
+			want: []string{"first turn", "second turn"},
+		},
+		"holding delivered": {
+			messages: []*client.AgentMessageEvent{
+				holding("c", 1, 2, "let me check"),
+				holding("c", 1, 2, "let me check"),
+				message("c", 2, 2, "done"),
+			},
+			want: []string{"let me check", "done"},
+		},
+		"holding suppressed": {
+			cfg: assembler.Config{
+				Holding: func(*client.AgentMessageEvent) assembler.HoldingAction { return assembler.HoldingSuppress },
+			},
+			messages: []*client.AgentMessageEvent{
+				message("c", 2, 2, "done"),
+				holding("c", 1, 2, "let me check"),
+				holding("c", 0, 0, "unsequenced"),
+			},
+			want: []string{"done"},
+		},
+		"holding inline": {
+			cfg: assembler.Config{
+				Merge:   true,
+				Holding: func(*client.AgentMessageEvent) assembler.HoldingAction { return assembler.HoldingInline },
+			},
+			messages: []*client.AgentMessageEvent{
+				holding("c", 1, 2, "let me check"),
+				message("c", 2, 2, "done"),
+			},
+			want: []string{"let me check\n\ndone"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var r recorder
+			a := assembler.New(r.deliver, tc.cfg)
+			for _, ev := range tc.messages {
+				if err := a.Add(context.Background(), ev); err != nil {
+					t.Fatalf("Add: %v", err)
+				}
+			}
+			if got := r.bodies(); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("delivered %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAssemblerTimeout(t *testing.T) {
+	var r recorder
+	a := assembler.New(r.deliver, assembler.Config{
+		Merge:   true,
+		Timeout: 20 * time.Millisecond,
+	})
+
+	if err := a.Add(context.Background(), message("c", 1, 3, "one")); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Add(context.Background(), message("c", 3, 3, "three")); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for len(r.bodies()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.deliveries) != 1 {
+		t.Fatalf("got %d deliveries", len(r.deliveries))
+	}
+	if d := r.deliveries[0]; d.Body != "one\n\nthree" || !d.Partial {
+		t.Errorf("delivery = %+v", d)
+	}
+}
+
+func TestAssemblerTimeoutError(t *testing.T) {
+	errs := make(chan error, 1)
+	a := assembler.New(func(context.Context, assembler.Delivery) error {
+		return errors.New("boom")
+	}, assembler.Config{
+		Merge:   true,
+		Timeout: 10 * time.Millisecond,
+		OnError: func(conv string, err error) { errs <- fmt.Errorf("%s: %w", conv, err) },
+	})
+
+	if err := a.Add(context.Background(), message("c", 1, 2, "one")); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-errs:
+		if err.Error() != "c: boom" {
+			t.Errorf("error = %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("OnError was not called")
+	}
+}
+
+func TestAssemblerFlush(t *testing.T) {
+	var r recorder
+	a := assembler.New(r.deliver, assembler.Config{})
+
+	if err := a.Add(context.Background(), message("c", 2, 2, "two")); err != nil {
+		t.Fatal(err)
+	}
+	if got := r.bodies(); len(got) != 0 {
+		t.Fatalf("delivered %q before Flush", got)
+	}
+	if err := a.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := r.bodies(); !reflect.DeepEqual(got, []string{"two"}) {
+		t.Errorf("delivered %q", got)
+	}
+}
+
+func TestAssemblerSerialisesDeliveries(t *testing.T) {
+	var (
+		active, overlaps int32
+		delivered        int32
+	)
+	a := assembler.New(func(context.Context, assembler.Delivery) error {
+		if atomic.AddInt32(&active, 1) > 1 {
+			atomic.AddInt32(&overlaps, 1)
+		}
+		time.Sleep(time.Millisecond)
+		atomic.AddInt32(&active, -1)
+		atomic.AddInt32(&delivered, 1)
+		return nil
+	}, assembler.Config{})
+
+	var wg sync.WaitGroup
+	for i := 1; i <= 10; i++ {
+		for _, ev := range []*client.AgentMessageEvent{
+			holding("c", 0, 0, fmt.Sprintf("holding %d", i)),
+			message("c", 0, 0, fmt.Sprintf("plain %d", i)),
+			message("c", i, 10, fmt.Sprintf("turn %d", i)),
+		} {
+			wg.Add(1)
+			go func(ev *client.AgentMessageEvent) {
+				defer wg.Done()
+				if err := a.Add(context.Background(), ev); err != nil {
+					t.Error(err)
+				}
+			}(ev)
+		}
+	}
+	wg.Wait()
+
+	if overlaps != 0 {
+		t.Errorf("%d deliveries overlapped", overlaps)
+	}
+	if delivered != 30 {
+		t.Errorf("delivered %d messages, want 30", delivered)
+	}
+}
+
+func webhook(id string, ev *client.AgentMessageEvent) *client.Webhook {
+	return &client.Webhook{ID: id, Type: client.WebhookTypeAgentMessage, Data: ev}
+}
+
+func TestAssemblerAddWebhook(t *testing.T) {
+	var (
+		r    recorder
+		fail atomic.Bool
+	)
+	a := assembler.New(func(ctx context.Context, d assembler.Delivery) error {
+		if fail.Load() {
+			return errors.New("boom")
+		}
+		return r.deliver(ctx, d)
+	}, assembler.Config{})
+
+	add := func(w *client.Webhook) error { return a.AddWebhook(context.Background(), w) }
+	for _, w := range []*client.Webhook{
+		webhook("w1", message("c", 1, 2, "one")),
+		webhook("w2", message("c", 2, 2, "two")),
+		webhook("w2", message("c", 2, 2, "two")),
+		webhook("w3", message("c", 1, 1, "anything else?")),
+		webhook("w3", message("c", 1, 1, "anything else?")),
+		webhook("w4", message("c", 1, 1, "anything else?")),
+	} {
+		if err := add(w); err != nil {
+			t.Fatalf("AddWebhook(%s): %v", w.ID, err)
+		}
+	}
+
+	// A webhook that failed to be delivered is handled again when retried.
+	fail.Store(true)
+	if err := add(webhook("w5", message("c", 1, 1, "retried"))); err == nil {
+		t.Fatal("expected the delivery to fail")
+	}
+	fail.Store(false)
+	if err := add(webhook("w5", message("c", 1, 1, "retried"))); err != nil {
+		t.Fatalf("AddWebhook(w5): %v", err)
+	}
+
+	want := []string{"one", "two", "anything else?", "anything else?", "retried"}
+	if got := r.bodies(); !reflect.DeepEqual(got, want) {
+		t.Errorf("delivered %q, want %q", got, want)
+	}
+}
+
+func TestAssemblerSlowDeliveryOnlyBlocksItsConversation(t *testing.T) {
+	release := make(chan struct{})
+	delivered := make(chan string, 2)
+	a := assembler.New(func(_ context.Context, d assembler.Delivery) error {
+		if d.ConversationID == "slow" {
+			<-release
+		}
+		delivered <- d.ConversationID
+		return nil
+	}, assembler.Config{})
+
+	go func() { _ = a.Add(context.Background(), message("slow", 1, 1, "one")) }()
+
+	select {
+	case <-delivered:
+		t.Fatal("slow delivery finished early")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- a.Add(context.Background(), message("fast", 1, 2, "one")) }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("a slow delivery blocked another conversation")
+	}
+	if got := <-delivered; got != "fast" {
+		t.Errorf("delivered %q, want fast", got)
+	}
+
+	close(release)
+	if got := <-delivered; got != "slow" {
+		t.Errorf("delivered %q, want slow", got)
+	}
+}
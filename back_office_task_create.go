@@ -48,19 +48,9 @@ type BackOfficeTaskAttachment struct {
 // CreateBackOfficeTask submits a new back-office task for AI processing.
 //
 // Note: requires a Public API key.
-func (c *Client) CreateBackOfficeTask(ctx context.Context, p BackOfficeTaskCreateParams) (*BackOfficeTask, error) {
-	rsp, err := c.makeRequest(ctx, http.MethodPost, "back-office-tasks", p)
-	if err != nil {
-		return nil, err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return nil, err
-	}
-
+func (c *Client) CreateBackOfficeTask(ctx context.Context, p BackOfficeTaskCreateParams, opts ...CallOption) (*BackOfficeTask, error) {
 	var task BackOfficeTask
-	if err := json.NewDecoder(rsp.Body).Decode(&task); err != nil {
+	if err := c.do(ctx, "CreateBackOfficeTask", http.MethodPost, "back-office-tasks", p, &task, opts...); err != nil {
 		return nil, err
 	}
 	return &task, nil
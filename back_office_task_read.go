@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 )
@@ -10,19 +9,9 @@ import (
 // ReadBackOfficeTask retrieves the current state of a back-office task.
 //
 // Note: requires a Public API key.
-func (c *Client) ReadBackOfficeTask(ctx context.Context, taskID string) (*BackOfficeTask, error) {
-	rsp, err := c.makeRequest(ctx, http.MethodGet, fmt.Sprintf("back-office-tasks/%s/read", taskID), nil)
-	if err != nil {
-		return nil, err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return nil, err
-	}
-
+func (c *Client) ReadBackOfficeTask(ctx context.Context, taskID string, opts ...CallOption) (*BackOfficeTask, error) {
 	var task BackOfficeTask
-	if err := json.NewDecoder(rsp.Body).Decode(&task); err != nil {
+	if err := c.do(ctx, "ReadBackOfficeTask", http.MethodGet, fmt.Sprintf("back-office-tasks/%s/read", taskID), nil, &task, opts...); err != nil {
 		return nil, err
 	}
 	return &task, nil
@@ -0,0 +1,57 @@
+package client
+
+import "time"
+
+// CallOption customises a single API call. Every Client method that calls the
+// API accepts them as trailing arguments.
+type CallOption interface{ isCallOption() }
+
+// WithCallTimeout limits how long the call may take, including any retries.
+func WithCallTimeout(timeout time.Duration) CallOption {
+	return callTimeoutOption{timeout}
+}
+
+// WithCallHeader adds a header to the call's HTTP request(s). An
+// Idempotency-Key given this way is used instead of any other key (see
+// WithCallIdempotencyKey).
+func WithCallHeader(key, value string) CallOption {
+	return callHeaderOption{key, value}
+}
+
+// WithCallURL overrides the client's base URL for the call (e.g. to target a
+// canary deployment).
+func WithCallURL(url string) CallOption {
+	return callURLOption{url}
+}
+
+// WithCallRetries overrides the number of times the call will be retried if it
+// fails with a transient error (see WithRetries).
+func WithCallRetries(retries int) CallOption {
+	return callRetriesOption{retries}
+}
+
+// WithCallIdempotencyKey sets the Idempotency-Key header sent with the call,
+// instead of having the client generate one.
+func WithCallIdempotencyKey(key string) CallOption {
+	return callIdempotencyKeyOption{key}
+}
+
+// WithResponseCapture populates meta with the details of the call's HTTP
+// response. It is equivalent to calling ContextWithResponseCapture.
+func WithResponseCapture(meta *ResponseMeta) CallOption {
+	return responseCaptureOption{meta}
+}
+
+type callTimeoutOption struct{ timeout time.Duration }
+type callHeaderOption struct{ key, value string }
+type callURLOption struct{ url string }
+type callRetriesOption struct{ retries int }
+type callIdempotencyKeyOption struct{ key string }
+type responseCaptureOption struct{ meta *ResponseMeta }
+
+func (callTimeoutOption) isCallOption()        {}
+func (callHeaderOption) isCallOption()         {}
+func (callURLOption) isCallOption()            {}
+func (callRetriesOption) isCallOption()        {}
+func (callIdempotencyKeyOption) isCallOption() {}
+func (responseCaptureOption) isCallOption()    {}
@@ -0,0 +1,331 @@
+// Package campaign runs outbound campaigns (e.g. payment reminders): it starts
+// an outbound conversation for each customer in a list, within the procedure's
+// daily limit, and records its progress so it can be resumed after a crash.
+//
+//	progress, err := campaign.OpenFile("reminders.jsonl")
+//	if err != nil {
+//		...
+//	}
+//	defer progress.Close()
+//
+//	runner, err := campaign.New(c, progress, campaign.Config{
+//		Name:           "payment-reminders-2024-06",
+//		ProcedureID:    "payment-reminder",
+//		CustomerSource: client.CustomerSourceIntercom,
+//	})
+//	if err != nil {
+//		...
+//	}
+//	summary, err := runner.Run(ctx, recipients)
+package campaign
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	client "github.com/gradientlabs-ai/gradientlabs-go"
+)
+
+// ErrDailyLimitReached is returned by Runner.Run when the procedure's daily
+// limit has been reached and Config.WaitForNextDay is false. Run the campaign
+// again the next day to continue.
+var ErrDailyLimitReached = errors.New("campaign: procedure daily limit reached")
+
+// API is the subset of client.API used by a Runner.
+type API interface {
+	ReadProcedure(ctx context.Context, procedureID string, opts ...client.CallOption) (*client.Procedure, error)
+	ListConversations(ctx context.Context, p *client.ListConversationsParams, opts ...client.CallOption) (*client.ListConversationsResponse, error)
+	StartOutboundConversation(ctx context.Context, p client.StartOutboundConversationParams, opts ...client.CallOption) (*client.StartOutboundConversationResponse, error)
+}
+
+// Recipient is a customer to contact.
+type Recipient struct {
+	// CustomerID is the customer's identifier in your support platform.
+	CustomerID string `json:"customer_id"`
+
+	// Subject and Body optionally override the campaign's initial message for
+	// this customer.
+	Subject string `json:"subject,omitempty"`
+	Body    string `json:"body,omitempty"`
+
+	// Resources optionally provides data about the customer to the agent.
+	Resources client.Resources `json:"resources,omitempty"`
+}
+
+// Config configures a campaign.
+type Config struct {
+	// Name identifies the campaign (e.g. "payment-reminders-2024-06"). It is
+	// part of the idempotency key used to start each conversation, so it must
+	// stay the same when a campaign is resumed, and be different for each new
+	// campaign that contacts the same customers with the same procedure.
+	Name string
+
+	// ProcedureID is the outbound procedure the conversations follow.
+	ProcedureID string
+
+	// CustomerSource is the source of the customer data.
+	CustomerSource client.CustomerSource
+
+	// SupportPlatform optionally chooses where conversations are created.
+	SupportPlatform client.SupportPlatform
+
+	// Channel optionally chooses the channel. Defaults to email.
+	Channel client.Channel
+
+	// Subject and Body optionally set the initial message. If omitted, the
+	// agent generates one.
+	Subject, Body string
+
+	// Interval is the minimum time between starting conversations, to spread
+	// the load on your support team.
+	Interval time.Duration
+
+	// WaitForNextDay makes Run wait until midnight UTC when the procedure's
+	// daily limit is reached, instead of returning ErrDailyLimitReached.
+	WaitForNextDay bool
+
+	// DailyUsage optionally returns how many conversations have used the
+	// procedure so far on the given day (in UTC), including those started by
+	// other campaigns and integrations. It is called before each conversation
+	// is started. If nil, only the conversations started by this campaign are
+	// counted, which undercounts if anything else uses the procedure.
+	DailyUsage func(ctx context.Context, procedureID string, day time.Time) (int, error)
+
+	// OnRecord is optionally called with each recipient's outcome.
+	OnRecord func(Record)
+}
+
+// Summary counts the outcomes of a campaign, including those from previous
+// runs.
+type Summary struct {
+	Started   int
+	Skipped   int
+	Failed    int
+	Remaining int
+
+	// ConversationIDs maps each customer ID to the conversation started for
+	// them.
+	ConversationIDs map[string]string
+}
+
+// Runner runs a campaign. Use New to create one.
+type Runner struct {
+	api      API
+	progress Progress
+	cfg      Config
+	now      func() time.Time
+}
+
+// New creates a Runner that records its progress to progress.
+func New(api API, progress Progress, cfg Config) (*Runner, error) {
+	if cfg.Name == "" {
+		return nil, errors.New("campaign: name is required")
+	}
+	if cfg.ProcedureID == "" {
+		return nil, errors.New("campaign: procedure ID is required")
+	}
+	if cfg.CustomerSource == "" {
+		return nil, errors.New("campaign: customer source is required")
+	}
+	return &Runner{api: api, progress: progress, cfg: cfg, now: time.Now}, nil
+}
+
+// Run starts a conversation for each recipient that doesn't already have one
+// from a previous run. Customers with an active conversation are skipped, and
+// recipients that were skipped or failed previously are checked again.
+//
+// The procedure's daily limit is checked against Config.DailyUsage if it is
+// set, or else against the conversations started by this campaign.
+func (r *Runner) Run(ctx context.Context, recipients []Recipient) (*Summary, error) {
+	proc, err := r.api.ReadProcedure(ctx, r.cfg.ProcedureID)
+	if err != nil {
+		return nil, fmt.Errorf("campaign: reading procedure: %w", err)
+	}
+
+	records, err := r.progress.Records(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("campaign: reading progress: %w", err)
+	}
+
+	latest := make(map[string]Record, len(records))
+	startedToday := 0
+	for _, rec := range records {
+		latest[rec.CustomerID] = rec
+		if rec.Outcome == OutcomeStarted && sameDay(rec.Time, r.now()) {
+			startedToday++
+		}
+	}
+
+	summary := &Summary{ConversationIDs: make(map[string]string)}
+	summarise := func() *Summary {
+		summary.Started, summary.Skipped, summary.Failed, summary.Remaining = 0, 0, 0, 0
+		for _, rcpt := range recipients {
+			rec, ok := latest[rcpt.CustomerID]
+			switch {
+			case !ok:
+				summary.Remaining++
+			case rec.Outcome == OutcomeStarted:
+				summary.Started++
+				summary.ConversationIDs[rec.CustomerID] = rec.ConversationID
+			case rec.Outcome == OutcomeSkipped:
+				summary.Skipped++
+			case rec.Outcome == OutcomeFailed:
+				summary.Failed++
+			}
+		}
+		return summary
+	}
+
+	var lastStart time.Time
+	for _, rcpt := range recipients {
+		if rec, ok := latest[rcpt.CustomerID]; ok && rec.Outcome == OutcomeStarted {
+			continue
+		}
+
+		for proc.IsDailyLimited {
+			used, err := r.dailyUsage(ctx, startedToday)
+			if err != nil {
+				return summarise(), err
+			}
+			if used < proc.MaxDailyConversations {
+				break
+			}
+			if !r.cfg.WaitForNextDay {
+				return summarise(), ErrDailyLimitReached
+			}
+			if err := sleep(ctx, nextDay(r.now()).Sub(r.now())); err != nil {
+				return summarise(), err
+			}
+			startedToday = 0
+		}
+
+		if !lastStart.IsZero() {
+			if err := sleep(ctx, r.cfg.Interval-r.now().Sub(lastStart)); err != nil {
+				return summarise(), err
+			}
+		}
+
+		rec, err := r.contact(ctx, rcpt)
+		if err != nil {
+			return summarise(), err
+		}
+		if rec.Outcome == OutcomeStarted {
+			startedToday++
+			lastStart = rec.Time
+		}
+
+		if err := r.progress.Save(ctx, rec); err != nil {
+			return summarise(), fmt.Errorf("campaign: saving progress: %w", err)
+		}
+		latest[rec.CustomerID] = rec
+		if r.cfg.OnRecord != nil {
+			r.cfg.OnRecord(rec)
+		}
+	}
+	return summarise(), nil
+}
+
+// dailyUsage returns how many conversations have used the procedure today,
+// given how many this campaign has started.
+func (r *Runner) dailyUsage(ctx context.Context, startedToday int) (int, error) {
+	if r.cfg.DailyUsage == nil {
+		return startedToday, nil
+	}
+	used, err := r.cfg.DailyUsage(ctx, r.cfg.ProcedureID, r.now().UTC())
+	if err != nil {
+		return 0, fmt.Errorf("campaign: reading daily usage: %w", err)
+	}
+	// The usage may not yet reflect the conversations just started.
+	if used < startedToday {
+		used = startedToday
+	}
+	return used, nil
+}
+
+// contact starts a conversation with the recipient, unless they already have
+// an active one. Errors from the API are recorded rather than returned, unless
+// the context is done.
+func (r *Runner) contact(ctx context.Context, rcpt Recipient) (Record, error) {
+	rec := Record{CustomerID: rcpt.CustomerID}
+
+	active, err := r.api.ListConversations(ctx, &client.ListConversationsParams{
+		CustomerID: rcpt.CustomerID,
+		Status:     []client.Status{client.StatusActive, client.StatusObserving},
+		Limit:      1,
+	})
+	if err == nil && len(active.Conversations) != 0 {
+		rec.Outcome = OutcomeSkipped
+		rec.ConversationID = active.Conversations[0].ID
+		rec.Time = r.now()
+		return rec, nil
+	}
+
+	if err == nil {
+		var rsp *client.StartOutboundConversationResponse
+		rsp, err = r.api.StartOutboundConversation(ctx, r.params(rcpt),
+			// The key is derived from the campaign and recipient so that, if we
+			// crash after starting the conversation but before saving the
+			// record, resuming the campaign doesn't contact the customer twice.
+			client.WithCallIdempotencyKey(fmt.Sprintf("campaign-%s-%s-%s", r.cfg.Name, r.cfg.ProcedureID, rcpt.CustomerID)),
+		)
+		if err == nil {
+			rec.Outcome = OutcomeStarted
+			rec.ConversationID = rsp.ConversationID
+		}
+	}
+
+	if err != nil {
+		if ctx.Err() != nil {
+			return rec, ctx.Err()
+		}
+		rec.Outcome = OutcomeFailed
+		rec.Error = err.Error()
+	}
+	rec.Time = r.now()
+	return rec, nil
+}
+
+func (r *Runner) params(rcpt Recipient) client.StartOutboundConversationParams {
+	p := client.StartOutboundConversationParams{
+		CustomerID:      rcpt.CustomerID,
+		CustomerSource:  r.cfg.CustomerSource,
+		ProcedureID:     r.cfg.ProcedureID,
+		SupportPlatform: r.cfg.SupportPlatform,
+		Channel:         r.cfg.Channel,
+		Subject:         r.cfg.Subject,
+		Body:            r.cfg.Body,
+		Resources:       rcpt.Resources,
+	}
+	if rcpt.Body != "" {
+		p.Subject, p.Body = rcpt.Subject, rcpt.Body
+	}
+	return p
+}
+
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.UTC().Date()
+	by, bm, bd := b.UTC().Date()
+	return ay == by && am == bm && ad == bd
+}
+
+func nextDay(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d+1, 0, 0, 0, 0, time.UTC)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
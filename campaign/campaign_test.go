@@ -0,0 +1,314 @@
+package campaign_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	client "github.com/gradientlabs-ai/gradientlabs-go"
+	"github.com/gradientlabs-ai/gradientlabs-go/campaign"
+)
+
+// fakeServer serves the endpoints used by a Runner.
+type fakeServer struct {
+	mu        sync.Mutex
+	procedure client.Procedure
+	active    map[string]bool
+	failFor   map[string]bool
+	started   []client.StartOutboundConversationParams
+	keys      []string
+}
+
+func newFakeServer(t *testing.T, s *fakeServer) *client.Client {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		switch {
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/procedure/"):
+			_ = json.NewEncoder(w).Encode(s.procedure)
+		case r.Method == http.MethodGet && r.URL.Path == "/conversations":
+			rsp := client.ListConversationsResponse{}
+			if id := r.URL.Query().Get("customer_id"); s.active[id] {
+				rsp.Conversations = []*client.Conversation{{ID: "active-" + id}}
+			}
+			_ = json.NewEncoder(w).Encode(rsp)
+		case r.Method == http.MethodPost && r.URL.Path == "/outbound/conversations":
+			var p client.StartOutboundConversationParams
+			_ = json.NewDecoder(r.Body).Decode(&p)
+			if s.failFor[p.CustomerID] {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"message":"bad customer"}`))
+				return
+			}
+			s.started = append(s.started, p)
+			s.keys = append(s.keys, r.Header.Get("Idempotency-Key"))
+			_ = json.NewEncoder(w).Encode(client.StartOutboundConversationResponse{ConversationID: "conv-" + p.CustomerID})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := client.NewClient(client.WithURL(srv.URL), client.WithAPIKey("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func recipients(ids ...string) []campaign.Recipient {
+	rs := make([]campaign.Recipient, len(ids))
+	for i, id := range ids {
+		rs[i] = campaign.Recipient{CustomerID: id}
+	}
+	return rs
+}
+
+func config() campaign.Config {
+	return campaign.Config{
+		Name:           "reminders-june",
+		ProcedureID:    "proc-1",
+		CustomerSource: client.CustomerSourceIntercom,
+	}
+}
+
+func TestNew(t *testing.T) {
+	testCases := map[string]struct {
+		modify func(*campaign.Config)
+		err    string
+	}{
+		"valid":              {modify: func(*campaign.Config) {}},
+		"no name":            {modify: func(c *campaign.Config) { c.Name = "" }, err: "name is required"},
+		"no procedure":       {modify: func(c *campaign.Config) { c.ProcedureID = "" }, err: "procedure ID is required"},
+		"no customer source": {modify: func(c *campaign.Config) { c.CustomerSource = "" }, err: "customer source is required"},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			cfg := config()
+			tc.modify(&cfg)
+			_, err := campaign.New(nil, &campaign.Memory{}, cfg)
+			if tc.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("expected error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	srv := &fakeServer{
+		active:  map[string]bool{"busy": true},
+		failFor: map[string]bool{"broken": true},
+	}
+	c := newFakeServer(t, srv)
+
+	var recorded []campaign.Record
+	cfg := config()
+	cfg.OnRecord = func(r campaign.Record) { recorded = append(recorded, r) }
+
+	runner, err := campaign.New(c, &campaign.Memory{}, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	summary, err := runner.Run(context.Background(), recipients("alice", "busy", "broken"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if summary.Started != 1 || summary.Skipped != 1 || summary.Failed != 1 || summary.Remaining != 0 {
+		t.Fatalf("unexpected summary: %+v", summary)
+	}
+	if got := summary.ConversationIDs["alice"]; got != "conv-alice" {
+		t.Fatalf("expected conversation ID conv-alice, got %q", got)
+	}
+	if len(recorded) != 3 {
+		t.Fatalf("expected 3 records, got %d", len(recorded))
+	}
+	if recorded[2].Error == "" {
+		t.Fatal("expected the failed record to include the error")
+	}
+}
+
+func TestRunIdempotencyKey(t *testing.T) {
+	srv := &fakeServer{}
+	c := newFakeServer(t, srv)
+
+	for _, name := range []string{"reminders-june", "reminders-july"} {
+		cfg := config()
+		cfg.Name = name
+		runner, err := campaign.New(c, &campaign.Memory{}, cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := runner.Run(context.Background(), recipients("alice")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(srv.keys) != 2 {
+		t.Fatalf("expected 2 conversations, got %d", len(srv.keys))
+	}
+	if want := "campaign-reminders-june-proc-1-alice"; srv.keys[0] != want {
+		t.Fatalf("expected key %q, got %q", want, srv.keys[0])
+	}
+	if srv.keys[0] == srv.keys[1] {
+		t.Fatalf("expected different campaigns to use different keys, both used %q", srv.keys[0])
+	}
+}
+
+func TestRunResume(t *testing.T) {
+	srv := &fakeServer{}
+	c := newFakeServer(t, srv)
+
+	progress := &campaign.Memory{}
+	now := time.Now()
+	for _, rec := range []campaign.Record{
+		{CustomerID: "started", Outcome: campaign.OutcomeStarted, ConversationID: "conv-old", Time: now},
+		{CustomerID: "skipped", Outcome: campaign.OutcomeSkipped, ConversationID: "active-skipped", Time: now},
+		{CustomerID: "failed", Outcome: campaign.OutcomeFailed, Error: "boom", Time: now},
+	} {
+		if err := progress.Save(context.Background(), rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	runner, err := campaign.New(c, progress, config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	summary, err := runner.Run(context.Background(), recipients("started", "skipped", "failed", "new"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []string
+	for _, p := range srv.started {
+		ids = append(ids, p.CustomerID)
+	}
+	if got, want := strings.Join(ids, ","), "skipped,failed,new"; got != want {
+		t.Fatalf("expected conversations to be started for %s, got %s", want, got)
+	}
+	if summary.Started != 4 || summary.Skipped != 0 || summary.Failed != 0 {
+		t.Fatalf("unexpected summary: %+v", summary)
+	}
+	if got := summary.ConversationIDs["started"]; got != "conv-old" {
+		t.Fatalf("expected the previous conversation to be kept, got %q", got)
+	}
+}
+
+func TestRunDailyLimit(t *testing.T) {
+	testCases := map[string]struct {
+		previous int
+		other    int
+		lagging  bool
+		usageErr error
+		started  int
+		err      error
+	}{
+		"within limit": {
+			started: 3,
+		},
+		"previous runs count": {
+			previous: 1,
+			started:  2,
+			err:      campaign.ErrDailyLimitReached,
+		},
+		"other usage counts": {
+			other:   2,
+			started: 1,
+			err:     campaign.ErrDailyLimitReached,
+		},
+		"usage lagging behind the campaign": {
+			lagging: true,
+			started: 3,
+		},
+		"usage error": {
+			usageErr: errors.New("boom"),
+			err:      errors.New("campaign: reading daily usage: boom"),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			srv := &fakeServer{procedure: client.Procedure{IsDailyLimited: true, MaxDailyConversations: 3}}
+			c := newFakeServer(t, srv)
+
+			progress := &campaign.Memory{}
+			for i := 0; i < tc.previous; i++ {
+				_ = progress.Save(context.Background(), campaign.Record{
+					CustomerID: "previous",
+					Outcome:    campaign.OutcomeStarted,
+					Time:       time.Now(),
+				})
+			}
+
+			cfg := config()
+			if tc.other != 0 || tc.lagging || tc.usageErr != nil {
+				cfg.DailyUsage = func(context.Context, string, time.Time) (int, error) {
+					if tc.lagging {
+						return 0, tc.usageErr
+					}
+					srv.mu.Lock()
+					defer srv.mu.Unlock()
+					return tc.other + len(srv.started), tc.usageErr
+				}
+			}
+			runner, err := campaign.New(c, progress, cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = runner.Run(context.Background(), recipients("a", "b", "c"))
+			switch {
+			case tc.err == nil && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case tc.err != nil && (err == nil || (!errors.Is(err, tc.err) && err.Error() != tc.err.Error())):
+				t.Fatalf("expected error %v, got %v", tc.err, err)
+			}
+			if len(srv.started) != tc.started {
+				t.Fatalf("expected %d conversations to be started, got %d", tc.started, len(srv.started))
+			}
+		})
+	}
+}
+
+func TestRunDailyUsageArguments(t *testing.T) {
+	srv := &fakeServer{procedure: client.Procedure{IsDailyLimited: true, MaxDailyConversations: 10}}
+	c := newFakeServer(t, srv)
+
+	var calls int
+	cfg := config()
+	cfg.DailyUsage = func(_ context.Context, procedureID string, day time.Time) (int, error) {
+		calls++
+		if procedureID != "proc-1" {
+			t.Errorf("expected procedure proc-1, got %q", procedureID)
+		}
+		if day.Location() != time.UTC {
+			t.Errorf("expected day in UTC, got %v", day.Location())
+		}
+		return 0, nil
+	}
+	runner, err := campaign.New(c, &campaign.Memory{}, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runner.Run(context.Background(), recipients("a", "b")); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected usage to be checked before each conversation, got %d calls", calls)
+	}
+}
@@ -0,0 +1,203 @@
+package campaign
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sync"
+	"time"
+)
+
+// Outcome describes what happened to a recipient.
+type Outcome string
+
+const (
+	// OutcomeStarted means an outbound conversation was started.
+	OutcomeStarted Outcome = "started"
+
+	// OutcomeSkipped means the customer already had an active conversation.
+	// Skipped recipients are checked again when the campaign is resumed.
+	OutcomeSkipped Outcome = "skipped"
+
+	// OutcomeFailed means the conversation couldn't be started. Failed
+	// recipients are retried when the campaign is resumed.
+	OutcomeFailed Outcome = "failed"
+)
+
+// Record is the outcome for a single recipient.
+type Record struct {
+	// CustomerID identifies the recipient.
+	CustomerID string `json:"customer_id"`
+
+	// Outcome is what happened to the recipient.
+	Outcome Outcome `json:"outcome"`
+
+	// ConversationID identifies the conversation that was started, if any.
+	ConversationID string `json:"conversation_id,omitempty"`
+
+	// Error describes why the conversation couldn't be started, if it failed.
+	Error string `json:"error,omitempty"`
+
+	// Time is when the outcome was recorded.
+	Time time.Time `json:"time"`
+}
+
+// Progress stores a campaign's records, so it can be resumed after a crash.
+type Progress interface {
+	// Records returns all of the records saved so far, in order.
+	Records(ctx context.Context) ([]Record, error)
+
+	// Save adds a record. It must not return until the record is durable.
+	Save(ctx context.Context, r Record) error
+}
+
+// Memory is a Progress held in memory, for campaigns that don't need to be
+// resumed. It is safe for concurrent use.
+type Memory struct {
+	mu      sync.Mutex
+	records []Record
+}
+
+// Records satisfies the Progress interface.
+func (m *Memory) Records(context.Context) ([]Record, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]Record(nil), m.records...), nil
+}
+
+// Save satisfies the Progress interface.
+func (m *Memory) Save(_ context.Context, r Record) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.records = append(m.records, r)
+	return nil
+}
+
+// File is a Progress that appends one JSON record per line (JSONL) to a file,
+// syncing after every record. It is safe for concurrent use.
+type File struct {
+	mu   sync.Mutex
+	path string
+	f    *os.File
+}
+
+// OpenFile opens (or creates) the progress file at path. A partially written
+// final line (e.g. from a crash mid-write) is truncated, so that new records
+// aren't appended to it.
+func OpenFile(path string) (*File, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	if err := truncatePartialLine(f); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("campaign progress: %w", err)
+	}
+	return &File{path: path, f: f}, nil
+}
+
+// truncatePartialLine removes anything after the file's final newline.
+func truncatePartialLine(f *os.File) error {
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	end := info.Size()
+	buf := make([]byte, 4096)
+	for end > 0 {
+		n := int64(len(buf))
+		if n > end {
+			n = end
+		}
+		if _, err := f.ReadAt(buf[:n], end-n); err != nil {
+			return err
+		}
+		if i := bytes.LastIndexByte(buf[:n], '\n'); i >= 0 {
+			end = end - n + int64(i) + 1
+			break
+		}
+		end -= n
+	}
+
+	if end == info.Size() {
+		return nil
+	}
+	if err := f.Truncate(end); err != nil {
+		return err
+	}
+	return f.Sync()
+}
+
+// Records satisfies the Progress interface. A partially written final line
+// (e.g. from a crash mid-write) is ignored.
+func (p *File) Records(context.Context) ([]Record, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	f, err := os.Open(p.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var (
+		records []Record
+		bad     error
+	)
+	sc := bufio.NewScanner(f)
+	line := 0
+	for sc.Scan() {
+		line++
+		if len(sc.Bytes()) == 0 {
+			continue
+		}
+		if bad != nil {
+			// Only the final line may be corrupt.
+			return nil, bad
+		}
+
+		var r Record
+		if err := json.Unmarshal(sc.Bytes(), &r); err != nil {
+			bad = fmt.Errorf("campaign progress line %d: %w", line, err)
+			continue
+		}
+		records = append(records, r)
+	}
+	return records, sc.Err()
+}
+
+// Save satisfies the Progress interface.
+func (p *File) Save(_ context.Context, r Record) error {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, err := p.f.Write(b); err != nil {
+		return err
+	}
+	return p.f.Sync()
+}
+
+// Close closes the underlying file.
+func (p *File) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.f.Close()
+}
@@ -0,0 +1,139 @@
+package campaign_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gradientlabs-ai/gradientlabs-go/campaign"
+)
+
+func TestFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "progress.jsonl")
+	ctx := context.Background()
+
+	p, err := campaign.OpenFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []campaign.Record{
+		{CustomerID: "alice", Outcome: campaign.OutcomeStarted, ConversationID: "conv-1", Time: time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)},
+		{CustomerID: "bob", Outcome: campaign.OutcomeFailed, Error: "boom", Time: time.Date(2024, 6, 1, 10, 1, 0, 0, time.UTC)},
+	}
+	for _, r := range want {
+		if err := p.Save(ctx, r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := p.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err = campaign.OpenFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+
+	got, err := p.Records(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d records, got %d", len(want), len(got))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("record %d: expected %+v, got %+v", i, want[i], got[i])
+		}
+	}
+}
+
+func TestFileCorruptLine(t *testing.T) {
+	testCases := map[string]struct {
+		contents string
+		records  int
+		err      bool
+	}{
+		"partial final line": {
+			contents: `{"customer_id":"alice","outcome":"started"}` + "\n" + `{"customer_id":"bo`,
+			records:  1,
+		},
+		"corrupt earlier line": {
+			contents: `{"customer_id":"al` + "\n" + `{"customer_id":"bob","outcome":"started"}` + "\n",
+			err:      true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "progress.jsonl")
+			if err := os.WriteFile(path, []byte(tc.contents), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			p, err := campaign.OpenFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer p.Close()
+
+			records, err := p.Records(context.Background())
+			if tc.err {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(records) != tc.records {
+				t.Fatalf("expected %d records, got %d", tc.records, len(records))
+			}
+		})
+	}
+}
+
+func TestFileResumeAfterPartialWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "progress.jsonl")
+	ctx := context.Background()
+
+	// Simulate a crash part way through writing the second record.
+	contents := `{"customer_id":"alice","outcome":"started"}` + "\n" + `{"customer_id":"bo`
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := campaign.OpenFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"bob", "carol"} {
+		if err := p.Save(ctx, campaign.Record{CustomerID: id, Outcome: campaign.OutcomeStarted}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := p.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err = campaign.OpenFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+
+	records, err := p.Records(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, r := range records {
+		ids = append(ids, r.CustomerID)
+	}
+	if got, want := fmt.Sprint(ids), "[alice bob carol]"; got != want {
+		t.Fatalf("expected records for %s, got %s", want, got)
+	}
+}
@@ -20,15 +20,22 @@ var userAgent = fmt.Sprintf("Gradient-Labs-Go (%s/%s)", version, runtime.Version
 
 // Client provides access to the Gradient Labs API. Use NewClient to create one.
 type Client struct {
-	url, apiKey     string
+	url             string
+	credentials     CredentialsProvider
 	httpClient      *http.Client
 	webhookVerifier *WebhookVerifier
+	maxRetries      int
+	idempotency     IdempotencyMode
+	journal         Journal
+	journalErrors   func(error)
+	middleware      []Middleware
+	handler         Handler
 }
 
 // NewClient creates a client with the given options.
 //
-// Note: the WithAPIKey option is required, as is WithWebhookSigningKey if you
-// intend to receive webhooks.
+// Note: the WithAPIKey (or WithCredentials) option is required, as is
+// WithWebhookSigningKey if you intend to receive webhooks.
 func NewClient(opts ...Option) (*Client, error) {
 	c := &Client{
 		url:        defaultURL,
@@ -43,16 +50,40 @@ func NewClient(opts ...Option) (*Client, error) {
 		case urlOption:
 			c.url = t.url
 		case apiKeyOption:
-			c.apiKey = t.apiKey
+			c.credentials = StaticCredentials(t.apiKey)
+		case credentialsOption:
+			c.credentials = t.provider
 		case transportOption:
 			c.httpClient.Transport = t.transport
 		case webhookSigningKeyOption:
 			c.webhookVerifier.secret = t.signingKey
 		case webhookLeewayOption:
 			c.webhookVerifier.leeway = t.leeway
+		case retriesOption:
+			c.maxRetries = t.retries
+		case idempotencyOption:
+			c.idempotency = t.mode
+		case journalOption:
+			c.journal = t.journal
+		case journalErrorHandlerOption:
+			c.journalErrors = t.fn
+		case middlewareOption:
+			c.middleware = append(c.middleware, t.middleware...)
 		}
 	}
 
+	if c.credentials == nil {
+		c.credentials = StaticCredentials("")
+	}
+
+	// The journal sees calls after all other middleware, so it records exactly
+	// what was sent.
+	mw := c.middleware
+	if c.journal != nil {
+		mw = append(mw[:len(mw):len(mw)], journalMiddleware(c.journal, c.journalErrors))
+	}
+	c.handler = chainMiddleware(c.send, mw)
+
 	return c, nil
 }
 
@@ -67,7 +98,8 @@ func WithTransport(rt http.RoundTripper) Option {
 	return transportOption{rt}
 }
 
-// WithAPIKey sets the client's API key.
+// WithAPIKey sets the client's API key. Use WithCredentials instead if the key
+// needs to be rotated without rebuilding the client.
 func WithAPIKey(key string) Option {
 	return apiKeyOption{key}
 }
@@ -99,29 +131,33 @@ func (webhookLeewayOption) isClientOption()     {}
 // Option customises the Client.
 type Option interface{ isClientOption() }
 
-func (c *Client) makeRequest(ctx context.Context, method string, path string, body any) (*http.Response, error) {
-	url := fmt.Sprintf("%s/%s", c.url, path)
+func (c *Client) makeRequest(ctx context.Context, apiKey string, r *Request) (*http.Response, error) {
+	url := fmt.Sprintf("%s/%s", r.BaseURL, r.Path)
 
 	var bodyReader io.Reader
-	if body != nil {
-		b, err := json.Marshal(body)
+	if r.Params != nil {
+		b, err := json.Marshal(r.Params)
 		if err != nil {
 			return nil, err
 		}
 		bodyReader = bytes.NewReader(b)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
+	req, err := http.NewRequestWithContext(ctx, r.Method, url, bodyReader)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", apiKey))
 	req.Header.Add("Accept", "application/json")
 	req.Header.Add("User-Agent", userAgent)
 
-	if body != nil {
+	if r.Params != nil {
 		req.Header.Add("Content-Type", "application/json")
 	}
 
+	for k, v := range r.Header {
+		req.Header[k] = v
+	}
+
 	return c.httpClient.Do(req)
 }
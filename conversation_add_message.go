@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"time"
@@ -119,19 +118,9 @@ type Message struct {
 }
 
 // AddMessage records a message sent by the customer or a human agent.
-func (c *Client) AddMessage(ctx context.Context, conversationID string, p AddMessageParams) (*Message, error) {
-	rsp, err := c.makeRequest(ctx, http.MethodPost, fmt.Sprintf("conversations/%s/messages", conversationID), p)
-	if err != nil {
-		return nil, err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return nil, err
-	}
-
+func (c *Client) AddMessage(ctx context.Context, conversationID string, p AddMessageParams, opts ...CallOption) (*Message, error) {
 	var msg Message
-	if err := json.NewDecoder(rsp.Body).Decode(&msg); err != nil {
+	if err := c.do(ctx, "AddMessage", http.MethodPost, fmt.Sprintf("conversations/%s/messages", conversationID), p, &msg, opts...); err != nil {
 		return nil, err
 	}
 	return &msg, nil
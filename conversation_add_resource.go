@@ -27,15 +27,6 @@ import (
 //
 // Deprecated: this endpoint has been removed from the backend. Pass resources
 // via StartConversationParams.Resources or ConversationResumeParams.Resources instead.
-func (c *Client) AddResource(ctx context.Context, conversationID string, name string, resource any) error {
-	rsp, err := c.makeRequest(ctx, http.MethodPut, fmt.Sprintf("conversations/%s/resources/%s", conversationID, name), resource)
-	if err != nil {
-		return err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return err
-	}
-	return nil
+func (c *Client) AddResource(ctx context.Context, conversationID string, name string, resource any, opts ...CallOption) error {
+	return c.do(ctx, "AddResource", http.MethodPut, fmt.Sprintf("conversations/%s/resources/%s", conversationID, name), resource, nil, opts...)
 }
@@ -26,15 +26,6 @@ type AssignmentParams struct {
 }
 
 // AssignConversation assigns a conversation to a participant.
-func (c *Client) AssignConversation(ctx context.Context, conversationID string, p *AssignmentParams) error {
-	rsp, err := c.makeRequest(ctx, http.MethodPut, fmt.Sprintf("conversations/%s/assignee", conversationID), p)
-	if err != nil {
-		return err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return err
-	}
-	return nil
+func (c *Client) AssignConversation(ctx context.Context, conversationID string, p *AssignmentParams, opts ...CallOption) error {
+	return c.do(ctx, "AssignConversation", http.MethodPut, fmt.Sprintf("conversations/%s/assignee", conversationID), p, nil, opts...)
 }
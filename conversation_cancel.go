@@ -21,15 +21,6 @@ type CancelParams struct {
 // This is intended for cases where the conversation is being explicitly cancelled or terminated.
 // Use FinishConversation() when the conversation is has reached a natural 'end' state, such as it being
 // resolved or closed due to inactivity.
-func (c *Client) CancelConversation(ctx context.Context, conversationID string, p CancelParams) error {
-	rsp, err := c.makeRequest(ctx, http.MethodPut, fmt.Sprintf("conversations/%s/cancel", conversationID), p)
-	if err != nil {
-		return err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return err
-	}
-	return nil
+func (c *Client) CancelConversation(ctx context.Context, conversationID string, p CancelParams, opts ...CallOption) error {
+	return c.do(ctx, "CancelConversation", http.MethodPut, fmt.Sprintf("conversations/%s/cancel", conversationID), p, nil, opts...)
 }
@@ -44,7 +44,8 @@ type EventParams struct {
 	// If not given, this will default to the current time.
 	Timestamp *time.Time `json:"timestamp,omitempty"`
 
-	// IdempotencyKey optionally enables you to safely retry requests
+	// IdempotencyKey optionally enables you to safely retry requests. It is
+	// also sent as the Idempotency-Key header.
 	IdempotencyKey string `json:"idempotency_key,omitempty"`
 
 	// Body contains any text associated with the event (e.g. the contents of
@@ -53,15 +54,9 @@ type EventParams struct {
 }
 
 // AddConversationEvent records an event such as the customer started typing.
-func (c *Client) AddConversationEvent(ctx context.Context, conversationID string, p *EventParams) error {
-	rsp, err := c.makeRequest(ctx, http.MethodPost, fmt.Sprintf("conversations/%s/events", conversationID), p)
-	if err != nil {
-		return err
+func (c *Client) AddConversationEvent(ctx context.Context, conversationID string, p *EventParams, opts ...CallOption) error {
+	if p != nil && p.IdempotencyKey != "" {
+		ctx = ContextWithIdempotencyKey(ctx, p.IdempotencyKey)
 	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return err
-	}
-	return nil
+	return c.do(ctx, "AddConversationEvent", http.MethodPost, fmt.Sprintf("conversations/%s/events", conversationID), p, nil, opts...)
 }
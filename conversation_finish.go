@@ -21,15 +21,6 @@ type FinishParams struct {
 // A conversation finishes when it has come to its natural conclusion. This could be because
 // the customer's query has been resolved, a human agent or other automation has closed the chat,
 // or because the chat is being closed due to inactivity.
-func (c *Client) FinishConversation(ctx context.Context, conversationID string, p FinishParams) error {
-	rsp, err := c.makeRequest(ctx, http.MethodPut, fmt.Sprintf("conversations/%s/finish", conversationID), p)
-	if err != nil {
-		return err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return err
-	}
-	return nil
+func (c *Client) FinishConversation(ctx context.Context, conversationID string, p FinishParams, opts ...CallOption) error {
+	return c.do(ctx, "FinishConversation", http.MethodPut, fmt.Sprintf("conversations/%s/finish", conversationID), p, nil, opts...)
 }
@@ -0,0 +1,130 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// ListConversationsParams are the parameters to Client.ListConversations. All
+// filters are optional.
+type ListConversationsParams struct {
+	// Cursor is used to retrieve the next/previous page of the list.
+	Cursor string
+
+	// Limit is the maximum number of conversations to return per page.
+	Limit int
+
+	// Status filters conversations to those with any of the given statuses
+	// (e.g. StatusActive and StatusFailed to find stuck conversations).
+	Status []Status
+
+	// Channel filters conversations by channel.
+	Channel Channel
+
+	// CustomerID filters conversations by customer.
+	CustomerID string
+
+	// AssigneeID filters conversations by who they are assigned to.
+	AssigneeID string
+
+	// AssigneeType filters conversations by the type of participant they are
+	// assigned to.
+	AssigneeType ParticipantType
+
+	// Intent filters conversations by the latest intent the agent classified
+	// (see AgentMetadata.Intent).
+	Intent string
+
+	// CreatedAfter and CreatedBefore filter conversations by creation time.
+	CreatedAfter, CreatedBefore *time.Time
+
+	// UpdatedAfter and UpdatedBefore filter conversations by the time they
+	// were last updated.
+	UpdatedAfter, UpdatedBefore *time.Time
+}
+
+func (p *ListConversationsParams) query() url.Values {
+	q := make(url.Values)
+	if p == nil {
+		return q
+	}
+
+	setString := func(key, value string) {
+		if value != "" {
+			q.Set(key, value)
+		}
+	}
+	setTime := func(key string, t *time.Time) {
+		if t != nil {
+			q.Set(key, t.UTC().Format(time.RFC3339))
+		}
+	}
+
+	setString("cursor", p.Cursor)
+	if p.Limit > 0 {
+		q.Set("limit", strconv.Itoa(p.Limit))
+	}
+	for _, s := range p.Status {
+		q.Add("status", string(s))
+	}
+	setString("channel", string(p.Channel))
+	setString("customer_id", p.CustomerID)
+	setString("assignee_id", p.AssigneeID)
+	setString("assignee_type", string(p.AssigneeType))
+	setString("intent", p.Intent)
+	setTime("created_after", p.CreatedAfter)
+	setTime("created_before", p.CreatedBefore)
+	setTime("updated_after", p.UpdatedAfter)
+	setTime("updated_before", p.UpdatedBefore)
+	return q
+}
+
+type ListConversationsResponse struct {
+	// Conversations contains the page of conversations.
+	Conversations []*Conversation `json:"conversations"`
+
+	// Pagination contains the pagination-related information.
+	Pagination *PaginationInfo `json:"pagination"`
+}
+
+// ListConversations lists conversations matching the given filters, one page
+// at a time. Use IterateConversations to step through every page.
+func (c *Client) ListConversations(ctx context.Context, p *ListConversationsParams, opts ...CallOption) (*ListConversationsResponse, error) {
+	path := "conversations"
+	if q := p.query(); len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+
+	var result ListConversationsResponse
+	if err := c.do(ctx, "ListConversations", http.MethodGet, path, nil, &result, opts...); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ConversationLister is implemented by *Client (and ConversationAPI mocks).
+type ConversationLister interface {
+	ListConversations(ctx context.Context, p *ListConversationsParams, opts ...CallOption) (*ListConversationsResponse, error)
+}
+
+// IterateConversations returns an Iterator over all of the conversations
+// matching the given filters, starting from p.Cursor.
+func IterateConversations(api ConversationLister, p ListConversationsParams, opts ...CallOption) *Iterator[*Conversation] {
+	start := p.Cursor
+	return NewIterator(func(ctx context.Context, cursor string) ([]*Conversation, *PaginationInfo, error) {
+		page := p
+		page.Cursor = start
+		if cursor != "" {
+			page.Cursor = cursor
+		}
+
+		rsp, err := api.ListConversations(ctx, &page, opts...)
+		if err != nil {
+			return nil, nil, err
+		}
+		return rsp.Conversations, rsp.Pagination, nil
+	})
+}
@@ -0,0 +1,134 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestListConversationsQuery(t *testing.T) {
+	var query url.Values
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		writeJSON(w, http.StatusOK, `{"conversations":[{"id":"conv-1"}]}`)
+	})
+
+	after := time.Date(2024, 1, 1, 12, 0, 0, 0, time.FixedZone("BST", 3600))
+	rsp, err := c.ListConversations(context.Background(), &ListConversationsParams{
+		Limit:        10,
+		Status:       []Status{StatusActive, StatusFailed},
+		Channel:      ChannelEmail,
+		CustomerID:   "cust-1",
+		AssigneeType: ParticipantTypeHumanAgent,
+		CreatedAfter: &after,
+	})
+	if err != nil {
+		t.Fatalf("ListConversations: %v", err)
+	}
+	if len(rsp.Conversations) != 1 {
+		t.Errorf("conversations = %d, want 1", len(rsp.Conversations))
+	}
+
+	want := url.Values{
+		"limit":         {"10"},
+		"status":        {"active", "failed"},
+		"channel":       {"email"},
+		"customer_id":   {"cust-1"},
+		"assignee_type": {"Agent"},
+		"created_after": {"2024-01-01T11:00:00Z"},
+	}
+	if query.Encode() != want.Encode() {
+		t.Errorf("query = %s, want %s", query.Encode(), want.Encode())
+	}
+}
+
+func TestListConversationsWithoutParams(t *testing.T) {
+	var rawQuery string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		rawQuery = r.URL.RawQuery
+		writeJSON(w, http.StatusOK, `{"conversations":[]}`)
+	})
+
+	if _, err := c.ListConversations(context.Background(), nil); err != nil {
+		t.Fatalf("ListConversations: %v", err)
+	}
+	if rawQuery != "" {
+		t.Errorf("query = %q, want none", rawQuery)
+	}
+}
+
+func TestIterateConversations(t *testing.T) {
+	pages := map[string]string{
+		"":   `{"conversations":[{"id":"conv-1"},{"id":"conv-2"}],"pagination":{"next":"p2"}}`,
+		"p2": `{"conversations":[],"pagination":{"next":"p3"}}`,
+		"p3": `{"conversations":[{"id":"conv-3"}],"pagination":{"next":""}}`,
+	}
+	var cursors []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		cursor := r.URL.Query().Get("cursor")
+		cursors = append(cursors, cursor)
+		if r.URL.Query().Get("customer_id") != "cust-1" {
+			t.Errorf("filters were not sent with cursor %q", cursor)
+		}
+		writeJSON(w, http.StatusOK, pages[cursor])
+	})
+
+	all, err := IterateConversations(c, ListConversationsParams{CustomerID: "cust-1"}).All(context.Background())
+	if err != nil {
+		t.Fatalf("All: %v", err)
+	}
+
+	var ids []string
+	for _, conv := range all {
+		ids = append(ids, conv.ID)
+	}
+	if fmt.Sprint(ids) != "[conv-1 conv-2 conv-3]" {
+		t.Errorf("ids = %v", ids)
+	}
+	if fmt.Sprint(cursors) != "[ p2 p3]" {
+		t.Errorf("cursors = %q", cursors)
+	}
+}
+
+func TestIteratorStopsOnRepeatedCursor(t *testing.T) {
+	next := "same"
+	var fetches int
+	it := NewIterator(func(ctx context.Context, cursor string) ([]int, *PaginationInfo, error) {
+		fetches++
+		return []int{fetches}, &PaginationInfo{Next: &next}, nil
+	})
+
+	all, err := it.All(context.Background())
+	if err != nil || len(all) != 2 {
+		t.Errorf("All = %v, %v, want 2 results", all, err)
+	}
+}
+
+func TestIteratorError(t *testing.T) {
+	errFetch := errors.New("fetch failed")
+	next := "p2"
+	it := NewIterator(func(ctx context.Context, cursor string) ([]int, *PaginationInfo, error) {
+		if cursor == "p2" {
+			return nil, nil, errFetch
+		}
+		return []int{1}, &PaginationInfo{Next: &next}, nil
+	})
+
+	ctx := context.Background()
+	if !it.Next(ctx) || it.Value() != 1 {
+		t.Fatal("expected the first result")
+	}
+	if it.Next(ctx) {
+		t.Fatal("expected iteration to stop")
+	}
+	if !errors.Is(it.Err(), errFetch) {
+		t.Errorf("Err = %v, want %v", it.Err(), errFetch)
+	}
+	if it.Next(ctx) {
+		t.Error("Next should keep returning false after an error")
+	}
+}
@@ -32,15 +32,6 @@ type RatingParams struct {
 }
 
 // RateConversation submits a customer (CSAT) rating for a conversation.
-func (c *Client) RateConversation(ctx context.Context, conversationID string, p *RatingParams) error {
-	rsp, err := c.makeRequest(ctx, http.MethodPut, fmt.Sprintf("conversations/%s/rate", conversationID), p)
-	if err != nil {
-		return err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return err
-	}
-	return nil
+func (c *Client) RateConversation(ctx context.Context, conversationID string, p *RatingParams, opts ...CallOption) error {
+	return c.do(ctx, "RateConversation", http.MethodPut, fmt.Sprintf("conversations/%s/rate", conversationID), p, nil, opts...)
 }
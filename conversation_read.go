@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 )
@@ -16,24 +15,14 @@ type ReadParams struct {
 	SupportPlatform string `json:"support_platform,omitempty"`
 }
 
-func (c *Client) ReadConversation(ctx context.Context, conversationID string, p *ReadParams) (*Conversation, error) {
+func (c *Client) ReadConversation(ctx context.Context, conversationID string, p *ReadParams, opts ...CallOption) (*Conversation, error) {
 	url := fmt.Sprintf("conversations/%s/read", conversationID)
 	if p.SupportPlatform != "" {
 		url = fmt.Sprintf("%s?support_platform=%s", url, p.SupportPlatform)
 	}
 
-	rsp, err := c.makeRequest(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return nil, err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return nil, err
-	}
-
 	var conv Conversation
-	if err := json.NewDecoder(rsp.Body).Decode(&conv); err != nil {
+	if err := c.do(ctx, "ReadConversation", http.MethodGet, url, nil, &conv, opts...); err != nil {
 		return nil, err
 	}
 	return &conv, nil
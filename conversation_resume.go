@@ -26,19 +26,10 @@ type ConversationResumeParams struct {
 
 	// Resources is an arbitrary object attached to the conversation and available to the AI agent
 	// during the conversation. You can also use resources as parameters for your tools.
-	Resources map[string]any `json:"resources,omitempty"`
+	Resources Resources `json:"resources,omitempty"`
 }
 
 // ConversationResume re-opens a conversation that was previously finished.
-func (c *Client) ResumeConversation(ctx context.Context, conversationID string, p *ConversationResumeParams) error {
-	rsp, err := c.makeRequest(ctx, http.MethodPut, fmt.Sprintf("conversations/%s/resume", conversationID), p)
-	if err != nil {
-		return err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return err
-	}
-	return nil
+func (c *Client) ResumeConversation(ctx context.Context, conversationID string, p *ConversationResumeParams, opts ...CallOption) error {
+	return c.do(ctx, "ResumeConversation", http.MethodPut, fmt.Sprintf("conversations/%s/resume", conversationID), p, nil, opts...)
 }
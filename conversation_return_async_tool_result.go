@@ -42,15 +42,6 @@ type ReturnAsyncToolResultParams struct {
 //     webhook event.
 //   - The result payload should be a valid JSON object containing the data the
 //     agent needs to continue the conversation.
-func (c *Client) ReturnAsyncToolResult(ctx context.Context, conversationID string, p ReturnAsyncToolResultParams) error {
-	rsp, err := c.makeRequest(ctx, http.MethodPut, fmt.Sprintf("conversations/%s/return-async-tool-result", conversationID), p)
-	if err != nil {
-		return err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return err
-	}
-	return nil
+func (c *Client) ReturnAsyncToolResult(ctx context.Context, conversationID string, p ReturnAsyncToolResultParams, opts ...CallOption) error {
+	return c.do(ctx, "ReturnAsyncToolResult", http.MethodPut, fmt.Sprintf("conversations/%s/return-async-tool-result", conversationID), p, nil, opts...)
 }
@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 	"time"
 )
@@ -39,7 +38,7 @@ type StartConversationParams struct {
 
 	// Resources is an arbitrary object attached to the conversation and available to the AI agent
 	// during the conversation. You can also use resources as parameters for your tools.
-	Resources map[string]any `json:"resources,omitempty"`
+	Resources Resources `json:"resources,omitempty"`
 
 	// ConversationToken is the raw sensitive token that can be optionally provided when starting a conversation.
 	// The latest token of the conversation will be echoed back in future Webhooks, under the header `X-GradientLabs-Token`,
@@ -51,20 +50,15 @@ type StartConversationParams struct {
 	TrafficGroupID string `json:"traffic_group_id,omitempty"`
 }
 
-// StartConversation begins a conversation.
-func (c *Client) StartConversation(ctx context.Context, p StartConversationParams) (*Conversation, error) {
-	rsp, err := c.makeRequest(ctx, http.MethodPost, "conversations", p)
-	if err != nil {
-		return nil, err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
+// StartConversation begins a conversation. The parameters are checked with
+// StartConversationParams.Validate before the request is sent.
+func (c *Client) StartConversation(ctx context.Context, p StartConversationParams, opts ...CallOption) (*Conversation, error) {
+	if err := p.Validate(); err != nil {
 		return nil, err
 	}
 
 	var conv Conversation
-	if err := json.NewDecoder(rsp.Body).Decode(&conv); err != nil {
+	if err := c.do(ctx, "StartConversation", http.MethodPost, "conversations", p, &conv, opts...); err != nil {
 		return nil, err
 	}
 	return &conv, nil
@@ -0,0 +1,130 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+)
+
+// TranscriptEventType describes an entry in a conversation's event history.
+type TranscriptEventType string
+
+const (
+	// TranscriptEventAssigned means the conversation was assigned to a
+	// participant.
+	TranscriptEventAssigned TranscriptEventType = "assigned"
+
+	// TranscriptEventHandOff means the AI agent handed the conversation off.
+	TranscriptEventHandOff TranscriptEventType = "hand-off"
+
+	// TranscriptEventFinished means the conversation was finished.
+	TranscriptEventFinished TranscriptEventType = "finished"
+
+	// TranscriptEventCancelled means the conversation was cancelled.
+	TranscriptEventCancelled TranscriptEventType = "cancelled"
+
+	// TranscriptEventResumed means the conversation was resumed.
+	TranscriptEventResumed TranscriptEventType = "resumed"
+
+	// TranscriptEventRated means the customer rated the conversation.
+	TranscriptEventRated TranscriptEventType = "rated"
+)
+
+// TranscriptEvent is an entry in a conversation's event history, such as an
+// internal note or the conversation being assigned. Events added with
+// Client.AddConversationEvent have their ConversationEventType as Type.
+type TranscriptEvent struct {
+	// Type identifies the type of event.
+	Type TranscriptEventType `json:"type"`
+
+	// ParticipantID identifies who caused the event, if anyone.
+	ParticipantID string `json:"participant_id,omitempty"`
+
+	// ParticipantType identifies the type of participant who caused the event.
+	ParticipantType ParticipantType `json:"participant_type,omitempty"`
+
+	// MessageID identifies the message that this event relates to, if any.
+	MessageID string `json:"message_id,omitempty"`
+
+	// Body contains any text associated with the event (e.g. the contents of an
+	// internal note, or a hand-off note).
+	Body string `json:"body,omitempty"`
+
+	// Reason contains the reason given for the event (e.g. a hand-off reason
+	// code), if any.
+	Reason string `json:"reason,omitempty"`
+
+	// Timestamp is the time at which the event occurred.
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Transcript contains a conversation's full message and event history.
+type Transcript struct {
+	// Conversation contains the conversation's metadata.
+	Conversation Conversation `json:"conversation"`
+
+	// Messages contains the messages sent by all participants, including the
+	// AI agent, in the order they were sent.
+	Messages []*Message `json:"messages"`
+
+	// Events contains the conversation's other events, in the order they
+	// occurred.
+	Events []*TranscriptEvent `json:"events"`
+}
+
+// TranscriptEntry is either a message or an event, as returned by
+// Transcript.Entries.
+type TranscriptEntry struct {
+	Timestamp time.Time
+	Message   *Message
+	Event     *TranscriptEvent
+}
+
+// Entries returns the transcript's messages and events interleaved in
+// chronological order.
+func (t *Transcript) Entries() []TranscriptEntry {
+	entries := make([]TranscriptEntry, 0, len(t.Messages)+len(t.Events))
+	for _, m := range t.Messages {
+		var ts time.Time
+		if m.Created != nil {
+			ts = *m.Created
+		}
+		entries = append(entries, TranscriptEntry{Timestamp: ts, Message: m})
+	}
+	for _, e := range t.Events {
+		entries = append(entries, TranscriptEntry{Timestamp: e.Timestamp, Event: e})
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Timestamp.Before(entries[j].Timestamp)
+	})
+	return entries
+}
+
+// ReadTranscriptParams are the parameters to Client.ReadConversationTranscript.
+type ReadTranscriptParams struct {
+	// SupportPlatform is the name of the support platform where the
+	// conversation was started (e.g. Intercom).
+	//
+	// Leave empty if the conversation was started via the Gradient
+	// Labs API.
+	SupportPlatform string `json:"support_platform,omitempty"`
+}
+
+// ReadConversationTranscript returns a conversation's full message and event
+// history. Use Transcript.WriteMarkdown, Transcript.WriteText or
+// Transcript.WriteJSON to export it.
+func (c *Client) ReadConversationTranscript(ctx context.Context, conversationID string, p *ReadTranscriptParams, opts ...CallOption) (*Transcript, error) {
+	path := fmt.Sprintf("conversations/%s/transcript", conversationID)
+	if p != nil && p.SupportPlatform != "" {
+		path = fmt.Sprintf("%s?support_platform=%s", path, url.QueryEscape(p.SupportPlatform))
+	}
+
+	var transcript Transcript
+	if err := c.do(ctx, "ReadConversationTranscript", http.MethodGet, path, nil, &transcript, opts...); err != nil {
+		return nil, err
+	}
+	return &transcript, nil
+}
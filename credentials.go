@@ -0,0 +1,200 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrNoCredentials is returned when a CredentialsProvider has no API key to
+// offer.
+var ErrNoCredentials = errors.New("no API key available")
+
+// CredentialsProvider supplies the API key used to authenticate requests. It is
+// consulted before every request, so implementations can rotate keys without
+// the Client being rebuilt.
+type CredentialsProvider interface {
+	// APIKey returns the key to use for the next request.
+	APIKey(ctx context.Context) (string, error)
+}
+
+// CredentialsRefresher can optionally be implemented by a CredentialsProvider
+// that caches keys. When the API rejects a key with a 401 status code, the
+// Client calls RefreshAPIKey with the rejected key and, if a different key is
+// returned, retries the request once.
+type CredentialsRefresher interface {
+	RefreshAPIKey(ctx context.Context, rejected string) (string, error)
+}
+
+// WithCredentials sets the provider that the client will consult for an API key
+// before every request. If it is combined with WithAPIKey, the last one given
+// wins.
+func WithCredentials(p CredentialsProvider) Option {
+	return credentialsOption{p}
+}
+
+type credentialsOption struct{ provider CredentialsProvider }
+
+func (credentialsOption) isClientOption() {}
+
+func (c *Client) refreshAPIKey(ctx context.Context, rejected string) (string, bool) {
+	r, ok := c.credentials.(CredentialsRefresher)
+	if !ok {
+		return "", false
+	}
+
+	fresh, err := r.RefreshAPIKey(ctx, rejected)
+	if err != nil || fresh == "" || fresh == rejected {
+		return "", false
+	}
+	return fresh, true
+}
+
+// StaticCredentials is a CredentialsProvider that always returns the same key.
+// It is what WithAPIKey uses under the hood.
+type StaticCredentials string
+
+// APIKey satisfies the CredentialsProvider interface.
+func (s StaticCredentials) APIKey(context.Context) (string, error) {
+	if s == "" {
+		return "", ErrNoCredentials
+	}
+	return string(s), nil
+}
+
+// EnvCredentials is a CredentialsProvider that reads the API key from the named
+// environment variable on every request.
+type EnvCredentials string
+
+// APIKey satisfies the CredentialsProvider interface.
+func (e EnvCredentials) APIKey(context.Context) (string, error) {
+	key := os.Getenv(string(e))
+	if key == "" {
+		return "", fmt.Errorf("%w: environment variable %s is empty", ErrNoCredentials, string(e))
+	}
+	return key, nil
+}
+
+// RefreshAPIKey satisfies the CredentialsRefresher interface.
+func (e EnvCredentials) RefreshAPIKey(ctx context.Context, _ string) (string, error) {
+	return e.APIKey(ctx)
+}
+
+// FileCredentials is a CredentialsProvider that reads the API key from a file
+// (e.g. a mounted Kubernetes secret). The file is watched for changes by
+// checking its modification time at most once per check interval, so keys can
+// be rotated by rewriting the file. Use NewFileCredentials to create one.
+type FileCredentials struct {
+	path          string
+	checkInterval time.Duration
+
+	mu        sync.Mutex
+	key       string
+	modTime   time.Time
+	lastCheck time.Time
+}
+
+// NewFileCredentials creates a FileCredentials for the given path. If
+// checkInterval is zero, the file is checked for changes before every request.
+func NewFileCredentials(path string, checkInterval time.Duration) *FileCredentials {
+	return &FileCredentials{path: path, checkInterval: checkInterval}
+}
+
+// APIKey satisfies the CredentialsProvider interface.
+func (f *FileCredentials) APIKey(context.Context) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.key != "" && time.Since(f.lastCheck) < f.checkInterval {
+		return f.key, nil
+	}
+	return f.load(false)
+}
+
+// RefreshAPIKey satisfies the CredentialsRefresher interface. It re-reads the
+// file regardless of its modification time.
+func (f *FileCredentials) RefreshAPIKey(context.Context, string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.load(true)
+}
+
+func (f *FileCredentials) load(force bool) (string, error) {
+	f.lastCheck = time.Now()
+
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return "", err
+	}
+	if !force && f.key != "" && info.ModTime().Equal(f.modTime) {
+		return f.key, nil
+	}
+
+	b, err := os.ReadFile(f.path)
+	if err != nil {
+		return "", err
+	}
+
+	key := strings.TrimSpace(string(b))
+	if key == "" {
+		return "", fmt.Errorf("%w: %s is empty", ErrNoCredentials, f.path)
+	}
+
+	f.key = key
+	f.modTime = info.ModTime()
+	return key, nil
+}
+
+// ChainCredentials is a CredentialsProvider that tries each of its providers in
+// turn, returning the first key found.
+type ChainCredentials []CredentialsProvider
+
+// APIKey satisfies the CredentialsProvider interface.
+func (c ChainCredentials) APIKey(ctx context.Context) (string, error) {
+	errs := make([]error, 0, len(c))
+	for _, p := range c {
+		key, err := p.APIKey(ctx)
+		if err == nil {
+			return key, nil
+		}
+		errs = append(errs, err)
+	}
+	if len(errs) == 0 {
+		return "", ErrNoCredentials
+	}
+	return "", errors.Join(errs...)
+}
+
+// RefreshAPIKey satisfies the CredentialsRefresher interface. Providers that
+// implement CredentialsRefresher are refreshed, and the first key that differs
+// from the rejected one is returned.
+func (c ChainCredentials) RefreshAPIKey(ctx context.Context, rejected string) (string, error) {
+	errs := make([]error, 0, len(c))
+	for _, p := range c {
+		var (
+			key string
+			err error
+		)
+		if r, ok := p.(CredentialsRefresher); ok {
+			key, err = r.RefreshAPIKey(ctx, rejected)
+		} else {
+			key, err = p.APIKey(ctx)
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if key != rejected {
+			return key, nil
+		}
+	}
+	if len(errs) == 0 {
+		return rejected, nil
+	}
+	return "", errors.Join(errs...)
+}
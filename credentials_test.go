@@ -0,0 +1,204 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNewClientLastCredentialsOptionWins(t *testing.T) {
+	testCases := map[string]struct {
+		opts []Option
+		want string
+	}{
+		"api key twice": {
+			opts: []Option{WithAPIKey("first"), WithAPIKey("second")},
+			want: "second",
+		},
+		"api key after credentials": {
+			opts: []Option{WithCredentials(StaticCredentials("provider")), WithAPIKey("key")},
+			want: "key",
+		},
+		"credentials after api key": {
+			opts: []Option{WithAPIKey("key"), WithCredentials(StaticCredentials("provider"))},
+			want: "provider",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			c, err := NewClient(tc.opts...)
+			if err != nil {
+				t.Fatalf("NewClient: %v", err)
+			}
+			got, err := c.credentials.APIKey(context.Background())
+			if err != nil {
+				t.Fatalf("APIKey: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("APIKey = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+// rotatingCredentials hands out "old" until it is refreshed.
+type rotatingCredentials struct {
+	mu        sync.Mutex
+	key       string
+	refreshes int
+}
+
+func (r *rotatingCredentials) APIKey(context.Context) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.key, nil
+}
+
+func (r *rotatingCredentials) RefreshAPIKey(_ context.Context, rejected string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.refreshes++
+	r.key = "new"
+	return r.key, nil
+}
+
+func TestRefreshOnUnauthorized(t *testing.T) {
+	creds := &rotatingCredentials{key: "old"}
+
+	var auth []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		auth = append(auth, r.Header.Get("Authorization"))
+		if r.Header.Get("Authorization") != "Bearer new" {
+			writeJSON(w, http.StatusUnauthorized, `{"message":"bad key"}`)
+			return
+		}
+		writeJSON(w, http.StatusOK, `{"id":"conv-1"}`)
+	}, WithCredentials(creds))
+
+	var meta ResponseMeta
+	if _, err := c.ReadConversation(context.Background(), "conv-1", &ReadParams{}, WithResponseCapture(&meta)); err != nil {
+		t.Fatalf("ReadConversation: %v", err)
+	}
+
+	if len(auth) != 2 || auth[0] != "Bearer old" || auth[1] != "Bearer new" {
+		t.Errorf("Authorization headers = %v, want [Bearer old, Bearer new]", auth)
+	}
+	if creds.refreshes != 1 {
+		t.Errorf("refreshes = %d, want 1", creds.refreshes)
+	}
+	if meta.Attempts != 2 {
+		t.Errorf("Attempts = %d, want 2", meta.Attempts)
+	}
+}
+
+func TestRefreshOnUnauthorizedOnlyOnce(t *testing.T) {
+	var requests int
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		writeJSON(w, http.StatusUnauthorized, `{"message":"bad key"}`)
+	}, WithCredentials(&rotatingCredentials{key: "old"}))
+
+	_, err := c.ReadConversation(context.Background(), "conv-1", &ReadParams{})
+
+	var re *ResponseError
+	if !errors.As(err, &re) || re.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("error = %v, want a 401 ResponseError", err)
+	}
+	if requests != 2 {
+		t.Errorf("requests = %d, want 2", requests)
+	}
+}
+
+func TestNoRefreshWithoutRefresher(t *testing.T) {
+	var requests int
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		writeJSON(w, http.StatusUnauthorized, `{}`)
+	})
+
+	if _, err := c.ReadConversation(context.Background(), "conv-1", &ReadParams{}); err == nil {
+		t.Fatal("expected an error")
+	}
+	if requests != 1 {
+		t.Errorf("requests = %d, want 1", requests)
+	}
+}
+
+func TestStaticCredentials(t *testing.T) {
+	if _, err := StaticCredentials("").APIKey(context.Background()); !errors.Is(err, ErrNoCredentials) {
+		t.Errorf("error = %v, want ErrNoCredentials", err)
+	}
+	if key, _ := StaticCredentials("key").APIKey(context.Background()); key != "key" {
+		t.Errorf("APIKey = %q, want key", key)
+	}
+}
+
+func TestEnvCredentials(t *testing.T) {
+	t.Setenv("GLABS_TEST_KEY", "")
+	creds := EnvCredentials("GLABS_TEST_KEY")
+
+	if _, err := creds.APIKey(context.Background()); !errors.Is(err, ErrNoCredentials) {
+		t.Errorf("error = %v, want ErrNoCredentials", err)
+	}
+
+	t.Setenv("GLABS_TEST_KEY", "from-env")
+	if key, err := creds.APIKey(context.Background()); err != nil || key != "from-env" {
+		t.Errorf("APIKey = %q, %v, want from-env", key, err)
+	}
+}
+
+func TestFileCredentials(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "key")
+	write := func(key string, mod time.Time) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(key+"\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, mod, mod); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx := context.Background()
+	now := time.Now()
+	write("first", now.Add(-time.Hour))
+
+	creds := NewFileCredentials(path, 0)
+	if key, err := creds.APIKey(ctx); err != nil || key != "first" {
+		t.Fatalf("APIKey = %q, %v, want first", key, err)
+	}
+
+	write("second", now)
+	if key, err := creds.APIKey(ctx); err != nil || key != "second" {
+		t.Errorf("APIKey after rotation = %q, %v, want second", key, err)
+	}
+
+	write("", now.Add(time.Hour))
+	if _, err := creds.RefreshAPIKey(ctx, "second"); !errors.Is(err, ErrNoCredentials) {
+		t.Errorf("RefreshAPIKey error = %v, want ErrNoCredentials", err)
+	}
+}
+
+func TestChainCredentials(t *testing.T) {
+	ctx := context.Background()
+	chain := ChainCredentials{StaticCredentials(""), StaticCredentials("second")}
+
+	if key, err := chain.APIKey(ctx); err != nil || key != "second" {
+		t.Errorf("APIKey = %q, %v, want second", key, err)
+	}
+	if _, err := (ChainCredentials{}).APIKey(ctx); !errors.Is(err, ErrNoCredentials) {
+		t.Errorf("empty chain error = %v, want ErrNoCredentials", err)
+	}
+
+	rotating := &rotatingCredentials{key: "old"}
+	chain = ChainCredentials{rotating, StaticCredentials("fallback")}
+	if key, err := chain.RefreshAPIKey(ctx, "old"); err != nil || key != "new" {
+		t.Errorf("RefreshAPIKey = %q, %v, want new", key, err)
+	}
+}
@@ -0,0 +1,79 @@
+package email
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"strings"
+	"unicode/utf8"
+)
+
+// wordDecoder decodes RFC 2047 encoded-words in headers and file names.
+var wordDecoder = &mime.WordDecoder{CharsetReader: charsetReader}
+
+// windows1252 maps the bytes 0x80-0x9F, where Windows-1252 differs from
+// ISO-8859-1. Zero entries are unassigned and decode as U+FFFD.
+var windows1252 = [32]rune{
+	0x20AC, 0, 0x201A, 0x0192, 0x201E, 0x2026, 0x2020, 0x2021,
+	0x02C6, 0x2030, 0x0160, 0x2039, 0x0152, 0, 0x017D, 0,
+	0, 0x2018, 0x2019, 0x201C, 0x201D, 0x2022, 0x2013, 0x2014,
+	0x02DC, 0x2122, 0x0161, 0x203A, 0x0153, 0, 0x017E, 0x0178,
+}
+
+// charsetReader converts text in the given charset to UTF-8. It supports
+// UTF-8, US-ASCII, ISO-8859-1 and Windows-1252, which cover most email.
+func charsetReader(charset string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(strings.TrimSpace(charset)) {
+	case "", "utf-8", "utf8", "us-ascii", "ascii":
+		return input, nil
+	case "iso-8859-1", "iso8859-1", "latin1", "latin-1":
+		return decodeSingleByte(input, false)
+	case "windows-1252", "cp1252", "x-cp1252":
+		return decodeSingleByte(input, true)
+	default:
+		return nil, fmt.Errorf("email: unsupported charset %q", charset)
+	}
+}
+
+func decodeSingleByte(input io.Reader, cp1252 bool) (io.Reader, error) {
+	data, err := io.ReadAll(input)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, 0, len(data))
+	for _, b := range data {
+		r := rune(b)
+		if cp1252 && b >= 0x80 && b <= 0x9F {
+			if r = windows1252[b-0x80]; r == 0 {
+				r = utf8.RuneError
+			}
+		}
+		out = utf8.AppendRune(out, r)
+	}
+	return bytes.NewReader(out), nil
+}
+
+// decodeText converts a text part's content to UTF-8. Content in an
+// unsupported charset is returned unchanged.
+func decodeText(data []byte, charset string) string {
+	r, err := charsetReader(charset, bytes.NewReader(data))
+	if err != nil {
+		return string(data)
+	}
+	decoded, err := io.ReadAll(r)
+	if err != nil {
+		return string(data)
+	}
+	return string(decoded)
+}
+
+// decodeFileName decodes RFC 2047 encoded-words in an attachment's file name,
+// which some clients use instead of RFC 2231 parameters.
+func decodeFileName(name string) string {
+	decoded, err := wordDecoder.DecodeHeader(name)
+	if err != nil {
+		return name
+	}
+	return decoded
+}
@@ -0,0 +1,267 @@
+package email_test
+
+import (
+	"bytes"
+	"context"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	client "github.com/gradientlabs-ai/gradientlabs-go"
+	"github.com/gradientlabs-ai/gradientlabs-go/email"
+)
+
+const multipartEmail = "From: Jane Doe <jane@example.com>\r\n" +
+	"To: support@example.com\r\n" +
+	"Subject: =?UTF-8?Q?Caf=C3=A9_order?=\r\n" +
+	"Date: Mon, 01 Jan 2024 10:00:00 +0000\r\n" +
+	"Message-ID: <abc@example.com>\r\n" +
+	"In-Reply-To: <prev@example.com>\r\n" +
+	"References: <first@example.com> <prev@example.com>\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"Content-Type: multipart/mixed; boundary=\"outer\"\r\n" +
+	"\r\n" +
+	"--outer\r\n" +
+	"Content-Type: text/plain; charset=iso-8859-1\r\n" +
+	"Content-Transfer-Encoding: quoted-printable\r\n" +
+	"\r\n" +
+	"Where is my caf=E9 order?\r\n" +
+	"\r\n" +
+	"On Sun, 31 Dec 2023 at 09:00, Support <support@example.com> wrote:\r\n" +
+	"> We have shipped it.\r\n" +
+	"--outer\r\n" +
+	"Content-Type: image/png\r\n" +
+	"Content-Disposition: attachment; filename=\"=?UTF-8?B?cmXDp3UucG5n?=\"\r\n" +
+	"Content-Transfer-Encoding: base64\r\n" +
+	"\r\n" +
+	"iVBO\r\n" +
+	"Rw==\r\n" +
+	"--outer--\r\n"
+
+func TestParse(t *testing.T) {
+	msg, err := email.Parse(strings.NewReader(multipartEmail))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	if msg.Subject != "Café order" {
+		t.Errorf("Subject = %q", msg.Subject)
+	}
+	if msg.MessageID != "<abc@example.com>" || msg.InReplyTo != "<prev@example.com>" {
+		t.Errorf("MessageID = %q, InReplyTo = %q", msg.MessageID, msg.InReplyTo)
+	}
+	if len(msg.References) != 2 {
+		t.Errorf("References = %v", msg.References)
+	}
+	if msg.From == nil || msg.From.Address != "jane@example.com" {
+		t.Errorf("From = %v", msg.From)
+	}
+	if !strings.HasPrefix(msg.Text, "Where is my café order?") {
+		t.Errorf("Text = %q", msg.Text)
+	}
+
+	if len(msg.Attachments) != 1 {
+		t.Fatalf("got %d attachments", len(msg.Attachments))
+	}
+	a := msg.Attachments[0]
+	if a.FileName != "reçu.png" || a.ContentType != "image/png" {
+		t.Errorf("attachment = %q (%s)", a.FileName, a.ContentType)
+	}
+	if !bytes.Equal(a.Data, []byte{0x89, 'P', 'N', 'G'}) {
+		t.Errorf("attachment data = %x", a.Data)
+	}
+}
+
+func TestParseCharsets(t *testing.T) {
+	testCases := map[string]struct {
+		contentType string
+		body        string
+		want        string
+	}{
+		"utf-8": {
+			contentType: "text/plain; charset=utf-8",
+			body:        "caf\xc3\xa9",
+			want:        "café",
+		},
+		"iso-8859-1": {
+			contentType: "text/plain; charset=ISO-8859-1",
+			body:        "caf\xe9",
+			want:        "café",
+		},
+		"windows-1252": {
+			contentType: "text/plain; charset=windows-1252",
+			body:        "\x93caf\xe9\x94 \x80",
+			want:        "“café” €",
+		},
+		"html": {
+			contentType: "text/html; charset=windows-1252",
+			body:        "<p>caf\xe9 &amp; \x96 tea</p>",
+			want:        "café & – tea",
+		},
+		"unsupported charset": {
+			contentType: "text/plain; charset=x-unknown",
+			body:        "plain",
+			want:        "plain",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			raw := "From: jane@example.com\r\nContent-Type: " + tc.contentType + "\r\n\r\n" + tc.body
+			msg, err := email.Parse(strings.NewReader(raw))
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			if msg.Text != tc.want {
+				t.Errorf("Text = %q, want %q", msg.Text, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseEncodedSubjectCharset(t *testing.T) {
+	raw := "From: jane@example.com\r\nSubject: =?ISO-8859-1?Q?R=E9sum=E9?=\r\n\r\nhi"
+	msg, err := email.Parse(strings.NewReader(raw))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if msg.Subject != "Résumé" {
+		t.Errorf("Subject = %q", msg.Subject)
+	}
+}
+
+func TestStripQuotedReply(t *testing.T) {
+	testCases := map[string]struct {
+		text string
+		want string
+	}{
+		"attribution": {
+			text: "Thanks!\n\nOn Mon, 1 Jan 2024 at 10:00, Jane <jane@example.com> wrote:\n> Hi",
+			want: "Thanks!",
+		},
+		"wrapped attribution": {
+			text: "Thanks!\n\nOn Mon, 1 Jan 2024 at 10:00, Jane Doe\n<jane@example.com> wrote:\n> Hi",
+			want: "Thanks!",
+		},
+		"outlook separator": {
+			text: "Sounds good\r\n\r\nFrom: Support\r\nSent: Monday\r\nSubject: Hi",
+			want: "Sounds good",
+		},
+		"original message": {
+			text: "Yes\n-----Original Message-----\nNo",
+			want: "Yes",
+		},
+		"interleaved quotes": {
+			text: "> question?\nanswer\n> another?\nsecond answer",
+			want: "answer\nsecond answer",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := email.StripQuotedReply(tc.text); got != tc.want {
+				t.Errorf("StripQuotedReply = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestHTMLToText(t *testing.T) {
+	in := "<html><head><title>x</title><style>p{}</style></head><body>" +
+		"<!-- comment --><p>Hello&nbsp;<b>Jane</b>,</p><ul><li>one</li><li>two</li></ul>" +
+		"Line<br>break<blockquote>quoted</blockquote></body></html>"
+	want := "Hello Jane,\n\n- one\n- two\n\nLine\nbreak"
+	if got := email.HTMLToText(in); got != want {
+		t.Errorf("HTMLToText = %q, want %q", got, want)
+	}
+}
+
+func TestAddMessageParams(t *testing.T) {
+	msg, err := email.Parse(strings.NewReader(multipartEmail))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	var uploaded []string
+	p, err := msg.AddMessageParams(context.Background(), email.AddMessageParamsOptions{
+		Upload: func(_ context.Context, part *email.Part) (string, error) {
+			uploaded = append(uploaded, part.FileName)
+			return "https://files.example.com/" + part.FileName, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("AddMessageParams: %v", err)
+	}
+
+	if p.Body != "Where is my café order?" {
+		t.Errorf("Body = %q", p.Body)
+	}
+	if p.ParticipantID != "jane@example.com" || p.ParticipantType != client.ParticipantTypeCustomer {
+		t.Errorf("participant = %s (%s)", p.ParticipantID, p.ParticipantType)
+	}
+	if !p.Created.Equal(time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("Created = %v", p.Created)
+	}
+	if len(p.Attachments) != 1 || p.Attachments[0].Type != client.AttachmentTypeImage ||
+		p.Attachments[0].URL != "https://files.example.com/reçu.png" {
+		t.Errorf("Attachments = %+v", p.Attachments)
+	}
+	if len(uploaded) != 1 {
+		t.Errorf("uploaded %v", uploaded)
+	}
+
+	again, err := msg.AddMessageParams(context.Background(), email.AddMessageParamsOptions{})
+	if err != nil {
+		t.Fatalf("AddMessageParams: %v", err)
+	}
+	if again.ID != p.ID || !strings.HasPrefix(p.ID, "email-") {
+		t.Errorf("IDs %q and %q should match", p.ID, again.ID)
+	}
+}
+
+func TestBuildReply(t *testing.T) {
+	original, err := email.Parse(strings.NewReader(multipartEmail))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	reply, err := email.BuildReply(email.ReplyParams{
+		From:     mail.Address{Name: "Support", Address: "support@example.com"},
+		Original: original,
+		Body:     "It's **on its way**.",
+	})
+	if err != nil {
+		t.Fatalf("BuildReply: %v", err)
+	}
+	if reply.Subject != "Re: Café order" {
+		t.Errorf("Subject = %q", reply.Subject)
+	}
+	if !strings.HasSuffix(reply.MessageID, "@example.com>") {
+		t.Errorf("MessageID = %q", reply.MessageID)
+	}
+
+	parsed, err := email.Parse(bytes.NewReader(reply.Raw))
+	if err != nil {
+		t.Fatalf("parsing reply: %v", err)
+	}
+	if parsed.Subject != reply.Subject || parsed.MessageID != reply.MessageID {
+		t.Errorf("parsed reply = %q %q", parsed.Subject, parsed.MessageID)
+	}
+	if parsed.InReplyTo != "<abc@example.com>" {
+		t.Errorf("In-Reply-To = %q", parsed.InReplyTo)
+	}
+	if got := strings.Join(parsed.References, " "); got != "<first@example.com> <prev@example.com> <abc@example.com>" {
+		t.Errorf("References = %q", got)
+	}
+	if len(parsed.To) != 1 || parsed.To[0].Address != "jane@example.com" {
+		t.Errorf("To = %v", parsed.To)
+	}
+	if parsed.Text != "It's on its way." || !strings.Contains(parsed.HTML, "<strong>on its way</strong>") {
+		t.Errorf("Text = %q, HTML = %q", parsed.Text, parsed.HTML)
+	}
+
+	if _, err := email.BuildReply(email.ReplyParams{}); err == nil {
+		t.Error("expected an error without an original message")
+	}
+}
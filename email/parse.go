@@ -0,0 +1,301 @@
+// Package email maps email-channel conversations to and from RFC 5322
+// messages: inbound emails become AddMessageParams (with quoted replies
+// stripped and attachments extracted), and agent messages become MIME replies
+// threaded with In-Reply-To and References.
+package email
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"time"
+
+	client "github.com/gradientlabs-ai/gradientlabs-go"
+)
+
+// Message is a parsed inbound email.
+type Message struct {
+	// MessageID is the Message-ID header, including angle brackets.
+	MessageID string
+
+	// InReplyTo is the In-Reply-To header, including angle brackets.
+	InReplyTo string
+
+	// References contains the message IDs in the References header.
+	References []string
+
+	// From is the sender.
+	From *mail.Address
+
+	// To contains the recipients.
+	To []*mail.Address
+
+	// Subject is the decoded subject line.
+	Subject string
+
+	// Date is the time the email was sent.
+	Date time.Time
+
+	// Text is the plain-text body, converted from HTML if there was no
+	// text/plain part.
+	Text string
+
+	// HTML is the HTML body, if there was one.
+	HTML string
+
+	// Attachments contains the email's attachments, including inline images.
+	Attachments []*Part
+}
+
+// Part is an attachment extracted from an email.
+type Part struct {
+	// FileName is the attachment's file name, if it had one.
+	FileName string
+
+	// ContentType is the attachment's media type (e.g. "image/png").
+	ContentType string
+
+	// ContentID is the Content-ID of inline attachments.
+	ContentID string
+
+	// Inline is true if the attachment was marked for inline display.
+	Inline bool
+
+	// Data is the decoded attachment content.
+	Data []byte
+}
+
+// Parse reads an RFC 5322 email.
+func Parse(r io.Reader) (*Message, error) {
+	raw, err := mail.ReadMessage(r)
+	if err != nil {
+		return nil, err
+	}
+
+	subject, err := wordDecoder.DecodeHeader(raw.Header.Get("Subject"))
+	if err != nil {
+		subject = raw.Header.Get("Subject")
+	}
+
+	msg := &Message{
+		MessageID:  strings.TrimSpace(raw.Header.Get("Message-Id")),
+		InReplyTo:  strings.TrimSpace(raw.Header.Get("In-Reply-To")),
+		References: strings.Fields(raw.Header.Get("References")),
+		Subject:    subject,
+	}
+	if from, err := raw.Header.AddressList("From"); err == nil && len(from) > 0 {
+		msg.From = from[0]
+	}
+	if to, err := raw.Header.AddressList("To"); err == nil {
+		msg.To = to
+	}
+	if date, err := raw.Header.Date(); err == nil {
+		msg.Date = date
+	}
+
+	header := partHeader{
+		contentType: raw.Header.Get("Content-Type"),
+		encoding:    raw.Header.Get("Content-Transfer-Encoding"),
+	}
+	if err := msg.readPart(header, raw.Body); err != nil {
+		return nil, err
+	}
+
+	if msg.Text == "" && msg.HTML != "" {
+		msg.Text = HTMLToText(msg.HTML)
+	}
+	return msg, nil
+}
+
+type partHeader struct {
+	contentType, encoding, disposition, contentID string
+}
+
+func (m *Message) readPart(h partHeader, body io.Reader) error {
+	mediaType, params, err := mime.ParseMediaType(h.contentType)
+	if err != nil {
+		mediaType, params = "text/plain", map[string]string{}
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		mr := multipart.NewReader(body, params["boundary"])
+		for {
+			p, err := mr.NextPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			// The multipart reader decodes quoted-printable itself and removes
+			// the header, so only other encodings need handling.
+			err = m.readPart(partHeader{
+				contentType: p.Header.Get("Content-Type"),
+				encoding:    p.Header.Get("Content-Transfer-Encoding"),
+				disposition: p.Header.Get("Content-Disposition"),
+				contentID:   p.Header.Get("Content-Id"),
+			}, p)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	data, err := io.ReadAll(decodeTransfer(h.encoding, body))
+	if err != nil {
+		return err
+	}
+
+	disposition, dparams, _ := mime.ParseMediaType(h.disposition)
+	fileName := dparams["filename"]
+	if fileName == "" {
+		fileName = params["name"]
+	}
+	fileName = decodeFileName(fileName)
+
+	isBody := disposition != "attachment" && fileName == "" &&
+		(mediaType == "text/plain" || mediaType == "text/html")
+	if !isBody {
+		m.Attachments = append(m.Attachments, &Part{
+			FileName:    fileName,
+			ContentType: mediaType,
+			ContentID:   strings.Trim(h.contentID, "<>"),
+			Inline:      disposition == "inline",
+			Data:        data,
+		})
+		return nil
+	}
+
+	text := decodeText(data, params["charset"])
+	if mediaType == "text/html" {
+		if m.HTML == "" {
+			m.HTML = text
+		}
+	} else if m.Text == "" {
+		m.Text = strings.ReplaceAll(text, "\r\n", "\n")
+	}
+	return nil
+}
+
+func decodeTransfer(encoding string, r io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, newlineStripper{r})
+	case "quoted-printable":
+		return quotedprintable.NewReader(r)
+	default:
+		return r
+	}
+}
+
+// newlineStripper removes line breaks, which base64.NewDecoder does not
+// tolerate in every position.
+type newlineStripper struct{ r io.Reader }
+
+func (n newlineStripper) Read(p []byte) (int, error) {
+	buf := make([]byte, len(p))
+	read, err := n.r.Read(buf)
+	out := p[:0]
+	for _, b := range buf[:read] {
+		if b != '\r' && b != '\n' {
+			out = append(out, b)
+		}
+	}
+	return len(out), err
+}
+
+// Uploader stores an attachment somewhere the AI agent can download it from,
+// returning its URL.
+type Uploader func(ctx context.Context, p *Part) (url string, err error)
+
+// AddMessageParamsOptions customise Message.AddMessageParams.
+type AddMessageParamsOptions struct {
+	// ParticipantID identifies the sender. Defaults to the From address.
+	ParticipantID string
+
+	// ParticipantType defaults to client.ParticipantTypeCustomer.
+	ParticipantType client.ParticipantType
+
+	// KeepQuotedReply includes the quoted previous messages in the body.
+	KeepQuotedReply bool
+
+	// Upload optionally stores attachments so the agent can access them. If
+	// nil, attachments are described by file name only.
+	Upload Uploader
+}
+
+// AddMessageParams converts the email into parameters for Client.AddMessage.
+// The message ID is derived from the Message-ID header, so adding the same
+// email twice is idempotent.
+func (m *Message) AddMessageParams(ctx context.Context, opts AddMessageParamsOptions) (client.AddMessageParams, error) {
+	body := m.Text
+	if !opts.KeepQuotedReply {
+		body = StripQuotedReply(body)
+	}
+
+	p := client.AddMessageParams{
+		ID:              messageID(m),
+		Body:            strings.TrimSpace(body),
+		Subject:         m.Subject,
+		ParticipantID:   opts.ParticipantID,
+		ParticipantType: opts.ParticipantType,
+		Created:         m.Date,
+	}
+	if p.ParticipantID == "" && m.From != nil {
+		p.ParticipantID = m.From.Address
+	}
+	if p.ParticipantType == "" {
+		p.ParticipantType = client.ParticipantTypeCustomer
+	}
+	if p.Created.IsZero() {
+		p.Created = time.Now()
+	}
+
+	for i, part := range m.Attachments {
+		a := &client.Attachment{
+			Type:     client.AttachmentTypeFile,
+			FileName: part.FileName,
+		}
+		if strings.HasPrefix(part.ContentType, "image/") {
+			a.Type = client.AttachmentTypeImage
+		}
+		if a.FileName == "" {
+			a.FileName = fmt.Sprintf("attachment-%d", i+1)
+		}
+		if opts.Upload != nil {
+			url, err := opts.Upload(ctx, part)
+			if err != nil {
+				return p, fmt.Errorf("uploading %s: %w", a.FileName, err)
+			}
+			a.URL = url
+		}
+		p.Attachments = append(p.Attachments, a)
+	}
+	return p, nil
+}
+
+// messageID derives an AddMessageParams.ID (which only allows letters, numbers
+// and _ - + =) from the email's Message-ID.
+func messageID(m *Message) string {
+	src := m.MessageID
+	if src == "" {
+		src = fmt.Sprintf("%s|%s|%s|%s", m.Date, addressString(m.From), m.Subject, m.Text)
+	}
+	sum := sha256.Sum256([]byte(src))
+	return "email-" + hex.EncodeToString(sum[:12])
+}
+
+func addressString(a *mail.Address) string {
+	if a == nil {
+		return ""
+	}
+	return a.Address
+}
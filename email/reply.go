@@ -0,0 +1,165 @@
+package email
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+
+	client "github.com/gradientlabs-ai/gradientlabs-go"
+	"github.com/gradientlabs-ai/gradientlabs-go/format"
+)
+
+// ReplyParams are the parameters to BuildReply.
+type ReplyParams struct {
+	// From is the address the reply is sent from (e.g. your support inbox).
+	From mail.Address
+
+	// To optionally overrides the recipients, which default to the sender of
+	// the original email.
+	To []*mail.Address
+
+	// Original is the email being replied to. Its Message-ID and References
+	// are used to thread the reply, and its subject is prefixed with "Re: ".
+	Original *Message
+
+	// Body is the Markdown body of the reply (e.g. AgentMessageEvent.Body).
+	Body string
+
+	// MessageIDDomain is the domain used in the reply's generated Message-ID.
+	// Defaults to the domain of From.
+	MessageIDDomain string
+
+	// Date optionally sets the Date header. Defaults to the current time.
+	Date time.Time
+}
+
+// Reply is a built MIME reply.
+type Reply struct {
+	// MessageID is the reply's generated Message-ID, which you should record
+	// so the customer's response can be threaded.
+	MessageID string
+
+	// Subject is the reply's subject line.
+	Subject string
+
+	// Raw is the complete RFC 5322 message, ready to send over SMTP.
+	Raw []byte
+}
+
+// ReplyToAgentMessage builds a reply containing an agent message.
+func ReplyToAgentMessage(ev *client.AgentMessageEvent, original *Message, from mail.Address) (*Reply, error) {
+	return BuildReply(ReplyParams{From: from, Original: original, Body: ev.Body})
+}
+
+// BuildReply builds a multipart/alternative reply (plain text and HTML)
+// threaded onto the original email with In-Reply-To and References.
+func BuildReply(p ReplyParams) (*Reply, error) {
+	if p.Original == nil {
+		return nil, errors.New("email: reply has no original message")
+	}
+
+	to := p.To
+	if len(to) == 0 && p.Original.From != nil {
+		to = []*mail.Address{p.Original.From}
+	}
+	if len(to) == 0 {
+		return nil, errors.New("email: reply has no recipients")
+	}
+
+	domain := p.MessageIDDomain
+	if domain == "" {
+		if at := strings.LastIndex(p.From.Address, "@"); at >= 0 {
+			domain = p.From.Address[at+1:]
+		}
+	}
+	messageID, err := newMessageID(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	date := p.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	rendered := format.Render(p.Body, client.ChannelEmail, format.Options{Subject: p.Original.Subject})
+
+	references := append([]string{}, p.Original.References...)
+	if p.Original.MessageID != "" {
+		references = append(references, p.Original.MessageID)
+	}
+
+	buf := new(bytes.Buffer)
+	recipients := make([]string, len(to))
+	for i, a := range to {
+		recipients[i] = a.String()
+	}
+
+	mw := multipart.NewWriter(buf)
+	header := []struct{ key, value string }{
+		{"From", p.From.String()},
+		{"To", strings.Join(recipients, ", ")},
+		{"Subject", mime.QEncoding.Encode("utf-8", rendered.Subject)},
+		{"Date", date.Format(time.RFC1123Z)},
+		{"Message-ID", messageID},
+		{"In-Reply-To", p.Original.MessageID},
+		{"References", strings.Join(references, " ")},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", fmt.Sprintf("multipart/alternative; boundary=%q", mw.Boundary())},
+	}
+	for _, h := range header {
+		if h.value != "" {
+			fmt.Fprintf(buf, "%s: %s\r\n", h.key, h.value)
+		}
+	}
+	buf.WriteString("\r\n")
+
+	for _, part := range []struct{ contentType, body string }{
+		{"text/plain; charset=utf-8", rendered.Text},
+		{"text/html; charset=utf-8", rendered.Body},
+	} {
+		w, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write([]byte(part.body)); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+
+	return &Reply{
+		MessageID: messageID,
+		Subject:   rendered.Subject,
+		Raw:       buf.Bytes(),
+	}, nil
+}
+
+func newMessageID(domain string) (string, error) {
+	if domain == "" {
+		domain = "localhost"
+	}
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("<%s@%s>", hex.EncodeToString(b), domain), nil
+}
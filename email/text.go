@@ -0,0 +1,79 @@
+package email
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	// Attribution lines that introduce a quoted reply, e.g. "On Mon, 1 Jan
+	// 2024 at 10:00, Jane <jane@example.com> wrote:". They may be wrapped
+	// over two lines.
+	attributionPattern = regexp.MustCompile(`(?im)^\s*On\b[^\n]*(\n[^\n]*)?\bwrote:\s*$`)
+
+	// Separators that Outlook and other clients put above forwarded or
+	// quoted messages.
+	separatorPattern = regexp.MustCompile(`(?im)^\s*(-{2,}\s*Original Message\s*-{2,}|_{5,}|From:\s.*\n\s*(Sent|Date):\s)`)
+)
+
+// StripQuotedReply removes the previous messages that email clients quote
+// below (or interleave with) a reply, returning only the new content.
+func StripQuotedReply(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+
+	cut := len(text)
+	if loc := attributionPattern.FindStringIndex(text); loc != nil && loc[0] < cut {
+		cut = loc[0]
+	}
+	if loc := separatorPattern.FindStringIndex(text); loc != nil && loc[0] < cut {
+		cut = loc[0]
+	}
+	text = text[:cut]
+
+	// Drop any remaining "> " quoted lines.
+	lines := strings.Split(text, "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), ">") {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return strings.TrimSpace(strings.Join(kept, "\n"))
+}
+
+var (
+	invisibleElementPattern = regexp.MustCompile(`(?is)<(head|style|script|title)\b.*?</(head|style|script|title)\s*>`)
+	commentPattern          = regexp.MustCompile(`(?s)<!--.*?-->`)
+	blockquotePattern       = regexp.MustCompile(`(?is)<blockquote\b.*?</blockquote\s*>`)
+	lineBreakPattern        = regexp.MustCompile(`(?i)<br\s*/?>`)
+	blockEndPattern         = regexp.MustCompile(`(?i)</(p|div|h[1-6]|tr|table|ul|ol)\s*>`)
+	listItemPattern         = regexp.MustCompile(`(?i)<li\b[^>]*>`)
+	tagPattern              = regexp.MustCompile(`(?s)<[^>]*>`)
+	spacePattern            = regexp.MustCompile(`[ \t\x{00a0}]+`)
+	blankLinesPattern       = regexp.MustCompile(`\n{3,}`)
+)
+
+// HTMLToText converts an HTML email body to plain text. Quoted replies in
+// <blockquote> elements are dropped.
+func HTMLToText(s string) string {
+	s = invisibleElementPattern.ReplaceAllString(s, "")
+	s = commentPattern.ReplaceAllString(s, "")
+	s = blockquotePattern.ReplaceAllString(s, "")
+	s = strings.NewReplacer("\r", "", "\n", " ").Replace(s)
+	s = lineBreakPattern.ReplaceAllString(s, "\n")
+	s = blockEndPattern.ReplaceAllString(s, "\n\n")
+	s = listItemPattern.ReplaceAllString(s, "\n- ")
+	s = tagPattern.ReplaceAllString(s, "")
+	s = html.UnescapeString(s)
+	s = spacePattern.ReplaceAllString(s, " ")
+
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	s = strings.Join(lines, "\n")
+	s = blankLinesPattern.ReplaceAllString(s, "\n\n")
+	return strings.TrimSpace(s)
+}
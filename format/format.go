@@ -0,0 +1,120 @@
+// Package format renders the Markdown bodies of agent messages for each
+// channel: HTML for email, sanitised HTML for chat widgets, and plain text or
+// SSML for voice.
+package format
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	client "github.com/gradientlabs-ai/gradientlabs-go"
+)
+
+// ContentType identifies the format of a rendered message.
+type ContentType string
+
+const (
+	// ContentTypeHTML indicates an HTML body, used for email and chat.
+	ContentTypeHTML ContentType = "text/html"
+
+	// ContentTypeText indicates a plain-text body.
+	ContentTypeText ContentType = "text/plain"
+
+	// ContentTypeSSML indicates an SSML body, for text-to-speech.
+	ContentTypeSSML ContentType = "application/ssml+xml"
+)
+
+// Message is an agent message rendered for a channel.
+type Message struct {
+	// Channel is the channel the message was rendered for.
+	Channel client.Channel
+
+	// Subject is the subject line for email replies, threaded onto the
+	// subject of the conversation's previous email.
+	Subject string
+
+	// Body is the rendered message body.
+	Body string
+
+	// ContentType is the format of Body.
+	ContentType ContentType
+
+	// Text is a plain-text version of the body (e.g. for the text/plain part
+	// of an email, or for voice providers that do not support SSML).
+	Text string
+}
+
+// Options customise how agent messages are rendered.
+type Options struct {
+	// Subject is the subject of the email being replied to (e.g. the last
+	// AddMessageParams.Subject sent for the conversation). Only used for
+	// ChannelEmail.
+	Subject string
+
+	// VoicePlainText renders voice messages as plain text instead of SSML.
+	VoicePlainText bool
+
+	// ChatLinkTarget is the target attribute for links in chat messages.
+	// Defaults to "_blank".
+	ChatLinkTarget string
+}
+
+// AgentMessage renders an `agent.message` body for the given channel.
+func AgentMessage(ev *client.AgentMessageEvent, channel client.Channel, opts Options) Message {
+	return Render(ev.Body, channel, opts)
+}
+
+// Render renders a Markdown body for the given channel.
+func Render(markdown string, channel client.Channel, opts Options) Message {
+	msg := Message{Channel: channel, Text: PlainText(markdown)}
+
+	switch channel {
+	case client.ChannelEmail:
+		msg.Subject = ReplySubject(opts.Subject)
+		msg.Body = EmailHTML(markdown, msg.Subject)
+		msg.ContentType = ContentTypeHTML
+	case client.ChannelVoice:
+		if opts.VoicePlainText {
+			msg.Body = msg.Text
+			msg.ContentType = ContentTypeText
+		} else {
+			msg.Body = SSML(markdown, SSMLOptions{SkipCode: true})
+			msg.ContentType = ContentTypeSSML
+		}
+	default:
+		target := opts.ChatLinkTarget
+		if target == "" {
+			target = "_blank"
+		}
+		msg.Body = HTML(markdown, HTMLOptions{LinkTarget: target})
+		msg.ContentType = ContentTypeHTML
+	}
+	return msg
+}
+
+// EmailHTML renders Markdown as a complete HTML email document.
+func EmailHTML(markdown, subject string) string {
+	b := new(strings.Builder)
+	b.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	if subject != "" {
+		fmt.Fprintf(b, "<title>%s</title>\n", html.EscapeString(subject))
+	}
+	b.WriteString("</head>\n<body>\n")
+	b.WriteString(HTML(markdown, HTMLOptions{}))
+	b.WriteString("</body>\n</html>\n")
+	return b.String()
+}
+
+// ReplySubject returns the subject line for a reply, adding a single "Re: "
+// prefix so the reply is threaded with the original email.
+func ReplySubject(subject string) string {
+	subject = strings.TrimSpace(subject)
+	if subject == "" {
+		return ""
+	}
+	for strings.HasPrefix(strings.ToLower(subject), "re:") {
+		subject = strings.TrimSpace(subject[3:])
+	}
+	return "Re: " + subject
+}
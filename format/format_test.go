@@ -0,0 +1,145 @@
+package format_test
+
+import (
+	"strings"
+	"testing"
+
+	client "github.com/gradientlabs-ai/gradientlabs-go"
+	"github.com/gradientlabs-ai/gradientlabs-go/format"
+)
+
+const sample = "# Your card\n\n" +
+	"Hi **Sam**, your card is _on its way_.\n" +
+	"- Track it [here](https://example.com/t?a=1&b=2)\n" +
+	"- Or [not](javascript:alert(1))\n\n" +
+	"1. First\n2. Second\n\n" +
+	"> quoted <b>\n\n" +
+	"```\ncode <x>\n```\n" +
+	"Use `abc`."
+
+func TestHTML(t *testing.T) {
+	want := "<h1>Your card</h1>\n" +
+		"<p>Hi <strong>Sam</strong>, your card is <em>on its way</em>.</p>\n" +
+		"<ul>\n" +
+		"<li>Track it <a href=\"https://example.com/t?a=1&amp;b=2\" target=\"_blank\" rel=\"noopener noreferrer\">here</a></li>\n" +
+		"<li>Or not</li>\n" +
+		"</ul>\n" +
+		"<ol>\n<li>First</li>\n<li>Second</li>\n</ol>\n" +
+		"<blockquote>quoted &lt;b&gt;</blockquote>\n" +
+		"<pre><code>code &lt;x&gt;</code></pre>\n" +
+		"<p>Use <code>abc</code>.</p>\n"
+
+	if got := format.HTML(sample, format.HTMLOptions{LinkTarget: "_blank"}); got != want {
+		t.Errorf("HTML =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestPlainText(t *testing.T) {
+	want := "Your card\n\n" +
+		"Hi Sam, your card is on its way.\n\n" +
+		"- Track it here (https://example.com/t?a=1&b=2)\n" +
+		"- Or not\n\n" +
+		"1. First\n2. Second\n\n" +
+		"> quoted <b>\n\n" +
+		"code <x>\n\n" +
+		"Use abc."
+
+	if got := format.PlainText(sample); got != want {
+		t.Errorf("PlainText =\n%q\nwant:\n%q", got, want)
+	}
+}
+
+func TestSSML(t *testing.T) {
+	want := `<speak><p>Your card</p>` +
+		`<p>Hi <emphasis level="strong">Sam</emphasis>, your card is <emphasis level="moderate">on its way</emphasis>.</p>` +
+		`<p><s>Track it here</s><break time="300ms"/><s>Or not</s></p>` +
+		`<p><s>First</s><break time="300ms"/><s>Second</s></p>` +
+		`<p>quoted &lt;b&gt;</p>` +
+		`<p>Use abc.</p></speak>`
+
+	if got := format.SSML(sample, format.SSMLOptions{SkipCode: true}); got != want {
+		t.Errorf("SSML =\n%s\nwant:\n%s", got, want)
+	}
+
+	got := format.SSML("- a\n- b", format.SSMLOptions{ListPause: "1s"})
+	if !strings.Contains(got, `<break time="1s"/>`) {
+		t.Errorf("SSML with ListPause = %s", got)
+	}
+}
+
+func TestLinkURLsWithParentheses(t *testing.T) {
+	got := format.HTML("See [the docs](https://en.wikipedia.org/wiki/Go_(language)).", format.HTMLOptions{})
+	want := `<p>See <a href="https://en.wikipedia.org/wiki/Go_(language)">the docs</a>.</p>` + "\n"
+	if got != want {
+		t.Errorf("HTML = %q, want %q", got, want)
+	}
+}
+
+func TestRender(t *testing.T) {
+	testCases := map[string]struct {
+		channel     client.Channel
+		opts        format.Options
+		contentType format.ContentType
+		subject     string
+		contains    string
+	}{
+		"chat": {
+			channel:     client.ChannelChat,
+			contentType: format.ContentTypeHTML,
+			contains:    `target="_blank"`,
+		},
+		"chat link target": {
+			channel:     client.ChannelChat,
+			opts:        format.Options{ChatLinkTarget: "_self"},
+			contentType: format.ContentTypeHTML,
+			contains:    `target="_self"`,
+		},
+		"email": {
+			channel:     client.ChannelEmail,
+			opts:        format.Options{Subject: "RE: Re: Lost card"},
+			contentType: format.ContentTypeHTML,
+			subject:     "Re: Lost card",
+			contains:    "<title>Re: Lost card</title>",
+		},
+		"voice": {
+			channel:     client.ChannelVoice,
+			contentType: format.ContentTypeSSML,
+			contains:    "<speak>",
+		},
+		"voice plain text": {
+			channel:     client.ChannelVoice,
+			opts:        format.Options{VoicePlainText: true},
+			contentType: format.ContentTypeText,
+			contains:    "Track it here (https://example.com",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			msg := format.AgentMessage(&client.AgentMessageEvent{Body: sample}, tc.channel, tc.opts)
+
+			if msg.Channel != tc.channel || msg.ContentType != tc.contentType || msg.Subject != tc.subject {
+				t.Errorf("message = %+v", msg)
+			}
+			if !strings.Contains(msg.Body, tc.contains) {
+				t.Errorf("Body does not contain %q:\n%s", tc.contains, msg.Body)
+			}
+			if msg.Text != format.PlainText(sample) {
+				t.Errorf("Text = %q", msg.Text)
+			}
+		})
+	}
+}
+
+func TestReplySubject(t *testing.T) {
+	for in, want := range map[string]string{
+		"":                  "",
+		"Lost card":         "Re: Lost card",
+		"Re: Lost card":     "Re: Lost card",
+		" re: RE:Lost card": "Re: Lost card",
+	} {
+		if got := format.ReplySubject(in); got != want {
+			t.Errorf("ReplySubject(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package format
+
+import (
+	"regexp"
+	"strings"
+)
+
+// The agent writes Markdown, but only a small subset of it: paragraphs,
+// headings, bullet and numbered lists, block quotes, fenced code, and inline
+// emphasis, code and links. This file parses that subset into blocks, which
+// the renderers turn into each channel's format.
+
+type blockKind int
+
+const (
+	blockParagraph blockKind = iota
+	blockHeading
+	blockBulletList
+	blockNumberedList
+	blockQuote
+	blockCode
+)
+
+type block struct {
+	kind  blockKind
+	level int      // heading level
+	lines []string // raw lines (paragraph, quote, code) or list items
+}
+
+var (
+	headingPattern  = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	bulletPattern   = regexp.MustCompile(`^\s*[-*+]\s+(.*)$`)
+	numberedPattern = regexp.MustCompile(`^\s*\d+[.)]\s+(.*)$`)
+	quotePattern    = regexp.MustCompile(`^>\s?(.*)$`)
+)
+
+func parseBlocks(markdown string) []block {
+	var (
+		blocks []block
+		cur    *block
+	)
+	flush := func() {
+		if cur != nil {
+			blocks = append(blocks, *cur)
+			cur = nil
+		}
+	}
+
+	lines := strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "```") {
+			flush()
+			code := block{kind: blockCode}
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```"); i++ {
+				code.lines = append(code.lines, lines[i])
+			}
+			blocks = append(blocks, code)
+			continue
+		}
+
+		if trimmed == "" {
+			flush()
+			continue
+		}
+
+		if m := headingPattern.FindStringSubmatch(trimmed); m != nil {
+			flush()
+			blocks = append(blocks, block{kind: blockHeading, level: len(m[1]), lines: []string{m[2]}})
+			continue
+		}
+
+		if m := bulletPattern.FindStringSubmatch(line); m != nil {
+			if cur == nil || cur.kind != blockBulletList {
+				flush()
+				cur = &block{kind: blockBulletList}
+			}
+			cur.lines = append(cur.lines, m[1])
+			continue
+		}
+
+		if m := numberedPattern.FindStringSubmatch(line); m != nil {
+			if cur == nil || cur.kind != blockNumberedList {
+				flush()
+				cur = &block{kind: blockNumberedList}
+			}
+			cur.lines = append(cur.lines, m[1])
+			continue
+		}
+
+		if m := quotePattern.FindStringSubmatch(trimmed); m != nil {
+			if cur == nil || cur.kind != blockQuote {
+				flush()
+				cur = &block{kind: blockQuote}
+			}
+			cur.lines = append(cur.lines, m[1])
+			continue
+		}
+
+		// Lazy continuation of a list item or paragraph.
+		switch {
+		case cur != nil && (cur.kind == blockBulletList || cur.kind == blockNumberedList):
+			cur.lines[len(cur.lines)-1] += " " + trimmed
+		case cur != nil && cur.kind == blockParagraph:
+			cur.lines = append(cur.lines, trimmed)
+		default:
+			flush()
+			cur = &block{kind: blockParagraph, lines: []string{trimmed}}
+		}
+	}
+	flush()
+	return blocks
+}
+
+// span is a run of inline text with its formatting.
+type span struct {
+	text   string
+	bold   bool
+	italic bool
+	code   bool
+	href   string
+}
+
+var inlinePattern = regexp.MustCompile("`([^`]+)`" + // code
+	`|\[([^\]]+)\]\(((?:[^()\s]|\([^()\s]*\))+)\)` + // link, allowing balanced parentheses in the URL
+	`|\*\*(.+?)\*\*|__(.+?)__` + // bold
+	`|\*([^*\s][^*]*?)\*|\b_([^_\s][^_]*?)_\b`) // italic
+
+func parseInline(text string) []span {
+	var spans []span
+	for len(text) > 0 {
+		loc := inlinePattern.FindStringSubmatchIndex(text)
+		if loc == nil {
+			spans = append(spans, span{text: text})
+			break
+		}
+		if loc[0] > 0 {
+			spans = append(spans, span{text: text[:loc[0]]})
+		}
+
+		group := func(n int) string {
+			if loc[2*n] < 0 {
+				return ""
+			}
+			return text[loc[2*n]:loc[2*n+1]]
+		}
+		switch {
+		case loc[2] >= 0:
+			spans = append(spans, span{text: group(1), code: true})
+		case loc[4] >= 0:
+			spans = append(spans, span{text: group(2), href: group(3)})
+		case loc[8] >= 0:
+			spans = append(spans, span{text: group(4), bold: true})
+		case loc[10] >= 0:
+			spans = append(spans, span{text: group(5), bold: true})
+		case loc[12] >= 0:
+			spans = append(spans, span{text: group(6), italic: true})
+		case loc[14] >= 0:
+			spans = append(spans, span{text: group(7), italic: true})
+		}
+		text = text[loc[1]:]
+	}
+	return spans
+}
+
+// safeURL reports whether a link target may be rendered as a hyperlink.
+func safeURL(href string) bool {
+	lower := strings.ToLower(href)
+	return strings.HasPrefix(lower, "https://") ||
+		strings.HasPrefix(lower, "http://") ||
+		strings.HasPrefix(lower, "mailto:") ||
+		strings.HasPrefix(lower, "tel:")
+}
@@ -0,0 +1,209 @@
+package format
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// HTMLOptions customise HTML rendering.
+type HTMLOptions struct {
+	// LinkTarget optionally sets the target attribute of links (e.g.
+	// "_blank" for chat widgets). Links with a target also get
+	// rel="noopener noreferrer".
+	LinkTarget string
+}
+
+// HTML renders Markdown as an HTML fragment. The input is treated as text, so
+// any HTML it contains is escaped, and only http(s), mailto and tel links are
+// rendered, making the output safe to insert into a page.
+func HTML(markdown string, opts HTMLOptions) string {
+	b := new(strings.Builder)
+	for _, blk := range parseBlocks(markdown) {
+		switch blk.kind {
+		case blockParagraph:
+			b.WriteString("<p>")
+			for i, line := range blk.lines {
+				if i > 0 {
+					b.WriteString("<br>\n")
+				}
+				writeInlineHTML(b, line, opts)
+			}
+			b.WriteString("</p>\n")
+		case blockHeading:
+			fmt.Fprintf(b, "<h%d>", blk.level)
+			writeInlineHTML(b, blk.lines[0], opts)
+			fmt.Fprintf(b, "</h%d>\n", blk.level)
+		case blockBulletList, blockNumberedList:
+			tag := "ul"
+			if blk.kind == blockNumberedList {
+				tag = "ol"
+			}
+			fmt.Fprintf(b, "<%s>\n", tag)
+			for _, item := range blk.lines {
+				b.WriteString("<li>")
+				writeInlineHTML(b, item, opts)
+				b.WriteString("</li>\n")
+			}
+			fmt.Fprintf(b, "</%s>\n", tag)
+		case blockQuote:
+			b.WriteString("<blockquote>")
+			for i, line := range blk.lines {
+				if i > 0 {
+					b.WriteString("<br>\n")
+				}
+				writeInlineHTML(b, line, opts)
+			}
+			b.WriteString("</blockquote>\n")
+		case blockCode:
+			b.WriteString("<pre><code>")
+			b.WriteString(html.EscapeString(strings.Join(blk.lines, "\n")))
+			b.WriteString("</code></pre>\n")
+		}
+	}
+	return b.String()
+}
+
+func writeInlineHTML(b *strings.Builder, text string, opts HTMLOptions) {
+	for _, s := range parseInline(text) {
+		escaped := html.EscapeString(s.text)
+		switch {
+		case s.code:
+			fmt.Fprintf(b, "<code>%s</code>", escaped)
+		case s.href != "" && safeURL(s.href):
+			fmt.Fprintf(b, `<a href="%s"`, html.EscapeString(s.href))
+			if opts.LinkTarget != "" {
+				fmt.Fprintf(b, ` target="%s" rel="noopener noreferrer"`, html.EscapeString(opts.LinkTarget))
+			}
+			fmt.Fprintf(b, ">%s</a>", escaped)
+		case s.bold:
+			fmt.Fprintf(b, "<strong>%s</strong>", escaped)
+		case s.italic:
+			fmt.Fprintf(b, "<em>%s</em>", escaped)
+		default:
+			b.WriteString(escaped)
+		}
+	}
+}
+
+// PlainText renders Markdown as plain text, removing inline markup. Links are
+// written as "text (url)".
+func PlainText(markdown string) string {
+	b := new(strings.Builder)
+	for i, blk := range parseBlocks(markdown) {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		switch blk.kind {
+		case blockBulletList:
+			for _, item := range blk.lines {
+				b.WriteString("- ")
+				writeInlineText(b, item)
+				b.WriteString("\n")
+			}
+		case blockNumberedList:
+			for n, item := range blk.lines {
+				fmt.Fprintf(b, "%d. ", n+1)
+				writeInlineText(b, item)
+				b.WriteString("\n")
+			}
+		case blockQuote:
+			for _, line := range blk.lines {
+				b.WriteString("> ")
+				writeInlineText(b, line)
+				b.WriteString("\n")
+			}
+		case blockCode:
+			for _, line := range blk.lines {
+				b.WriteString(line)
+				b.WriteString("\n")
+			}
+		default:
+			for _, line := range blk.lines {
+				writeInlineText(b, line)
+				b.WriteString("\n")
+			}
+		}
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func writeInlineText(b *strings.Builder, text string) {
+	for _, s := range parseInline(text) {
+		b.WriteString(s.text)
+		if s.href != "" && s.href != s.text && safeURL(s.href) {
+			fmt.Fprintf(b, " (%s)", strings.TrimPrefix(s.href, "mailto:"))
+		}
+	}
+}
+
+// SSMLOptions customise SSML rendering.
+type SSMLOptions struct {
+	// ListPause is the pause between list items (e.g. "300ms"). Defaults to
+	// "300ms".
+	ListPause string
+
+	// SkipCode omits code blocks, which rarely make sense when spoken.
+	SkipCode bool
+}
+
+// SSML renders Markdown as Speech Synthesis Markup Language for voice
+// channels. Headings and paragraphs become <p> elements, list items are
+// separated by pauses, emphasis is preserved, and links are spoken as their
+// text only.
+func SSML(markdown string, opts SSMLOptions) string {
+	pause := opts.ListPause
+	if pause == "" {
+		pause = "300ms"
+	}
+
+	b := new(strings.Builder)
+	b.WriteString("<speak>")
+	for _, blk := range parseBlocks(markdown) {
+		switch blk.kind {
+		case blockBulletList, blockNumberedList:
+			b.WriteString("<p>")
+			for i, item := range blk.lines {
+				if i > 0 {
+					fmt.Fprintf(b, `<break time="%s"/>`, pause)
+				}
+				b.WriteString("<s>")
+				writeInlineSSML(b, item)
+				b.WriteString("</s>")
+			}
+			b.WriteString("</p>")
+		case blockCode:
+			if opts.SkipCode {
+				continue
+			}
+			b.WriteString("<p>")
+			b.WriteString(html.EscapeString(strings.Join(blk.lines, " ")))
+			b.WriteString("</p>")
+		default:
+			b.WriteString("<p>")
+			for i, line := range blk.lines {
+				if i > 0 {
+					b.WriteString(" ")
+				}
+				writeInlineSSML(b, line)
+			}
+			b.WriteString("</p>")
+		}
+	}
+	b.WriteString("</speak>")
+	return b.String()
+}
+
+func writeInlineSSML(b *strings.Builder, text string) {
+	for _, s := range parseInline(text) {
+		escaped := html.EscapeString(s.text)
+		switch {
+		case s.bold:
+			fmt.Fprintf(b, `<emphasis level="strong">%s</emphasis>`, escaped)
+		case s.italic:
+			fmt.Fprintf(b, `<emphasis level="moderate">%s</emphasis>`, escaped)
+		default:
+			b.WriteString(escaped)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+// Command mockgen generates the glabsmock.Mock methods from the interfaces in
+// the client package's api.go.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"strings"
+)
+
+func main() {
+	in := flag.String("in", "../api.go", "file declaring the API interfaces")
+	out := flag.String("out", "mock_gen.go", "file to write")
+	flag.Parse()
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, *in, nil, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	buf := new(bytes.Buffer)
+	fmt.Fprintln(buf, "// Code generated by mockgen. DO NOT EDIT.")
+	fmt.Fprintln(buf)
+	fmt.Fprintln(buf, "package glabsmock")
+	fmt.Fprintln(buf)
+	fmt.Fprintln(buf, "import (")
+	fmt.Fprintln(buf, "\t\"context\"")
+	fmt.Fprintln(buf)
+	fmt.Fprintln(buf, "\tclient \"github.com/gradientlabs-ai/gradientlabs-go\"")
+	fmt.Fprintln(buf, ")")
+	fmt.Fprintln(buf)
+	fmt.Fprintln(buf, "var _ client.API = (*Mock)(nil)")
+
+	seen := make(map[string]bool)
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			iface, ok := spec.(*ast.TypeSpec).Type.(*ast.InterfaceType)
+			if !ok {
+				continue
+			}
+			for _, m := range iface.Methods.List {
+				fn, ok := m.Type.(*ast.FuncType)
+				if !ok || len(m.Names) == 0 || seen[m.Names[0].Name] {
+					continue
+				}
+				seen[m.Names[0].Name] = true
+				writeMethod(buf, m.Names[0].Name, fn)
+			}
+		}
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("formatting generated code: %v\n%s", err, buf.Bytes())
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func writeMethod(buf *bytes.Buffer, name string, fn *ast.FuncType) {
+	var (
+		params []string
+		args   []string
+		call   []string
+	)
+	for i, p := range fn.Params.List {
+		typ := typeString(p.Type)
+		names := p.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{ast.NewIdent(fmt.Sprintf("arg%d", i))}
+		}
+		for _, n := range names {
+			params = append(params, n.Name+" "+typ)
+			if _, variadic := p.Type.(*ast.Ellipsis); variadic {
+				continue
+			}
+			args = append(args, n.Name)
+		}
+	}
+
+	var results []string
+	if fn.Results != nil {
+		for _, r := range fn.Results.List {
+			results = append(results, typeString(r.Type))
+		}
+	}
+
+	call = append(call, fmt.Sprintf("%q", name), fmt.Sprint(len(results)))
+	call = append(call, args...)
+
+	fmt.Fprintln(buf)
+	fmt.Fprintf(buf, "// %s records the call and returns the values configured with On.\n", name)
+	fmt.Fprintf(buf, "func (m *Mock) %s(%s) ", name, strings.Join(params, ", "))
+	switch len(results) {
+	case 0:
+		fmt.Fprintln(buf, "{")
+	case 1:
+		fmt.Fprintf(buf, "%s {\n", results[0])
+	default:
+		fmt.Fprintf(buf, "(%s) {\n", strings.Join(results, ", "))
+	}
+
+	if len(results) == 0 {
+		fmt.Fprintf(buf, "\tm.called(%s)\n}\n", strings.Join(call, ", "))
+		return
+	}
+
+	fmt.Fprintf(buf, "\tret := m.called(%s)\n", strings.Join(call, ", "))
+	var returns []string
+	for i, typ := range results {
+		if typ == "error" {
+			returns = append(returns, fmt.Sprintf("errorResult(ret[%d])", i))
+			continue
+		}
+		fmt.Fprintf(buf, "\tr%d, _ := ret[%d].(%s)\n", i, i, typ)
+		returns = append(returns, fmt.Sprintf("r%d", i))
+	}
+	fmt.Fprintf(buf, "\treturn %s\n}\n", strings.Join(returns, ", "))
+}
+
+// typeString renders a type expression from the client package, qualifying its
+// exported identifiers.
+func typeString(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if t.IsExported() {
+			return "client." + t.Name
+		}
+		return t.Name
+	case *ast.SelectorExpr:
+		return typeString(t.X) + "." + t.Sel.Name
+	case *ast.StarExpr:
+		return "*" + typeString(t.X)
+	case *ast.ArrayType:
+		return "[]" + typeString(t.Elt)
+	case *ast.MapType:
+		return "map[" + typeString(t.Key) + "]" + typeString(t.Value)
+	case *ast.Ellipsis:
+		return "..." + typeString(t.Elt)
+	case *ast.InterfaceType:
+		return "interface{}"
+	default:
+		log.Fatalf("unsupported type expression %T", expr)
+		return ""
+	}
+}
@@ -0,0 +1,267 @@
+// Package glabsmock provides a mock implementation of the client's API
+// interfaces for use in tests.
+//
+// Configure return values with On and Return, and inspect what was called with
+// Calls:
+//
+//	m := glabsmock.New(t)
+//	m.On("StartConversation").Return(&client.Conversation{ID: "conv-1"}, nil)
+//
+//	svc := NewService(m) // accepts a client.ConversationAPI
+//	...
+//
+//	m.AssertExpectations(t)
+//
+// Calls without a matching expectation return zero values, or fail the test if
+// the mock was created with NewStrict.
+package glabsmock
+
+//go:generate go run ./internal/mockgen -in ../api.go -out mock_gen.go
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// T is the subset of testing.TB used by Mock.
+type T interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// Mock implements client.API (and therefore each of the per-area interfaces)
+// by recording calls and returning the values configured with On.
+type Mock struct {
+	t      T
+	strict bool
+
+	mu           sync.Mutex
+	calls        []Call
+	expectations []*Expectation
+}
+
+// New creates a Mock. Calls without a matching expectation return zero values.
+func New(t T) *Mock {
+	return &Mock{t: t}
+}
+
+// NewStrict creates a Mock that fails the test on calls without a matching
+// expectation.
+func NewStrict(t T) *Mock {
+	return &Mock{t: t, strict: true}
+}
+
+// Call is a recorded method call.
+type Call struct {
+	// Method is the name of the method that was called.
+	Method string
+
+	// Ctx is the context the method was called with.
+	Ctx context.Context
+
+	// Args contains the arguments, excluding the leading context and the
+	// trailing call options.
+	Args []any
+}
+
+// Anything matches any argument value when passed to On.
+var Anything = anything{}
+
+type anything struct{}
+
+// MatchedBy returns an argument matcher for On that calls fn with the argument.
+func MatchedBy(fn func(arg any) bool) any {
+	return matcherFunc(fn)
+}
+
+type matcherFunc func(arg any) bool
+
+// Expectation configures how a Mock responds to calls of a method. Create one
+// with Mock.On.
+type Expectation struct {
+	method  string
+	args    []any
+	results []any
+	run     func(args []any)
+	times   int
+	calls   int
+}
+
+// On adds an expectation for calls to the named method. Optional args are
+// matched against the call's arguments in order, using reflect.DeepEqual,
+// Anything or MatchedBy. The context and call options are not matched, so
+// args start from the argument after ctx:
+//
+//	m.On("ReadConversation", "conv-1", glabsmock.Anything)
+//
+// If no args are given, every call matches.
+func (m *Mock) On(method string, args ...any) *Expectation {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e := &Expectation{method: method, args: args}
+	m.expectations = append(m.expectations, e)
+	return e
+}
+
+// Return sets the values returned by matching calls, in the same order as the
+// method's results.
+func (e *Expectation) Return(results ...any) *Expectation {
+	e.results = results
+	return e
+}
+
+// Run sets a function to be called with the arguments of matching calls (e.g.
+// to capture them), excluding the context and call options as with On.
+func (e *Expectation) Run(fn func(args []any)) *Expectation {
+	e.run = fn
+	return e
+}
+
+// Times limits the expectation to matching exactly n calls. Once used up, later
+// calls fall through to the next matching expectation.
+func (e *Expectation) Times(n int) *Expectation {
+	e.times = n
+	return e
+}
+
+// Once is shorthand for Times(1).
+func (e *Expectation) Once() *Expectation {
+	return e.Times(1)
+}
+
+func (e *Expectation) matches(args []any) bool {
+	if e.times > 0 && e.calls >= e.times {
+		return false
+	}
+	if len(e.args) == 0 {
+		return true
+	}
+	if len(e.args) != len(args) {
+		return false
+	}
+	for i, want := range e.args {
+		switch w := want.(type) {
+		case anything:
+			continue
+		case matcherFunc:
+			if !w(args[i]) {
+				return false
+			}
+		default:
+			if !reflect.DeepEqual(want, args[i]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Calls returns the recorded calls to the named method, or all calls if method
+// is empty.
+func (m *Mock) Calls(method string) []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var calls []Call
+	for _, c := range m.calls {
+		if method == "" || c.Method == method {
+			calls = append(calls, c)
+		}
+	}
+	return calls
+}
+
+// AssertExpectations fails the test if an expectation was not met: each must
+// have matched at least one call, or exactly n calls if Times was used.
+func (m *Mock) AssertExpectations(t T) bool {
+	t.Helper()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ok := true
+	for _, e := range m.expectations {
+		switch {
+		case e.times > 0 && e.calls != e.times:
+			t.Errorf("glabsmock: expected %d call(s) to %s%s, got %d", e.times, e.method, formatArgs(e.args), e.calls)
+			ok = false
+		case e.times == 0 && e.calls == 0:
+			t.Errorf("glabsmock: expected a call to %s%s, got none", e.method, formatArgs(e.args))
+			ok = false
+		}
+	}
+	return ok
+}
+
+// AssertCalled fails the test if the named method was not called.
+func (m *Mock) AssertCalled(t T, method string) bool {
+	t.Helper()
+
+	if len(m.Calls(method)) == 0 {
+		t.Errorf("glabsmock: expected a call to %s, got none", method)
+		return false
+	}
+	return true
+}
+
+// AssertNotCalled fails the test if the named method was called.
+func (m *Mock) AssertNotCalled(t T, method string) bool {
+	t.Helper()
+
+	if n := len(m.Calls(method)); n != 0 {
+		t.Errorf("glabsmock: expected no calls to %s, got %d", method, n)
+		return false
+	}
+	return true
+}
+
+// called records a call and returns the results of the first matching
+// expectation, padded with nils to numResults.
+func (m *Mock) called(method string, numResults int, ctx context.Context, args ...any) []any {
+	m.mu.Lock()
+
+	m.calls = append(m.calls, Call{Method: method, Ctx: ctx, Args: args})
+
+	var match *Expectation
+	for _, e := range m.expectations {
+		if e.method == method && e.matches(args) {
+			match = e
+			break
+		}
+	}
+	if match != nil {
+		match.calls++
+	}
+	m.mu.Unlock()
+
+	results := make([]any, numResults)
+	if match == nil {
+		if m.strict && m.t != nil {
+			m.t.Helper()
+			m.t.Errorf("glabsmock: unexpected call to %s%s", method, formatArgs(args))
+		}
+		return results
+	}
+
+	if match.run != nil {
+		match.run(args)
+	}
+	copy(results, match.results)
+	return results
+}
+
+func formatArgs(args []any) string {
+	if len(args) == 0 {
+		return "()"
+	}
+	s := fmt.Sprintf("%+v", args)
+	return "(" + s[1:len(s)-1] + ")"
+}
+
+func errorResult(v any) error {
+	err, _ := v.(error)
+	return err
+}
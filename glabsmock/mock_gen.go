@@ -0,0 +1,493 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package glabsmock
+
+import (
+	"context"
+
+	client "github.com/gradientlabs-ai/gradientlabs-go"
+)
+
+var _ client.API = (*Mock)(nil)
+
+// StartConversation records the call and returns the values configured with On.
+func (m *Mock) StartConversation(ctx context.Context, p client.StartConversationParams, opts ...client.CallOption) (*client.Conversation, error) {
+	ret := m.called("StartConversation", 2, ctx, p)
+	r0, _ := ret[0].(*client.Conversation)
+	return r0, errorResult(ret[1])
+}
+
+// ReadConversation records the call and returns the values configured with On.
+func (m *Mock) ReadConversation(ctx context.Context, conversationID string, p *client.ReadParams, opts ...client.CallOption) (*client.Conversation, error) {
+	ret := m.called("ReadConversation", 2, ctx, conversationID, p)
+	r0, _ := ret[0].(*client.Conversation)
+	return r0, errorResult(ret[1])
+}
+
+// ListConversations records the call and returns the values configured with On.
+func (m *Mock) ListConversations(ctx context.Context, p *client.ListConversationsParams, opts ...client.CallOption) (*client.ListConversationsResponse, error) {
+	ret := m.called("ListConversations", 2, ctx, p)
+	r0, _ := ret[0].(*client.ListConversationsResponse)
+	return r0, errorResult(ret[1])
+}
+
+// ReadConversationTranscript records the call and returns the values configured with On.
+func (m *Mock) ReadConversationTranscript(ctx context.Context, conversationID string, p *client.ReadTranscriptParams, opts ...client.CallOption) (*client.Transcript, error) {
+	ret := m.called("ReadConversationTranscript", 2, ctx, conversationID, p)
+	r0, _ := ret[0].(*client.Transcript)
+	return r0, errorResult(ret[1])
+}
+
+// AddMessage records the call and returns the values configured with On.
+func (m *Mock) AddMessage(ctx context.Context, conversationID string, p client.AddMessageParams, opts ...client.CallOption) (*client.Message, error) {
+	ret := m.called("AddMessage", 2, ctx, conversationID, p)
+	r0, _ := ret[0].(*client.Message)
+	return r0, errorResult(ret[1])
+}
+
+// AddConversationEvent records the call and returns the values configured with On.
+func (m *Mock) AddConversationEvent(ctx context.Context, conversationID string, p *client.EventParams, opts ...client.CallOption) error {
+	ret := m.called("AddConversationEvent", 1, ctx, conversationID, p)
+	return errorResult(ret[0])
+}
+
+// AddResource records the call and returns the values configured with On.
+func (m *Mock) AddResource(ctx context.Context, conversationID string, name string, resource any, opts ...client.CallOption) error {
+	ret := m.called("AddResource", 1, ctx, conversationID, name, resource)
+	return errorResult(ret[0])
+}
+
+// AssignConversation records the call and returns the values configured with On.
+func (m *Mock) AssignConversation(ctx context.Context, conversationID string, p *client.AssignmentParams, opts ...client.CallOption) error {
+	ret := m.called("AssignConversation", 1, ctx, conversationID, p)
+	return errorResult(ret[0])
+}
+
+// ResumeConversation records the call and returns the values configured with On.
+func (m *Mock) ResumeConversation(ctx context.Context, conversationID string, p *client.ConversationResumeParams, opts ...client.CallOption) error {
+	ret := m.called("ResumeConversation", 1, ctx, conversationID, p)
+	return errorResult(ret[0])
+}
+
+// ReturnAsyncToolResult records the call and returns the values configured with On.
+func (m *Mock) ReturnAsyncToolResult(ctx context.Context, conversationID string, p client.ReturnAsyncToolResultParams, opts ...client.CallOption) error {
+	ret := m.called("ReturnAsyncToolResult", 1, ctx, conversationID, p)
+	return errorResult(ret[0])
+}
+
+// RateConversation records the call and returns the values configured with On.
+func (m *Mock) RateConversation(ctx context.Context, conversationID string, p *client.RatingParams, opts ...client.CallOption) error {
+	ret := m.called("RateConversation", 1, ctx, conversationID, p)
+	return errorResult(ret[0])
+}
+
+// FinishConversation records the call and returns the values configured with On.
+func (m *Mock) FinishConversation(ctx context.Context, conversationID string, p client.FinishParams, opts ...client.CallOption) error {
+	ret := m.called("FinishConversation", 1, ctx, conversationID, p)
+	return errorResult(ret[0])
+}
+
+// CancelConversation records the call and returns the values configured with On.
+func (m *Mock) CancelConversation(ctx context.Context, conversationID string, p client.CancelParams, opts ...client.CallOption) error {
+	ret := m.called("CancelConversation", 1, ctx, conversationID, p)
+	return errorResult(ret[0])
+}
+
+// StartOutboundConversation records the call and returns the values configured with On.
+func (m *Mock) StartOutboundConversation(ctx context.Context, p client.StartOutboundConversationParams, opts ...client.CallOption) (*client.StartOutboundConversationResponse, error) {
+	ret := m.called("StartOutboundConversation", 2, ctx, p)
+	r0, _ := ret[0].(*client.StartOutboundConversationResponse)
+	return r0, errorResult(ret[1])
+}
+
+// ReadLatestVoiceCallContext records the call and returns the values configured with On.
+func (m *Mock) ReadLatestVoiceCallContext(ctx context.Context, phoneNumber string, p *client.ReadVoiceCallContextParams, opts ...client.CallOption) (*client.VoiceCallContext, error) {
+	ret := m.called("ReadLatestVoiceCallContext", 2, ctx, phoneNumber, p)
+	r0, _ := ret[0].(*client.VoiceCallContext)
+	return r0, errorResult(ret[1])
+}
+
+// ListHandOffTargets records the call and returns the values configured with On.
+func (m *Mock) ListHandOffTargets(ctx context.Context, opts ...client.CallOption) (*client.HandOffTargets, error) {
+	ret := m.called("ListHandOffTargets", 2, ctx)
+	r0, _ := ret[0].(*client.HandOffTargets)
+	return r0, errorResult(ret[1])
+}
+
+// UpsertHandOffTarget records the call and returns the values configured with On.
+func (m *Mock) UpsertHandOffTarget(ctx context.Context, p *client.UpsertHandOffTargetParams, opts ...client.CallOption) error {
+	ret := m.called("UpsertHandOffTarget", 1, ctx, p)
+	return errorResult(ret[0])
+}
+
+// DeleteHandOffTarget records the call and returns the values configured with On.
+func (m *Mock) DeleteHandOffTarget(ctx context.Context, p *client.HandOffTargetDeleteParams, opts ...client.CallOption) error {
+	ret := m.called("DeleteHandOffTarget", 1, ctx, p)
+	return errorResult(ret[0])
+}
+
+// GetDefaultHandOffTarget records the call and returns the values configured with On.
+func (m *Mock) GetDefaultHandOffTarget(ctx context.Context, p *client.GetDefaultHandOffTargetParams, opts ...client.CallOption) (*client.GetDefaultHandOffTargetResponse, error) {
+	ret := m.called("GetDefaultHandOffTarget", 2, ctx, p)
+	r0, _ := ret[0].(*client.GetDefaultHandOffTargetResponse)
+	return r0, errorResult(ret[1])
+}
+
+// SetDefaultHandOffTarget records the call and returns the values configured with On.
+func (m *Mock) SetDefaultHandOffTarget(ctx context.Context, p *client.SetDefaultHandOffTargetParams, opts ...client.CallOption) error {
+	ret := m.called("SetDefaultHandOffTarget", 1, ctx, p)
+	return errorResult(ret[0])
+}
+
+// CreateTool records the call and returns the values configured with On.
+func (m *Mock) CreateTool(ctx context.Context, p *client.Tool, opts ...client.CallOption) (*client.Tool, error) {
+	ret := m.called("CreateTool", 2, ctx, p)
+	r0, _ := ret[0].(*client.Tool)
+	return r0, errorResult(ret[1])
+}
+
+// ReadTool records the call and returns the values configured with On.
+func (m *Mock) ReadTool(ctx context.Context, toolID string, p *client.ReadToolParams, opts ...client.CallOption) (*client.Tool, error) {
+	ret := m.called("ReadTool", 2, ctx, toolID, p)
+	r0, _ := ret[0].(*client.Tool)
+	return r0, errorResult(ret[1])
+}
+
+// ListTools records the call and returns the values configured with On.
+func (m *Mock) ListTools(ctx context.Context, opts ...client.CallOption) ([]*client.Tool, error) {
+	ret := m.called("ListTools", 2, ctx)
+	r0, _ := ret[0].([]*client.Tool)
+	return r0, errorResult(ret[1])
+}
+
+// UpdateTool records the call and returns the values configured with On.
+func (m *Mock) UpdateTool(ctx context.Context, p *client.UpdateToolParams, opts ...client.CallOption) (*client.Tool, error) {
+	ret := m.called("UpdateTool", 2, ctx, p)
+	r0, _ := ret[0].(*client.Tool)
+	return r0, errorResult(ret[1])
+}
+
+// DeleteTool records the call and returns the values configured with On.
+func (m *Mock) DeleteTool(ctx context.Context, toolID string, opts ...client.CallOption) error {
+	ret := m.called("DeleteTool", 1, ctx, toolID)
+	return errorResult(ret[0])
+}
+
+// ExecuteTool records the call and returns the values configured with On.
+func (m *Mock) ExecuteTool(ctx context.Context, p *client.ExecutionParams, opts ...client.CallOption) (*client.ExecuteResult, error) {
+	ret := m.called("ExecuteTool", 2, ctx, p)
+	r0, _ := ret[0].(*client.ExecuteResult)
+	return r0, errorResult(ret[1])
+}
+
+// CreateResourceType records the call and returns the values configured with On.
+func (m *Mock) CreateResourceType(ctx context.Context, req *client.ResourceTypeCreateParams, opts ...client.CallOption) (*client.ResourceType, error) {
+	ret := m.called("CreateResourceType", 2, ctx, req)
+	r0, _ := ret[0].(*client.ResourceType)
+	return r0, errorResult(ret[1])
+}
+
+// ReadResourceType records the call and returns the values configured with On.
+func (m *Mock) ReadResourceType(ctx context.Context, id string, opts ...client.CallOption) (*client.ResourceType, error) {
+	ret := m.called("ReadResourceType", 2, ctx, id)
+	r0, _ := ret[0].(*client.ResourceType)
+	return r0, errorResult(ret[1])
+}
+
+// ListResourceTypes records the call and returns the values configured with On.
+func (m *Mock) ListResourceTypes(ctx context.Context, opts ...client.CallOption) (*client.ResourceTypeListResponse, error) {
+	ret := m.called("ListResourceTypes", 2, ctx)
+	r0, _ := ret[0].(*client.ResourceTypeListResponse)
+	return r0, errorResult(ret[1])
+}
+
+// UpdateResourceType records the call and returns the values configured with On.
+func (m *Mock) UpdateResourceType(ctx context.Context, id string, req *client.ResourceTypeUpdateParams, opts ...client.CallOption) (*client.ResourceType, error) {
+	ret := m.called("UpdateResourceType", 2, ctx, id, req)
+	r0, _ := ret[0].(*client.ResourceType)
+	return r0, errorResult(ret[1])
+}
+
+// DeleteResourceType records the call and returns the values configured with On.
+func (m *Mock) DeleteResourceType(ctx context.Context, id string, opts ...client.CallOption) error {
+	ret := m.called("DeleteResourceType", 1, ctx, id)
+	return errorResult(ret[0])
+}
+
+// CreateResourceSource records the call and returns the values configured with On.
+func (m *Mock) CreateResourceSource(ctx context.Context, req *client.ResourceSourceCreateParams, opts ...client.CallOption) (*client.ResourceSource, error) {
+	ret := m.called("CreateResourceSource", 2, ctx, req)
+	r0, _ := ret[0].(*client.ResourceSource)
+	return r0, errorResult(ret[1])
+}
+
+// ReadResourceSource records the call and returns the values configured with On.
+func (m *Mock) ReadResourceSource(ctx context.Context, id string, opts ...client.CallOption) (*client.ResourceSource, error) {
+	ret := m.called("ReadResourceSource", 2, ctx, id)
+	r0, _ := ret[0].(*client.ResourceSource)
+	return r0, errorResult(ret[1])
+}
+
+// ListResourceSources records the call and returns the values configured with On.
+func (m *Mock) ListResourceSources(ctx context.Context, opts ...client.CallOption) (*client.ResourceSourceListResponse, error) {
+	ret := m.called("ListResourceSources", 2, ctx)
+	r0, _ := ret[0].(*client.ResourceSourceListResponse)
+	return r0, errorResult(ret[1])
+}
+
+// UpdateResourceSource records the call and returns the values configured with On.
+func (m *Mock) UpdateResourceSource(ctx context.Context, id string, req *client.ResourceSourceUpdateParams, opts ...client.CallOption) (*client.ResourceSource, error) {
+	ret := m.called("UpdateResourceSource", 2, ctx, id, req)
+	r0, _ := ret[0].(*client.ResourceSource)
+	return r0, errorResult(ret[1])
+}
+
+// UpdateResourceSourceSchemaByExamples records the call and returns the values configured with On.
+func (m *Mock) UpdateResourceSourceSchemaByExamples(ctx context.Context, id string, req *client.UpdateResourceSourceSchemaByExamplesParams, opts ...client.CallOption) (*client.ResourceSource, error) {
+	ret := m.called("UpdateResourceSourceSchemaByExamples", 2, ctx, id, req)
+	r0, _ := ret[0].(*client.ResourceSource)
+	return r0, errorResult(ret[1])
+}
+
+// DeleteResourceSource records the call and returns the values configured with On.
+func (m *Mock) DeleteResourceSource(ctx context.Context, id string, opts ...client.CallOption) error {
+	ret := m.called("DeleteResourceSource", 1, ctx, id)
+	return errorResult(ret[0])
+}
+
+// UpsertArticle records the call and returns the values configured with On.
+func (m *Mock) UpsertArticle(ctx context.Context, p *client.UpsertArticleParams, opts ...client.CallOption) error {
+	ret := m.called("UpsertArticle", 1, ctx, p)
+	return errorResult(ret[0])
+}
+
+// SetArticleUsageStatus records the call and returns the values configured with On.
+func (m *Mock) SetArticleUsageStatus(ctx context.Context, articleID string, p *client.SetArticleUsageStatusParams, opts ...client.CallOption) error {
+	ret := m.called("SetArticleUsageStatus", 1, ctx, articleID, p)
+	return errorResult(ret[0])
+}
+
+// DeleteArticle records the call and returns the values configured with On.
+func (m *Mock) DeleteArticle(ctx context.Context, articleID string, opts ...client.CallOption) error {
+	ret := m.called("DeleteArticle", 1, ctx, articleID)
+	return errorResult(ret[0])
+}
+
+// UpsertArticleTopic records the call and returns the values configured with On.
+func (m *Mock) UpsertArticleTopic(ctx context.Context, p *client.UpsertArticleTopicParams, opts ...client.CallOption) error {
+	ret := m.called("UpsertArticleTopic", 1, ctx, p)
+	return errorResult(ret[0])
+}
+
+// ReadTopic records the call and returns the values configured with On.
+func (m *Mock) ReadTopic(ctx context.Context, topicID string, p *client.ReadTopicParams, opts ...client.CallOption) (*client.Topic, error) {
+	ret := m.called("ReadTopic", 2, ctx, topicID, p)
+	r0, _ := ret[0].(*client.Topic)
+	return r0, errorResult(ret[1])
+}
+
+// ListTopics records the call and returns the values configured with On.
+func (m *Mock) ListTopics(ctx context.Context, p *client.ListTopicsParams, opts ...client.CallOption) (*client.ListTopicsResponse, error) {
+	ret := m.called("ListTopics", 2, ctx, p)
+	r0, _ := ret[0].(*client.ListTopicsResponse)
+	return r0, errorResult(ret[1])
+}
+
+// CreateNote records the call and returns the values configured with On.
+func (m *Mock) CreateNote(ctx context.Context, p *client.CreateNoteParams, opts ...client.CallOption) (*client.Note, error) {
+	ret := m.called("CreateNote", 2, ctx, p)
+	r0, _ := ret[0].(*client.Note)
+	return r0, errorResult(ret[1])
+}
+
+// UpdateNote records the call and returns the values configured with On.
+func (m *Mock) UpdateNote(ctx context.Context, noteID string, p *client.UpdateNoteParams, opts ...client.CallOption) (*client.Note, error) {
+	ret := m.called("UpdateNote", 2, ctx, noteID, p)
+	r0, _ := ret[0].(*client.Note)
+	return r0, errorResult(ret[1])
+}
+
+// SetNoteStatus records the call and returns the values configured with On.
+func (m *Mock) SetNoteStatus(ctx context.Context, noteID string, p *client.SetNoteStatusParams, opts ...client.CallOption) error {
+	ret := m.called("SetNoteStatus", 1, ctx, noteID, p)
+	return errorResult(ret[0])
+}
+
+// DeleteNote records the call and returns the values configured with On.
+func (m *Mock) DeleteNote(ctx context.Context, noteID string, opts ...client.CallOption) error {
+	ret := m.called("DeleteNote", 1, ctx, noteID)
+	return errorResult(ret[0])
+}
+
+// CreateTerminologySubstitution records the call and returns the values configured with On.
+func (m *Mock) CreateTerminologySubstitution(ctx context.Context, p *client.TerminologySubstitutionCreateParams, opts ...client.CallOption) (*client.TerminologySubstitution, error) {
+	ret := m.called("CreateTerminologySubstitution", 2, ctx, p)
+	r0, _ := ret[0].(*client.TerminologySubstitution)
+	return r0, errorResult(ret[1])
+}
+
+// ReadTerminologySubstitution records the call and returns the values configured with On.
+func (m *Mock) ReadTerminologySubstitution(ctx context.Context, id string, opts ...client.CallOption) (*client.TerminologySubstitution, error) {
+	ret := m.called("ReadTerminologySubstitution", 2, ctx, id)
+	r0, _ := ret[0].(*client.TerminologySubstitution)
+	return r0, errorResult(ret[1])
+}
+
+// ListTerminologySubstitutions records the call and returns the values configured with On.
+func (m *Mock) ListTerminologySubstitutions(ctx context.Context, opts ...client.CallOption) (*client.TerminologySubstitutionListResponse, error) {
+	ret := m.called("ListTerminologySubstitutions", 2, ctx)
+	r0, _ := ret[0].(*client.TerminologySubstitutionListResponse)
+	return r0, errorResult(ret[1])
+}
+
+// UpdateTerminologySubstitution records the call and returns the values configured with On.
+func (m *Mock) UpdateTerminologySubstitution(ctx context.Context, id string, p *client.TerminologySubstitutionUpdateParams, opts ...client.CallOption) (*client.TerminologySubstitution, error) {
+	ret := m.called("UpdateTerminologySubstitution", 2, ctx, id, p)
+	r0, _ := ret[0].(*client.TerminologySubstitution)
+	return r0, errorResult(ret[1])
+}
+
+// DeleteTerminologySubstitution records the call and returns the values configured with On.
+func (m *Mock) DeleteTerminologySubstitution(ctx context.Context, id string, opts ...client.CallOption) error {
+	ret := m.called("DeleteTerminologySubstitution", 1, ctx, id)
+	return errorResult(ret[0])
+}
+
+// ListProcedures records the call and returns the values configured with On.
+func (m *Mock) ListProcedures(ctx context.Context, p *client.ProcedureListParams, opts ...client.CallOption) (*client.ProcedureListResponse, error) {
+	ret := m.called("ListProcedures", 2, ctx, p)
+	r0, _ := ret[0].(*client.ProcedureListResponse)
+	return r0, errorResult(ret[1])
+}
+
+// ReadProcedure records the call and returns the values configured with On.
+func (m *Mock) ReadProcedure(ctx context.Context, procedureID string, opts ...client.CallOption) (*client.Procedure, error) {
+	ret := m.called("ReadProcedure", 2, ctx, procedureID)
+	r0, _ := ret[0].(*client.Procedure)
+	return r0, errorResult(ret[1])
+}
+
+// SetProcedureLimit records the call and returns the values configured with On.
+func (m *Mock) SetProcedureLimit(ctx context.Context, procedureID string, p *client.ProcedureLimitParams, opts ...client.CallOption) (*client.Procedure, error) {
+	ret := m.called("SetProcedureLimit", 2, ctx, procedureID, p)
+	r0, _ := ret[0].(*client.Procedure)
+	return r0, errorResult(ret[1])
+}
+
+// ListProcedureVersions records the call and returns the values configured with On.
+func (m *Mock) ListProcedureVersions(ctx context.Context, procedureID string, opts ...client.CallOption) (*client.ListProcedureVersionsResponse, error) {
+	ret := m.called("ListProcedureVersions", 2, ctx, procedureID)
+	r0, _ := ret[0].(*client.ListProcedureVersionsResponse)
+	return r0, errorResult(ret[1])
+}
+
+// SetProcedureLiveVersion records the call and returns the values configured with On.
+func (m *Mock) SetProcedureLiveVersion(ctx context.Context, procedureID string, version int, opts ...client.CallOption) error {
+	ret := m.called("SetProcedureLiveVersion", 1, ctx, procedureID, version)
+	return errorResult(ret[0])
+}
+
+// UnsetProcedureLiveVersion records the call and returns the values configured with On.
+func (m *Mock) UnsetProcedureLiveVersion(ctx context.Context, procedureID string, version int, opts ...client.CallOption) error {
+	ret := m.called("UnsetProcedureLiveVersion", 1, ctx, procedureID, version)
+	return errorResult(ret[0])
+}
+
+// SetProcedureGatedVersion records the call and returns the values configured with On.
+func (m *Mock) SetProcedureGatedVersion(ctx context.Context, procedureID string, version int, p *client.SetProcedureGatedVersionParams, opts ...client.CallOption) error {
+	ret := m.called("SetProcedureGatedVersion", 1, ctx, procedureID, version, p)
+	return errorResult(ret[0])
+}
+
+// UnsetProcedureGatedVersion records the call and returns the values configured with On.
+func (m *Mock) UnsetProcedureGatedVersion(ctx context.Context, procedureID string, version int, opts ...client.CallOption) error {
+	ret := m.called("UnsetProcedureGatedVersion", 1, ctx, procedureID, version)
+	return errorResult(ret[0])
+}
+
+// CreateTrafficGroup records the call and returns the values configured with On.
+func (m *Mock) CreateTrafficGroup(ctx context.Context, p *client.CreateTrafficGroupParams, opts ...client.CallOption) (*client.TrafficGroup, error) {
+	ret := m.called("CreateTrafficGroup", 2, ctx, p)
+	r0, _ := ret[0].(*client.TrafficGroup)
+	return r0, errorResult(ret[1])
+}
+
+// ListTrafficGroups records the call and returns the values configured with On.
+func (m *Mock) ListTrafficGroups(ctx context.Context, opts ...client.CallOption) ([]*client.TrafficGroup, error) {
+	ret := m.called("ListTrafficGroups", 2, ctx)
+	r0, _ := ret[0].([]*client.TrafficGroup)
+	return r0, errorResult(ret[1])
+}
+
+// UpdateTrafficGroup records the call and returns the values configured with On.
+func (m *Mock) UpdateTrafficGroup(ctx context.Context, p *client.UpdateTrafficGroupParams, opts ...client.CallOption) (*client.TrafficGroup, error) {
+	ret := m.called("UpdateTrafficGroup", 2, ctx, p)
+	r0, _ := ret[0].(*client.TrafficGroup)
+	return r0, errorResult(ret[1])
+}
+
+// DeleteTrafficGroup records the call and returns the values configured with On.
+func (m *Mock) DeleteTrafficGroup(ctx context.Context, trafficGroupID string, opts ...client.CallOption) error {
+	ret := m.called("DeleteTrafficGroup", 1, ctx, trafficGroupID)
+	return errorResult(ret[0])
+}
+
+// CreateTrafficGroupTarget records the call and returns the values configured with On.
+func (m *Mock) CreateTrafficGroupTarget(ctx context.Context, p *client.CreateTrafficGroupTargetParams, opts ...client.CallOption) (*client.TrafficGroupTarget, error) {
+	ret := m.called("CreateTrafficGroupTarget", 2, ctx, p)
+	r0, _ := ret[0].(*client.TrafficGroupTarget)
+	return r0, errorResult(ret[1])
+}
+
+// DeleteTrafficGroupTarget records the call and returns the values configured with On.
+func (m *Mock) DeleteTrafficGroupTarget(ctx context.Context, trafficGroupID string, targetID string, opts ...client.CallOption) error {
+	ret := m.called("DeleteTrafficGroupTarget", 1, ctx, trafficGroupID, targetID)
+	return errorResult(ret[0])
+}
+
+// CreateTrafficGroupExclusion records the call and returns the values configured with On.
+func (m *Mock) CreateTrafficGroupExclusion(ctx context.Context, p *client.CreateTrafficGroupExclusionParams, opts ...client.CallOption) (*client.TrafficGroupTarget, error) {
+	ret := m.called("CreateTrafficGroupExclusion", 2, ctx, p)
+	r0, _ := ret[0].(*client.TrafficGroupTarget)
+	return r0, errorResult(ret[1])
+}
+
+// DeleteTrafficGroupExclusion records the call and returns the values configured with On.
+func (m *Mock) DeleteTrafficGroupExclusion(ctx context.Context, trafficGroupID string, targetID string, opts ...client.CallOption) error {
+	ret := m.called("DeleteTrafficGroupExclusion", 1, ctx, trafficGroupID, targetID)
+	return errorResult(ret[0])
+}
+
+// WriteSecret records the call and returns the values configured with On.
+func (m *Mock) WriteSecret(ctx context.Context, p *client.WriteSecretParams, opts ...client.CallOption) (*client.Secret, error) {
+	ret := m.called("WriteSecret", 2, ctx, p)
+	r0, _ := ret[0].(*client.Secret)
+	return r0, errorResult(ret[1])
+}
+
+// ListSecrets records the call and returns the values configured with On.
+func (m *Mock) ListSecrets(ctx context.Context, opts ...client.CallOption) (*client.SecretsListResponse, error) {
+	ret := m.called("ListSecrets", 2, ctx)
+	r0, _ := ret[0].(*client.SecretsListResponse)
+	return r0, errorResult(ret[1])
+}
+
+// RevokeSecret records the call and returns the values configured with On.
+func (m *Mock) RevokeSecret(ctx context.Context, p *client.RevokeSecretParams, opts ...client.CallOption) error {
+	ret := m.called("RevokeSecret", 1, ctx, p)
+	return errorResult(ret[0])
+}
+
+// CreateBackOfficeTask records the call and returns the values configured with On.
+func (m *Mock) CreateBackOfficeTask(ctx context.Context, p client.BackOfficeTaskCreateParams, opts ...client.CallOption) (*client.BackOfficeTask, error) {
+	ret := m.called("CreateBackOfficeTask", 2, ctx, p)
+	r0, _ := ret[0].(*client.BackOfficeTask)
+	return r0, errorResult(ret[1])
+}
+
+// ReadBackOfficeTask records the call and returns the values configured with On.
+func (m *Mock) ReadBackOfficeTask(ctx context.Context, taskID string, opts ...client.CallOption) (*client.BackOfficeTask, error) {
+	ret := m.called("ReadBackOfficeTask", 2, ctx, taskID)
+	r0, _ := ret[0].(*client.BackOfficeTask)
+	return r0, errorResult(ret[1])
+}
@@ -0,0 +1,101 @@
+package glabsmock_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	client "github.com/gradientlabs-ai/gradientlabs-go"
+	"github.com/gradientlabs-ai/gradientlabs-go/glabsmock"
+)
+
+type fakeT struct{ errors []string }
+
+func (t *fakeT) Helper() {}
+func (t *fakeT) Errorf(format string, args ...any) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func TestOnMatchesArgsAfterContext(t *testing.T) {
+	m := glabsmock.New(t)
+	m.On("ReadConversation", "conv-1", glabsmock.Anything).Return(&client.Conversation{ID: "conv-1"}, nil)
+	m.On("ReadConversation", "conv-2", glabsmock.Anything).Return(nil, errors.New("not found"))
+
+	ctx := context.WithValue(context.Background(), struct{}{}, "value")
+	conv, err := m.ReadConversation(ctx, "conv-1", &client.ReadParams{}, client.WithCallRetries(2))
+	if err != nil || conv.ID != "conv-1" {
+		t.Errorf("ReadConversation(conv-1) = %v, %v", conv, err)
+	}
+	if _, err := m.ReadConversation(ctx, "conv-2", nil); err == nil {
+		t.Error("ReadConversation(conv-2) should return the configured error")
+	}
+	if conv, err := m.ReadConversation(ctx, "conv-3", nil); conv != nil || err != nil {
+		t.Errorf("unmatched call = %v, %v, want zero values", conv, err)
+	}
+
+	calls := m.Calls("ReadConversation")
+	if len(calls) != 3 {
+		t.Fatalf("calls = %d, want 3", len(calls))
+	}
+	if calls[0].Ctx != ctx || len(calls[0].Args) != 2 || calls[0].Args[0] != "conv-1" {
+		t.Errorf("calls[0] = %+v, want the context and args recorded separately", calls[0])
+	}
+	m.AssertExpectations(t)
+}
+
+func TestMatchedByAndRun(t *testing.T) {
+	m := glabsmock.New(t)
+
+	var captured client.StartConversationParams
+	m.On("StartConversation", glabsmock.MatchedBy(func(arg any) bool {
+		return arg.(client.StartConversationParams).Channel == client.ChannelEmail
+	})).Run(func(args []any) {
+		captured = args[0].(client.StartConversationParams)
+	}).Return(&client.Conversation{ID: "email"}, nil)
+
+	conv, _ := m.StartConversation(context.Background(), client.StartConversationParams{ID: "conv-1", Channel: client.ChannelEmail})
+	if conv == nil || conv.ID != "email" || captured.ID != "conv-1" {
+		t.Errorf("conv = %v, captured = %+v", conv, captured)
+	}
+
+	if conv, _ := m.StartConversation(context.Background(), client.StartConversationParams{Channel: client.ChannelChat}); conv != nil {
+		t.Errorf("non-matching call returned %v", conv)
+	}
+}
+
+func TestTimesAndAssertions(t *testing.T) {
+	m := glabsmock.New(t)
+	m.On("FinishConversation").Return(nil).Once()
+	m.On("FinishConversation").Return(errors.New("already finished"))
+
+	ctx := context.Background()
+	if err := m.FinishConversation(ctx, "conv-1", client.FinishParams{}); err != nil {
+		t.Errorf("first call error = %v", err)
+	}
+	if err := m.FinishConversation(ctx, "conv-1", client.FinishParams{}); err == nil {
+		t.Error("second call should fall through to the next expectation")
+	}
+
+	ft := &fakeT{}
+	m.On("CancelConversation").Times(2)
+	if m.AssertExpectations(ft) || len(ft.errors) != 1 {
+		t.Errorf("AssertExpectations errors = %q, want one for CancelConversation", ft.errors)
+	}
+	if m.AssertNotCalled(ft, "FinishConversation") {
+		t.Error("AssertNotCalled should fail")
+	}
+	if !m.AssertCalled(ft, "FinishConversation") {
+		t.Error("AssertCalled should pass")
+	}
+}
+
+func TestStrict(t *testing.T) {
+	ft := &fakeT{}
+	m := glabsmock.NewStrict(ft)
+	_ = m.CancelConversation(context.Background(), "conv-1", client.CancelParams{})
+
+	if len(ft.errors) != 1 {
+		t.Errorf("errors = %q, want an unexpected call error", ft.errors)
+	}
+}
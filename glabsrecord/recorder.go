@@ -0,0 +1,443 @@
+// Package glabsrecord records interactions with the Gradient Labs API to
+// cassette files, and replays them deterministically in tests.
+//
+// Use a Recorder as the client's transport:
+//
+//	rec := glabsrecord.New(t, "testdata/start-conversation.json")
+//	c, err := client.NewClient(
+//		client.WithAPIKey(os.Getenv("GLABS_API_KEY")),
+//		client.WithTransport(rec),
+//	)
+//
+// In ModeAuto (the default), the cassette is replayed if it exists, otherwise
+// the real API is called and the cassette is written when the test finishes.
+// Set the GLABSRECORD_MODE environment variable to "record" or "replay" to
+// force either mode.
+package glabsrecord
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Redacted is the placeholder that sensitive values are replaced with.
+const Redacted = "[REDACTED]"
+
+// ModeEnvVar is the environment variable that overrides the Recorder's mode.
+const ModeEnvVar = "GLABSRECORD_MODE"
+
+// Mode determines whether a Recorder calls the real API or replays a cassette.
+type Mode int
+
+const (
+	// ModeAuto replays the cassette if it exists, and records it otherwise.
+	ModeAuto Mode = iota
+
+	// ModeRecord calls the real API and (over)writes the cassette.
+	ModeRecord
+
+	// ModeReplay only replays the cassette, and fails the test if a request
+	// does not match any recorded interaction, or if any recorded interaction
+	// is not used by the time the test finishes.
+	ModeReplay
+)
+
+// ErrUnmatchedRequest is returned by a replaying Recorder when a request does
+// not match any of the cassette's unused interactions.
+var ErrUnmatchedRequest = errors.New("glabsrecord: no matching interaction in cassette")
+
+// T is the subset of testing.TB used by Recorder.
+type T interface {
+	Helper()
+	Errorf(format string, args ...any)
+	Cleanup(func())
+}
+
+// Cassette is the on-disk format of recorded interactions.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request/response pair.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+
+	used bool
+}
+
+// RecordedRequest is the recorded form of an HTTP request. JSON bodies are
+// stored in Body, and any other bodies are stored verbatim in BodyText.
+type RecordedRequest struct {
+	Method   string          `json:"method"`
+	Path     string          `json:"path"`
+	Header   http.Header     `json:"header,omitempty"`
+	Body     json.RawMessage `json:"body,omitempty"`
+	BodyText string          `json:"body_text,omitempty"`
+}
+
+// RecordedResponse is the recorded form of an HTTP response. JSON bodies are
+// stored in Body, and any other bodies are stored verbatim in BodyText.
+type RecordedResponse struct {
+	StatusCode int             `json:"status_code"`
+	Header     http.Header     `json:"header,omitempty"`
+	Body       json.RawMessage `json:"body,omitempty"`
+	BodyText   string          `json:"body_text,omitempty"`
+}
+
+// Recorder is an http.RoundTripper that records or replays API interactions.
+// Use New to create one.
+type Recorder struct {
+	t         T
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+	headers   map[string]bool
+	fields    map[string]bool
+
+	mu       sync.Mutex
+	cassette Cassette
+}
+
+// Option customises a Recorder.
+type Option func(*Recorder)
+
+// WithMode sets the Recorder's mode. The GLABSRECORD_MODE environment variable
+// takes precedence.
+func WithMode(mode Mode) Option {
+	return func(r *Recorder) { r.mode = mode }
+}
+
+// WithTransport sets the transport used to call the real API when recording.
+// Defaults to http.DefaultTransport.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(r *Recorder) { r.transport = rt }
+}
+
+// WithRedactedHeaders adds headers whose values will be redacted, on top of
+// Authorization, Cookie, Set-Cookie and X-GradientLabs-Token.
+func WithRedactedHeaders(names ...string) Option {
+	return func(r *Recorder) {
+		for _, n := range names {
+			r.headers[http.CanonicalHeaderKey(n)] = true
+		}
+	}
+}
+
+// WithRedactedFields adds JSON object keys whose values will be redacted
+// wherever they appear in request and response bodies, on top of the defaults
+// (e.g. "conversation_token", "api_key" and "password"). Names starting with
+// "$." only match at that path from the root of the body (e.g. "$.value", which
+// is redacted by default because it holds the value given to WriteSecret).
+func WithRedactedFields(names ...string) Option {
+	return func(r *Recorder) {
+		for _, n := range names {
+			r.fields[strings.ToLower(n)] = true
+		}
+	}
+}
+
+// New creates a Recorder for the cassette at path. In record mode, the cassette
+// is written when the test finishes.
+func New(t T, path string, opts ...Option) *Recorder {
+	t.Helper()
+
+	r := &Recorder{
+		t:         t,
+		path:      path,
+		transport: http.DefaultTransport,
+		headers: map[string]bool{
+			"Authorization":        true,
+			"Cookie":               true,
+			"Set-Cookie":           true,
+			"X-Gradientlabs-Token": true,
+		},
+		fields: map[string]bool{
+			"access_token":       true,
+			"api_key":            true,
+			"conversation_token": true,
+			"password":           true,
+			"refresh_token":      true,
+			"secret":             true,
+			"token":              true,
+			"$.value":            true,
+		},
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	switch os.Getenv(ModeEnvVar) {
+	case "record":
+		r.mode = ModeRecord
+	case "replay":
+		r.mode = ModeReplay
+	}
+
+	if r.mode == ModeAuto {
+		if _, err := os.Stat(path); err == nil {
+			r.mode = ModeReplay
+		} else {
+			r.mode = ModeRecord
+		}
+	}
+
+	switch r.mode {
+	case ModeReplay:
+		if err := r.load(); err != nil {
+			t.Errorf("glabsrecord: loading cassette: %v", err)
+		}
+		t.Cleanup(func() {
+			for _, in := range r.Unused() {
+				t.Errorf("glabsrecord: unused interaction %s %s %s", in.Request.Method, in.Request.Path, in.Request.body())
+			}
+		})
+	case ModeRecord:
+		t.Cleanup(func() {
+			if err := r.save(); err != nil {
+				t.Errorf("glabsrecord: saving cassette: %v", err)
+			}
+		})
+	}
+	return r
+}
+
+// RoundTrip satisfies the http.RoundTripper interface.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded, err := r.recordRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, recorded)
+	}
+	return r.record(req, recorded)
+}
+
+func (r *Recorder) replay(req *http.Request, recorded *RecordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, in := range r.cassette.Interactions {
+		if in.used || !matches(&in.Request, recorded) {
+			continue
+		}
+		in.used = true
+
+		body := []byte(in.Response.BodyText)
+		if len(in.Response.Body) > 0 {
+			body = in.Response.Body
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Response.StatusCode, http.StatusText(in.Response.StatusCode)),
+			StatusCode:    in.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        in.Response.Header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+
+	r.t.Errorf("glabsrecord: unmatched request %s %s %s", recorded.Method, recorded.Path, recorded.body())
+	return nil, fmt.Errorf("%w: %s %s", ErrUnmatchedRequest, recorded.Method, recorded.Path)
+}
+
+// Unused returns the cassette's interactions that haven't been replayed. When
+// replaying, the test fails if there are any left once it finishes.
+func (r *Recorder) Unused() []*Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var unused []*Interaction
+	for _, in := range r.cassette.Interactions {
+		if !in.used {
+			unused = append(unused, in)
+		}
+	}
+	return unused
+}
+
+func (r *Recorder) record(req *http.Request, recorded *RecordedRequest) (*http.Response, error) {
+	rsp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(rsp.Body)
+	if err != nil {
+		return nil, err
+	}
+	_ = rsp.Body.Close()
+	rsp.Body = io.NopCloser(bytes.NewReader(body))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	recordedRsp := RecordedResponse{
+		StatusCode: rsp.StatusCode,
+		Header:     r.redactHeader(rsp.Header),
+	}
+	recordedRsp.Body, recordedRsp.BodyText = r.redactBody(body)
+
+	r.cassette.Interactions = append(r.cassette.Interactions, &Interaction{
+		Request:  *recorded,
+		Response: recordedRsp,
+	})
+	return rsp, nil
+}
+
+func (r *Recorder) recordRequest(req *http.Request) (*RecordedRequest, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		_ = req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	recorded := &RecordedRequest{
+		Method: req.Method,
+		Path:   req.URL.RequestURI(),
+		Header: r.redactHeader(req.Header),
+	}
+	recorded.Body, recorded.BodyText = r.redactBody(body)
+
+	// Values that change on every call would make cassettes noisy.
+	recorded.Header.Del("Idempotency-Key")
+	recorded.Header.Del("User-Agent")
+	return recorded, nil
+}
+
+func (r *Recorder) redactHeader(h http.Header) http.Header {
+	out := h.Clone()
+	if out == nil {
+		out = make(http.Header)
+	}
+	for k := range out {
+		if r.headers[http.CanonicalHeaderKey(k)] {
+			out[k] = []string{Redacted}
+		}
+	}
+	return out
+}
+
+// redactBody redacts sensitive fields and normalises the JSON encoding (i.e.
+// sorted keys, no insignificant whitespace) so bodies can be compared. Non-JSON
+// bodies are returned verbatim as text instead.
+func (r *Recorder) redactBody(body []byte) (json.RawMessage, string) {
+	if len(body) == 0 {
+		return nil, ""
+	}
+
+	v, err := decodeJSON(body)
+	if err != nil {
+		return nil, string(body)
+	}
+
+	b, err := json.Marshal(r.redactValue("$", v))
+	if err != nil {
+		return body, ""
+	}
+	return b, ""
+}
+
+func (r *Recorder) redactValue(path string, v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, vv := range t {
+			p := path + "." + strings.ToLower(k)
+			if r.fields[strings.ToLower(k)] || r.fields[p] {
+				t[k] = Redacted
+			} else {
+				t[k] = r.redactValue(p, vv)
+			}
+		}
+	case []any:
+		for i, vv := range t {
+			t[i] = r.redactValue(path+"[*]", vv)
+		}
+	}
+	return v
+}
+
+// body returns the request body for error messages.
+func (r *RecordedRequest) body() string {
+	if len(r.Body) > 0 {
+		return string(r.Body)
+	}
+	return r.BodyText
+}
+
+func matches(a, b *RecordedRequest) bool {
+	return a.Method == b.Method && a.Path == b.Path &&
+		bytes.Equal(normalise(a.Body), normalise(b.Body)) && a.BodyText == b.BodyText
+}
+
+func normalise(body json.RawMessage) []byte {
+	v, err := decodeJSON(body)
+	if err != nil {
+		return body
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return body
+	}
+	return b
+}
+
+// decodeJSON decodes a JSON document, keeping numbers as json.Number so that
+// large integers aren't rounded when the document is encoded again.
+func decodeJSON(b []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("unexpected data after JSON value")
+	}
+	return v, nil
+}
+
+func (r *Recorder) load() error {
+	b, err := os.ReadFile(r.path)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return json.Unmarshal(b, &r.cassette)
+}
+
+func (r *Recorder) save() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(b, '\n'), 0o644)
+}
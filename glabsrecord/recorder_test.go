@@ -0,0 +1,225 @@
+package glabsrecord_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	client "github.com/gradientlabs-ai/gradientlabs-go"
+	"github.com/gradientlabs-ai/gradientlabs-go/glabsrecord"
+)
+
+// fakeT records errors and cleanups so tests can run them explicitly.
+type fakeT struct {
+	errors   []string
+	cleanups []func()
+}
+
+func (t *fakeT) Helper() {}
+func (t *fakeT) Errorf(format string, args ...any) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+func (t *fakeT) Cleanup(fn func()) { t.cleanups = append(t.cleanups, fn) }
+
+func (t *fakeT) finish() {
+	for i := len(t.cleanups) - 1; i >= 0; i-- {
+		t.cleanups[i]()
+	}
+}
+
+func newClient(t *testing.T, rec *glabsrecord.Recorder) *client.Client {
+	t.Helper()
+
+	c, err := client.NewClient(
+		client.WithURL("https://api.example.com"),
+		client.WithAPIKey("secret-key"),
+		client.WithTransport(rec),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	t.Setenv(glabsrecord.ModeEnvVar, "")
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	ctx := context.Background()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/secrets/db":
+			fmt.Fprint(w, `{"name":"db"}`)
+		case "/conversations/conv-1/read":
+			fmt.Fprint(w, `{"id":"conv-1"}`)
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+			fmt.Fprint(w, "upstream unavailable")
+		}
+	}))
+	defer srv.Close()
+
+	recT := &fakeT{}
+	rec := glabsrecord.New(recT, path, glabsrecord.WithTransport(rewriteHost(srv.URL)))
+	c := newClient(t, rec)
+
+	if _, err := c.WriteSecret(ctx, &client.WriteSecretParams{Name: "db", Value: "hunter2"}); err != nil {
+		t.Fatalf("WriteSecret: %v", err)
+	}
+	if _, err := c.ReadConversation(ctx, "conv-1", &client.ReadParams{}); err != nil {
+		t.Fatalf("ReadConversation: %v", err)
+	}
+	_, err := c.ReadConversation(ctx, "missing", &client.ReadParams{})
+	if err == nil {
+		t.Fatal("expected an error for the non-JSON response")
+	}
+	recordedErr := err.Error()
+	recT.finish()
+
+	if len(recT.errors) != 0 {
+		t.Fatalf("recording errors: %v", recT.errors)
+	}
+
+	cassette, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"hunter2", "secret-key"} {
+		if strings.Contains(string(cassette), secret) {
+			t.Errorf("cassette contains %q:\n%s", secret, cassette)
+		}
+	}
+	if !strings.Contains(string(cassette), `"body_text": "upstream unavailable"`) {
+		t.Errorf("cassette should store the non-JSON body verbatim:\n%s", cassette)
+	}
+
+	replayT := &fakeT{}
+	rec = glabsrecord.New(replayT, path)
+	c = newClient(t, rec)
+
+	if _, err := c.WriteSecret(ctx, &client.WriteSecretParams{Name: "db", Value: "a different value"}); err != nil {
+		t.Fatalf("replayed WriteSecret: %v", err)
+	}
+	conv, err := c.ReadConversation(ctx, "conv-1", &client.ReadParams{})
+	if err != nil || conv.ID != "conv-1" {
+		t.Fatalf("replayed ReadConversation = %v, %v", conv, err)
+	}
+
+	var meta client.ResponseMeta
+	_, err = c.ReadConversation(ctx, "missing", &client.ReadParams{}, client.WithResponseCapture(&meta))
+	if err == nil || err.Error() != recordedErr {
+		t.Errorf("replayed error = %v, want %s", err, recordedErr)
+	}
+	if string(meta.Body) != "upstream unavailable" {
+		t.Errorf("replayed body = %q, want it verbatim", meta.Body)
+	}
+
+	replayT.finish()
+	if len(replayT.errors) != 0 {
+		t.Errorf("replay errors: %v", replayT.errors)
+	}
+}
+
+func TestReplayReportsUnmatchedAndUnused(t *testing.T) {
+	t.Setenv(glabsrecord.ModeEnvVar, "")
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	cassette := `{"interactions": [
+		{"request": {"method": "GET", "path": "/conversations/conv-1/read"}, "response": {"status_code": 200, "body": {"id": "conv-1"}}},
+		{"request": {"method": "GET", "path": "/conversations/conv-2/read"}, "response": {"status_code": 200, "body": {"id": "conv-2"}}}
+	]}`
+	if err := os.WriteFile(path, []byte(cassette), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ft := &fakeT{}
+	c := newClient(t, glabsrecord.New(ft, path))
+	ctx := context.Background()
+
+	if _, err := c.ReadConversation(ctx, "conv-1", &client.ReadParams{}); err != nil {
+		t.Fatalf("ReadConversation: %v", err)
+	}
+	if _, err := c.ReadConversation(ctx, "conv-1", &client.ReadParams{}); err == nil {
+		t.Error("expected an error when the interaction has already been used")
+	}
+	ft.finish()
+
+	if len(ft.errors) != 2 {
+		t.Fatalf("errors = %q, want one unmatched and one unused", ft.errors)
+	}
+	if !strings.Contains(ft.errors[0], "unmatched request GET /conversations/conv-1/read") {
+		t.Errorf("errors[0] = %q", ft.errors[0])
+	}
+	if !strings.Contains(ft.errors[1], "unused interaction GET /conversations/conv-2/read") {
+		t.Errorf("errors[1] = %q", ft.errors[1])
+	}
+}
+
+// rewriteHost sends requests to the given test server instead.
+func rewriteHost(target string) http.RoundTripper {
+	return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		out := req.Clone(req.Context())
+		out.URL.Scheme = "http"
+		out.URL.Host = strings.TrimPrefix(target, "http://")
+		if req.Body != nil {
+			b, _ := io.ReadAll(req.Body)
+			out.Body = io.NopCloser(strings.NewReader(string(b)))
+		}
+		return http.DefaultTransport.RoundTrip(out)
+	})
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestRecordKeepsLargeIntegers(t *testing.T) {
+	t.Setenv(glabsrecord.ModeEnvVar, "")
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	const body = `{"id":"conv-1","order_id":9007199254740993}`
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, body)
+	}))
+	defer srv.Close()
+
+	get := func(rt http.RoundTripper) string {
+		t.Helper()
+		rsp, err := (&http.Client{Transport: rt}).Get("https://api.example.com/conversations/conv-1/read")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rsp.Body.Close()
+		b, err := io.ReadAll(rsp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(b)
+	}
+
+	recT := &fakeT{}
+	get(glabsrecord.New(recT, path, glabsrecord.WithTransport(rewriteHost(srv.URL))))
+	recT.finish()
+
+	cassette, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(cassette), "9007199254740993") {
+		t.Errorf("cassette changed the large integer:\n%s", cassette)
+	}
+
+	replayT := &fakeT{}
+	if got := get(glabsrecord.New(replayT, path)); !strings.Contains(got, "9007199254740993") {
+		t.Errorf("replayed body changed the large integer:\n%s", got)
+	}
+	replayT.finish()
+	if len(replayT.errors) != 0 {
+		t.Errorf("replay errors: %v", replayT.errors)
+	}
+}
@@ -0,0 +1,175 @@
+package handoff
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// BusinessHours describes when a team is available, e.g. Monday to Friday
+// from 09:00 to 17:30 in Europe/London.
+type BusinessHours struct {
+	// TimeZone is the IANA name of the time zone the hours are in (e.g.
+	// "Europe/London"). Defaults to UTC.
+	TimeZone string `json:"time_zone,omitempty"`
+
+	// Days lists the days the team works, as three-letter abbreviations (e.g.
+	// "mon", "tue"). Defaults to Monday to Friday.
+	Days []string `json:"days,omitempty"`
+
+	// Open is the time of day the team starts, in 24-hour "15:04" format.
+	Open string `json:"open"`
+
+	// Close is the time of day the team finishes, in 24-hour "15:04" format. If
+	// it is earlier than Open, the hours run past midnight. If it is the same
+	// as Open, the team works around the clock, e.g. "00:00" to "00:00" covers
+	// the whole of each working day.
+	Close string `json:"close"`
+
+	// Holidays lists dates (in "2006-01-02" format) on which the team does not
+	// work.
+	Holidays []string `json:"holidays,omitempty"`
+}
+
+// hours is the parsed form of BusinessHours.
+type hours struct {
+	loc         *time.Location
+	days        [7]bool
+	open, close time.Duration
+	holidays    map[string]bool
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// Validate checks that the hours are well-formed.
+func (b *BusinessHours) Validate() error {
+	_, err := b.parse()
+	return err
+}
+
+// IsOpen reports whether the team is working at the given time. Invalid hours
+// are never open.
+func (b *BusinessHours) IsOpen(t time.Time) bool {
+	h, err := b.parse()
+	if err != nil {
+		return false
+	}
+	return h.isOpen(t)
+}
+
+// NextOpen returns the next time the team is working, on or after t. It returns
+// the zero time if the hours are invalid or the team doesn't work in the next
+// year (e.g. no days are configured).
+func (b *BusinessHours) NextOpen(t time.Time) time.Time {
+	h, err := b.parse()
+	if err != nil {
+		return time.Time{}
+	}
+	return h.nextOpen(t)
+}
+
+func (b *BusinessHours) parse() (*hours, error) {
+	h := &hours{loc: time.UTC, holidays: make(map[string]bool)}
+
+	if b.TimeZone != "" {
+		loc, err := time.LoadLocation(b.TimeZone)
+		if err != nil {
+			return nil, fmt.Errorf("business hours: %w", err)
+		}
+		h.loc = loc
+	}
+
+	if len(b.Days) == 0 {
+		for d := time.Monday; d <= time.Friday; d++ {
+			h.days[d] = true
+		}
+	}
+	for _, name := range b.Days {
+		d, ok := weekdays[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("business hours: unknown day %q", name)
+		}
+		h.days[d] = true
+	}
+
+	var err error
+	if h.open, err = parseTimeOfDay(b.Open); err != nil {
+		return nil, fmt.Errorf("business hours: open: %w", err)
+	}
+	if h.close, err = parseTimeOfDay(b.Close); err != nil {
+		return nil, fmt.Errorf("business hours: close: %w", err)
+	}
+
+	for _, date := range b.Holidays {
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			return nil, fmt.Errorf("business hours: holiday: %w", err)
+		}
+		h.holidays[date] = true
+	}
+	return h, nil
+}
+
+func parseTimeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// isOpen reports whether t falls within the hours. Times are compared by wall
+// clock, so days on which daylight saving time starts or ends are handled.
+// Shifts that run past midnight belong to the day they started on.
+func (h *hours) isOpen(t time.Time) bool {
+	t = t.In(h.loc)
+	hh, mm, ss := t.Clock()
+	clock := time.Duration(hh)*time.Hour + time.Duration(mm)*time.Minute + time.Duration(ss)*time.Second
+	day := h.day(t)
+
+	if h.open < h.close {
+		return h.working(day) && clock >= h.open && clock < h.close
+	}
+	if clock >= h.open {
+		return h.working(day)
+	}
+	return clock < h.close && h.working(day.AddDate(0, 0, -1))
+}
+
+func (h *hours) nextOpen(t time.Time) time.Time {
+	if h.isOpen(t) {
+		return t
+	}
+
+	t = t.In(h.loc)
+	day := h.day(t)
+	for i := 0; i < 366; i++ {
+		if h.working(day) {
+			open := time.Date(day.Year(), day.Month(), day.Day(),
+				int(h.open/time.Hour), int(h.open%time.Hour/time.Minute), 0, 0, h.loc)
+			if !open.Before(t) {
+				return open
+			}
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return time.Time{}
+}
+
+// day returns midday on t's date, which (unlike midnight) exists on every day
+// regardless of daylight saving time changes.
+func (h *hours) day(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 12, 0, 0, 0, h.loc)
+}
+
+// working reports whether the team works on the given day.
+func (h *hours) working(day time.Time) bool {
+	return h.days[day.Weekday()] && !h.holidays[day.Format("2006-01-02")]
+}
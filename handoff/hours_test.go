@@ -0,0 +1,132 @@
+package handoff_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gradientlabs-ai/gradientlabs-go/handoff"
+)
+
+func mustLoad(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("time zone %s is not available: %v", name, err)
+	}
+	return loc
+}
+
+func TestBusinessHoursIsOpen(t *testing.T) {
+	london := mustLoad(t, "Europe/London")
+
+	weekdays := &handoff.BusinessHours{TimeZone: "Europe/London", Open: "09:00", Close: "17:30"}
+	sunday := &handoff.BusinessHours{TimeZone: "Europe/London", Days: []string{"Sun"}, Open: "09:00", Close: "17:00"}
+	overnight := &handoff.BusinessHours{Days: []string{"fri"}, Open: "22:00", Close: "06:00"}
+	allDay := &handoff.BusinessHours{Days: []string{"sat", "sun"}, Open: "00:00", Close: "00:00"}
+	holiday := &handoff.BusinessHours{Open: "09:00", Close: "17:00", Holidays: []string{"2024-12-25"}}
+
+	testCases := map[string]struct {
+		hours *handoff.BusinessHours
+		time  time.Time
+		want  bool
+	}{
+		"weekday open":              {weekdays, time.Date(2024, 6, 3, 9, 0, 0, 0, london), true},
+		"weekday before open":       {weekdays, time.Date(2024, 6, 3, 8, 59, 0, 0, london), false},
+		"weekday at close":          {weekdays, time.Date(2024, 6, 3, 17, 30, 0, 0, london), false},
+		"weekend":                   {weekdays, time.Date(2024, 6, 1, 12, 0, 0, 0, london), false},
+		"other time zone":           {weekdays, time.Date(2024, 6, 3, 8, 30, 0, 0, time.UTC), true},
+		"clocks go forward":         {sunday, time.Date(2024, 3, 31, 9, 30, 0, 0, london), true},
+		"clocks go forward close":   {sunday, time.Date(2024, 3, 31, 16, 59, 0, 0, london), true},
+		"clocks go back":            {sunday, time.Date(2024, 10, 27, 9, 0, 0, 0, london), true},
+		"clocks go back close":      {sunday, time.Date(2024, 10, 27, 17, 0, 0, 0, london), false},
+		"overnight start":           {overnight, time.Date(2024, 6, 7, 23, 0, 0, 0, time.UTC), true},
+		"overnight next morning":    {overnight, time.Date(2024, 6, 8, 5, 59, 0, 0, time.UTC), true},
+		"overnight after shift":     {overnight, time.Date(2024, 6, 8, 6, 0, 0, 0, time.UTC), false},
+		"overnight wrong day":       {overnight, time.Date(2024, 6, 7, 5, 0, 0, 0, time.UTC), false},
+		"all day midnight":          {allDay, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), true},
+		"all day late":              {allDay, time.Date(2024, 6, 2, 23, 59, 0, 0, time.UTC), true},
+		"all day not a working day": {allDay, time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC), false},
+		"holiday":                   {holiday, time.Date(2024, 12, 25, 10, 0, 0, 0, time.UTC), false},
+		"invalid":                   {&handoff.BusinessHours{Open: "9am"}, time.Now(), false},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := tc.hours.IsOpen(tc.time); got != tc.want {
+				t.Errorf("IsOpen(%v) = %v, want %v", tc.time, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBusinessHoursNextOpen(t *testing.T) {
+	london := mustLoad(t, "Europe/London")
+
+	testCases := map[string]struct {
+		hours *handoff.BusinessHours
+		time  time.Time
+		want  time.Time
+	}{
+		"already open": {
+			hours: &handoff.BusinessHours{Open: "09:00", Close: "17:00"},
+			time:  time.Date(2024, 6, 3, 10, 0, 0, 0, time.UTC),
+			want:  time.Date(2024, 6, 3, 10, 0, 0, 0, time.UTC),
+		},
+		"later today": {
+			hours: &handoff.BusinessHours{Open: "09:00", Close: "17:00"},
+			time:  time.Date(2024, 6, 3, 7, 0, 0, 0, time.UTC),
+			want:  time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC),
+		},
+		"after the weekend": {
+			hours: &handoff.BusinessHours{Open: "09:00", Close: "17:00"},
+			time:  time.Date(2024, 6, 7, 18, 0, 0, 0, time.UTC),
+			want:  time.Date(2024, 6, 10, 9, 0, 0, 0, time.UTC),
+		},
+		"after a holiday": {
+			hours: &handoff.BusinessHours{Open: "09:00", Close: "17:00", Holidays: []string{"2024-12-25", "2024-12-26"}},
+			time:  time.Date(2024, 12, 24, 18, 0, 0, 0, time.UTC),
+			want:  time.Date(2024, 12, 27, 9, 0, 0, 0, time.UTC),
+		},
+		"clocks go forward": {
+			hours: &handoff.BusinessHours{TimeZone: "Europe/London", Days: []string{"sun"}, Open: "09:00", Close: "17:00"},
+			time:  time.Date(2024, 3, 31, 6, 0, 0, 0, london),
+			want:  time.Date(2024, 3, 31, 9, 0, 0, 0, london),
+		},
+		"around the clock": {
+			hours: &handoff.BusinessHours{Days: []string{"mon"}, Open: "09:00", Close: "09:00"},
+			time:  time.Date(2024, 6, 4, 9, 0, 0, 0, time.UTC),
+			want:  time.Date(2024, 6, 10, 9, 0, 0, 0, time.UTC),
+		},
+		"invalid": {
+			hours: &handoff.BusinessHours{TimeZone: "Nowhere/Special", Open: "09:00", Close: "17:00"},
+			time:  time.Date(2024, 6, 3, 7, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := tc.hours.NextOpen(tc.time); !got.Equal(tc.want) {
+				t.Errorf("NextOpen(%v) = %v, want %v", tc.time, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBusinessHoursValidate(t *testing.T) {
+	testCases := map[string]*handoff.BusinessHours{
+		"time zone": {TimeZone: "Nowhere/Special", Open: "09:00", Close: "17:00"},
+		"day":       {Days: []string{"funday"}, Open: "09:00", Close: "17:00"},
+		"open":      {Open: "9", Close: "17:00"},
+		"close":     {Open: "09:00", Close: "25:00"},
+		"holiday":   {Open: "09:00", Close: "17:00", Holidays: []string{"25/12/2024"}},
+	}
+	for name, h := range testCases {
+		if err := h.Validate(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	if err := (&handoff.BusinessHours{Open: "09:00", Close: "17:00"}).Validate(); err != nil {
+		t.Errorf("valid hours: %v", err)
+	}
+}
@@ -0,0 +1,240 @@
+// Package handoff helps pass conversations that the AI agent hands off on to
+// human agents: it routes them to the right team, and writes structured
+// internal notes so they can pick up where the agent left off.
+package handoff
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	client "github.com/gradientlabs-ai/gradientlabs-go"
+	"github.com/gradientlabs-ai/gradientlabs-go/format"
+)
+
+// EventSender is the subset of client.ConversationAPI used to post notes.
+type EventSender interface {
+	AddConversationEvent(ctx context.Context, conversationID string, p *client.EventParams, opts ...client.CallOption) error
+}
+
+// Link is a labelled link included in a Note.
+type Link struct {
+	Label string
+	URL   string
+}
+
+// Field is a labelled value included in a Note (e.g. "Customer tier").
+type Field struct {
+	Label string
+	Value string
+}
+
+// Note is a structured internal note for the human agent a conversation is
+// handed off to. Build one with NoteFromHandOff, or by setting its fields
+// directly.
+type Note struct {
+	// Summary is a summary of the conversation so far.
+	Summary string
+
+	// Intent is the customer's most recently classified intent.
+	Intent string
+
+	// ReasonCode is the code describing why the conversation was handed off.
+	ReasonCode string
+
+	// Reason is a human-legible description of ReasonCode.
+	Reason string
+
+	// Target is the hand-off target the agent chose.
+	Target string
+
+	// Fields contains any additional information to include.
+	Fields []Field
+
+	// Links contains any links to include (e.g. to the conversation in the
+	// Gradient Labs UI).
+	Links []Link
+}
+
+// NoteFromHandOff creates a Note from a `conversation.hand_off` event.
+func NoteFromHandOff(ev *client.ConversationHandOffEvent) *Note {
+	return &Note{
+		Summary:    ev.Note,
+		Intent:     ev.Intent,
+		ReasonCode: ev.Reason,
+		Reason:     ev.Description,
+		Target:     ev.Target,
+	}
+}
+
+// AddField adds a labelled value to the note. Empty values are skipped.
+func (n *Note) AddField(label, value string) *Note {
+	if value != "" {
+		n.Fields = append(n.Fields, Field{Label: label, Value: value})
+	}
+	return n
+}
+
+// AddLink adds a link to the note. Empty URLs are skipped.
+func (n *Note) AddLink(label, url string) *Note {
+	if url != "" {
+		n.Links = append(n.Links, Link{Label: label, URL: url})
+	}
+	return n
+}
+
+// AddVoiceCallContext adds the details of the customer's most recent voice
+// call, including links to the call and its procedure in the Gradient Labs UI.
+// The call's summary is used if the note doesn't already have one.
+func (n *Note) AddVoiceCallContext(vc *client.VoiceCallContext) *Note {
+	if vc == nil {
+		return n
+	}
+	if n.Summary == "" {
+		n.Summary = vc.Summary
+	}
+	if !vc.StartedAt.IsZero() {
+		n.AddField("Last call", vc.StartedAt.UTC().Format(time.RFC1123))
+	}
+	n.AddField("Call hand-off reason", vc.HandoffReason)
+	n.AddField("Last procedure", vc.LastExecutedProcedure)
+	n.AddLink("Call in Gradient Labs", vc.GradientLabsURL)
+	n.AddLink("Procedure in Gradient Labs", vc.LastExecutedProcedureURL)
+	return n
+}
+
+// Markdown renders the note as Markdown.
+func (n *Note) Markdown() string {
+	var sections []string
+
+	if n.Summary != "" {
+		sections = append(sections, "**Summary**\n"+strings.TrimSpace(n.Summary))
+	}
+
+	var details []string
+	if n.Intent != "" {
+		details = append(details, fmt.Sprintf("- Intent: %s", n.Intent))
+	}
+	switch {
+	case n.Reason != "" && n.ReasonCode != "":
+		details = append(details, fmt.Sprintf("- Hand-off reason: %s (`%s`)", n.Reason, n.ReasonCode))
+	case n.Reason != "":
+		details = append(details, fmt.Sprintf("- Hand-off reason: %s", n.Reason))
+	case n.ReasonCode != "":
+		details = append(details, fmt.Sprintf("- Hand-off reason: `%s`", n.ReasonCode))
+	}
+	if n.Target != "" {
+		details = append(details, fmt.Sprintf("- Target: %s", n.Target))
+	}
+	for _, f := range n.Fields {
+		details = append(details, fmt.Sprintf("- %s: %s", f.Label, f.Value))
+	}
+	if len(details) != 0 {
+		sections = append(sections, "**Details**\n"+strings.Join(details, "\n"))
+	}
+
+	if len(n.Links) != 0 {
+		links := make([]string, len(n.Links))
+		for i, l := range n.Links {
+			links[i] = fmt.Sprintf("- [%s](%s)", l.Label, l.URL)
+		}
+		sections = append(sections, "**Links**\n"+strings.Join(links, "\n"))
+	}
+
+	return strings.Join(sections, "\n\n")
+}
+
+// Template renders a Note as the body of an internal note.
+type Template func(n *Note) string
+
+// MarkdownTemplate renders notes as Markdown.
+func MarkdownTemplate(n *Note) string { return n.Markdown() }
+
+// HTMLTemplate renders notes as HTML, for platforms whose notes support it.
+func HTMLTemplate(n *Note) string {
+	return format.HTML(n.Markdown(), format.HTMLOptions{LinkTarget: "_blank"})
+}
+
+// TextTemplate renders notes as plain text, for platforms whose notes don't
+// support any markup.
+func TextTemplate(n *Note) string { return format.PlainText(n.Markdown()) }
+
+// TemplateFor returns the default template for notes on the given support
+// platform.
+func TemplateFor(platform client.SupportPlatform) Template {
+	switch platform {
+	case client.SupportPlatformIntercom,
+		client.SupportPlatformFreshdesk,
+		client.SupportPlatformFreshchat:
+		return HTMLTemplate
+	case client.SupportPlatformZendesk,
+		client.SupportPlatformSalesforce:
+		return TextTemplate
+	default:
+		return MarkdownTemplate
+	}
+}
+
+// PostOptions customise how a Note is posted.
+type PostOptions struct {
+	// Platform is the conversation's support platform, used to choose the
+	// template when Template is nil.
+	Platform client.SupportPlatform
+
+	// Template optionally overrides the platform's default template.
+	Template Template
+
+	// ParticipantID identifies who the note is from. Required.
+	ParticipantID string
+
+	// ParticipantType identifies the type of participant the note is from.
+	// Defaults to client.ParticipantTypeBot.
+	ParticipantType client.ParticipantType
+
+	// IdempotencyKey optionally overrides the default key, which is derived
+	// from the conversation and note body so that a redelivered webhook
+	// doesn't produce a duplicate note.
+	IdempotencyKey string
+}
+
+// EventParams renders the note as the parameters for an internal note event.
+func (n *Note) EventParams(conversationID string, opts PostOptions) (*client.EventParams, error) {
+	if opts.ParticipantID == "" {
+		return nil, errors.New("handoff: participant ID is required")
+	}
+
+	tmpl := opts.Template
+	if tmpl == nil {
+		tmpl = TemplateFor(opts.Platform)
+	}
+	body := tmpl(n)
+
+	p := &client.EventParams{
+		Type:            client.ConversationEventInternalNote,
+		ParticipantID:   opts.ParticipantID,
+		ParticipantType: opts.ParticipantType,
+		Body:            body,
+		IdempotencyKey:  opts.IdempotencyKey,
+	}
+	if p.ParticipantType == "" {
+		p.ParticipantType = client.ParticipantTypeBot
+	}
+	if p.IdempotencyKey == "" {
+		sum := sha256.Sum256([]byte(conversationID + "\x00" + body))
+		p.IdempotencyKey = "note-" + hex.EncodeToString(sum[:16])
+	}
+	return p, nil
+}
+
+// Post adds the note to the conversation as an internal note event.
+func (n *Note) Post(ctx context.Context, api EventSender, conversationID string, opts PostOptions, callOpts ...client.CallOption) error {
+	p, err := n.EventParams(conversationID, opts)
+	if err != nil {
+		return err
+	}
+	return api.AddConversationEvent(ctx, conversationID, p, callOpts...)
+}
@@ -0,0 +1,183 @@
+package handoff_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	client "github.com/gradientlabs-ai/gradientlabs-go"
+	"github.com/gradientlabs-ai/gradientlabs-go/handoff"
+)
+
+// fakeSender records the events it is sent.
+type fakeSender struct {
+	conversationID string
+	events         []*client.EventParams
+}
+
+func (f *fakeSender) AddConversationEvent(_ context.Context, conversationID string, p *client.EventParams, _ ...client.CallOption) error {
+	f.conversationID = conversationID
+	f.events = append(f.events, p)
+	return nil
+}
+
+func testHandOff() *client.ConversationHandOffEvent {
+	return &client.ConversationHandOffEvent{
+		Conversation: client.WebhookConversation{ID: "conv-1", CustomerID: "cust-1"},
+		Target:       "billing",
+		Reason:       "customer-request",
+		Description:  "The customer asked for a human",
+		Note:         "  The customer wants a refund.  ",
+		Intent:       "refund",
+	}
+}
+
+func TestNoteMarkdown(t *testing.T) {
+	note := handoff.NoteFromHandOff(testHandOff()).
+		AddField("Customer tier", "gold").
+		AddField("Empty", "").
+		AddLink("Conversation", "https://app.example.com/c/1").
+		AddLink("Nothing", "")
+
+	want := "**Summary**\nThe customer wants a refund.\n\n" +
+		"**Details**\n" +
+		"- Intent: refund\n" +
+		"- Hand-off reason: The customer asked for a human (`customer-request`)\n" +
+		"- Target: billing\n" +
+		"- Customer tier: gold\n\n" +
+		"**Links**\n" +
+		"- [Conversation](https://app.example.com/c/1)"
+	if got := note.Markdown(); got != want {
+		t.Errorf("Markdown =\n%s\nwant:\n%s", got, want)
+	}
+
+	if got := (&handoff.Note{}).Markdown(); got != "" {
+		t.Errorf("empty note rendered %q", got)
+	}
+}
+
+func TestNoteReason(t *testing.T) {
+	testCases := map[string]struct {
+		note handoff.Note
+		want string
+	}{
+		"reason and code": {
+			note: handoff.Note{Reason: "Asked for a human", ReasonCode: "human"},
+			want: "- Hand-off reason: Asked for a human (`human`)",
+		},
+		"reason only": {
+			note: handoff.Note{Reason: "Asked for a human"},
+			want: "- Hand-off reason: Asked for a human",
+		},
+		"code only": {
+			note: handoff.Note{ReasonCode: "human"},
+			want: "- Hand-off reason: `human`",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := tc.note.Markdown(); !strings.Contains(got, tc.want) {
+				t.Errorf("Markdown = %q, want it to contain %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNoteVoiceCallContext(t *testing.T) {
+	note := (&handoff.Note{}).AddVoiceCallContext(&client.VoiceCallContext{
+		StartedAt:             time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC),
+		Summary:               "Called about a lost card.",
+		HandoffReason:         "escalation",
+		LastExecutedProcedure: "Lost card",
+		GradientLabsURL:       "https://app.example.com/call/1",
+	})
+
+	md := note.Markdown()
+	for _, want := range []string{
+		"Called about a lost card.",
+		"- Last call: Fri, 01 Mar 2024 09:30:00 UTC",
+		"- Call hand-off reason: escalation",
+		"- Last procedure: Lost card",
+		"- [Call in Gradient Labs](https://app.example.com/call/1)",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("Markdown does not contain %q:\n%s", want, md)
+		}
+	}
+	if strings.Contains(md, "Procedure in Gradient Labs") {
+		t.Error("empty procedure link was included")
+	}
+
+	kept := (&handoff.Note{Summary: "Agent summary"}).AddVoiceCallContext(&client.VoiceCallContext{Summary: "Call summary"})
+	if kept.Summary != "Agent summary" {
+		t.Errorf("Summary = %q", kept.Summary)
+	}
+	if (&handoff.Note{}).AddVoiceCallContext(nil).Markdown() != "" {
+		t.Error("nil call context added content")
+	}
+}
+
+func TestTemplateFor(t *testing.T) {
+	note := &handoff.Note{Summary: "Needs **help** & a <refund>"}
+
+	testCases := map[client.SupportPlatform]string{
+		client.SupportPlatformIntercom: "<p><strong>Summary</strong><br>\nNeeds <strong>help</strong> &amp; a &lt;refund&gt;</p>\n",
+		client.SupportPlatformZendesk:  "Summary\nNeeds help & a <refund>",
+		"":                             "**Summary**\nNeeds **help** & a <refund>",
+	}
+	for platform, want := range testCases {
+		if got := handoff.TemplateFor(platform)(note); got != want {
+			t.Errorf("%q template = %q, want %q", platform, got, want)
+		}
+	}
+}
+
+func TestNotePost(t *testing.T) {
+	note := handoff.NoteFromHandOff(testHandOff())
+
+	if _, err := note.EventParams("conv-1", handoff.PostOptions{}); err == nil {
+		t.Error("expected an error without a participant ID")
+	}
+
+	var sender fakeSender
+	opts := handoff.PostOptions{Platform: client.SupportPlatformZendesk, ParticipantID: "bot"}
+	if err := note.Post(context.Background(), &sender, "conv-1", opts); err != nil {
+		t.Fatalf("Post: %v", err)
+	}
+	if err := note.Post(context.Background(), &sender, "conv-1", opts); err != nil {
+		t.Fatalf("Post: %v", err)
+	}
+
+	if sender.conversationID != "conv-1" || len(sender.events) != 2 {
+		t.Fatalf("posted %d events to %q", len(sender.events), sender.conversationID)
+	}
+	p := sender.events[0]
+	if p.Type != client.ConversationEventInternalNote || p.ParticipantType != client.ParticipantTypeBot {
+		t.Errorf("params = %+v", p)
+	}
+	if strings.Contains(p.Body, "**") {
+		t.Errorf("Zendesk note contains Markdown: %q", p.Body)
+	}
+	if !strings.HasPrefix(p.IdempotencyKey, "note-") || p.IdempotencyKey != sender.events[1].IdempotencyKey {
+		t.Errorf("idempotency keys %q and %q", p.IdempotencyKey, sender.events[1].IdempotencyKey)
+	}
+
+	other, err := note.EventParams("conv-2", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other.IdempotencyKey == p.IdempotencyKey {
+		t.Error("notes on different conversations share an idempotency key")
+	}
+
+	opts.IdempotencyKey = "custom"
+	custom, err := note.EventParams("conv-1", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if custom.IdempotencyKey != "custom" {
+		t.Errorf("IdempotencyKey = %q", custom.IdempotencyKey)
+	}
+}
@@ -0,0 +1,287 @@
+package handoff
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	client "github.com/gradientlabs-ai/gradientlabs-go"
+)
+
+// ErrNoRoute is returned when no rule matches a hand-off, the agent didn't
+// choose a target, and there is no default hand-off target to fall back to.
+var ErrNoRoute = errors.New("handoff: no route for conversation")
+
+// RoutingAPI is the subset of client.API used by a Router.
+type RoutingAPI interface {
+	ListHandOffTargets(ctx context.Context, opts ...client.CallOption) (*client.HandOffTargets, error)
+	GetDefaultHandOffTarget(ctx context.Context, p *client.GetDefaultHandOffTargetParams, opts ...client.CallOption) (*client.GetDefaultHandOffTargetResponse, error)
+	AssignConversation(ctx context.Context, conversationID string, p *client.AssignmentParams, opts ...client.CallOption) error
+}
+
+// Input describes a hand-off to be routed.
+type Input struct {
+	// ConversationID identifies the conversation being handed off.
+	ConversationID string
+
+	// Channel is the conversation's channel.
+	Channel client.Channel
+
+	// ReasonCode is the code describing why the agent handed off.
+	ReasonCode string
+
+	// Intent is the customer's most recently classified intent.
+	Intent string
+
+	// Target is the ID of the hand-off target chosen by the agent (e.g. from
+	// AgentMetadata.IntentHandOffTarget).
+	Target string
+
+	// Time is when the hand-off happened. Defaults to the current time.
+	Time time.Time
+}
+
+// InputFromHandOff creates an Input from a `conversation.hand_off` event. The
+// event doesn't include the conversation's channel, so it must be given.
+func InputFromHandOff(ev *client.ConversationHandOffEvent, channel client.Channel) Input {
+	return Input{
+		ConversationID: ev.Conversation.ID,
+		Channel:        channel,
+		ReasonCode:     ev.Reason,
+		Intent:         ev.Intent,
+		Target:         ev.Target,
+	}
+}
+
+// Rule assigns matching hand-offs to a queue, team or person. Empty match
+// fields match anything, and non-empty ones match if any of their values do.
+type Rule struct {
+	// Name identifies the rule in Decisions.
+	Name string `json:"name"`
+
+	// ReasonCodes matches the hand-off's reason code.
+	ReasonCodes []string `json:"reason_codes,omitempty"`
+
+	// Intents matches the customer's intent.
+	Intents []string `json:"intents,omitempty"`
+
+	// Channels matches the conversation's channel.
+	Channels []client.Channel `json:"channels,omitempty"`
+
+	// Targets matches the ID of the hand-off target chosen by the agent.
+	Targets []string `json:"targets,omitempty"`
+
+	// Hours optionally restricts the rule to business hours.
+	Hours *BusinessHours `json:"hours,omitempty"`
+
+	// OutsideHours inverts Hours, so the rule only matches outside of
+	// business hours.
+	OutsideHours bool `json:"outside_hours,omitempty"`
+
+	// AssigneeID identifies who the conversation is assigned to.
+	AssigneeID string `json:"assignee_id"`
+
+	// AssigneeType is the type of participant being assigned. Defaults to
+	// client.ParticipantTypeHumanAgent.
+	AssigneeType client.ParticipantType `json:"assignee_type,omitempty"`
+}
+
+func (r *Rule) matches(in Input) bool {
+	if !matchAny(r.ReasonCodes, in.ReasonCode) ||
+		!matchAny(r.Intents, in.Intent) ||
+		!matchAny(r.Channels, in.Channel) ||
+		!matchAny(r.Targets, in.Target) {
+		return false
+	}
+	if r.Hours != nil && r.Hours.IsOpen(in.Time) == r.OutsideHours {
+		return false
+	}
+	return true
+}
+
+func matchAny[T comparable](values []T, v T) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, want := range values {
+		if want == v {
+			return true
+		}
+	}
+	return false
+}
+
+// RouterConfig configures a Router.
+type RouterConfig struct {
+	// Rules are evaluated in order, and the first match wins.
+	Rules []Rule `json:"rules"`
+
+	// DisableDefault stops the Router from falling back to the channel's
+	// default hand-off target when no rule matches and the agent didn't choose
+	// a target.
+	DisableDefault bool `json:"disable_default,omitempty"`
+}
+
+// LoadRouterConfig reads a JSON-encoded RouterConfig from the given file.
+func LoadRouterConfig(path string) (*RouterConfig, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg RouterConfig
+	if err := json.Unmarshal(b, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// Decision is the outcome of routing a hand-off.
+type Decision struct {
+	// Rule is the name of the rule that matched. Empty if the agent's target
+	// or the default hand-off target was used.
+	Rule string
+
+	// AssigneeID identifies who the conversation should be assigned to.
+	AssigneeID string
+
+	// AssigneeType is the type of participant being assigned.
+	AssigneeType client.ParticipantType
+
+	// AgentTarget is true if no rule matched and the hand-off target chosen
+	// by the agent was used.
+	AgentTarget bool
+
+	// Default is true if no rule matched, the agent didn't choose a target,
+	// and the channel's default hand-off target was used.
+	Default bool
+}
+
+// AssignmentParams returns the parameters to assign a conversation according
+// to the decision.
+func (d *Decision) AssignmentParams() *client.AssignmentParams {
+	reason := "Routed by hand-off rule " + d.Rule
+	switch {
+	case d.AgentTarget:
+		reason = "Routed to the hand-off target chosen by the agent"
+	case d.Default:
+		reason = "Routed to the default hand-off target"
+	}
+	return &client.AssignmentParams{
+		AssigneeID:   d.AssigneeID,
+		AssigneeType: d.AssigneeType,
+		Reason:       reason,
+	}
+}
+
+// Router turns hand-offs into assignments using declarative rules. Use
+// NewRouter to create one.
+type Router struct {
+	api RoutingAPI
+	cfg RouterConfig
+	now func() time.Time
+}
+
+// NewRouter creates a Router, checking that its rules are well-formed.
+func NewRouter(api RoutingAPI, cfg RouterConfig) (*Router, error) {
+	for i, r := range cfg.Rules {
+		if r.AssigneeID == "" {
+			return nil, fmt.Errorf("handoff: rule %d (%s) has no assignee", i, r.Name)
+		}
+		if r.Hours != nil {
+			if err := r.Hours.Validate(); err != nil {
+				return nil, fmt.Errorf("handoff: rule %d (%s): %w", i, r.Name, err)
+			}
+		}
+	}
+	return &Router{api: api, cfg: cfg, now: time.Now}, nil
+}
+
+// ValidateTargets checks that every target the rules match on exists in
+// ListHandOffTargets, to catch typos and targets that have since been deleted.
+func (r *Router) ValidateTargets(ctx context.Context) error {
+	targets, err := r.api.ListHandOffTargets(ctx)
+	if err != nil {
+		return err
+	}
+
+	known := make(map[string]bool, len(targets.Targets))
+	for _, t := range targets.Targets {
+		known[t.ID] = true
+	}
+
+	var errs []error
+	for _, rule := range r.cfg.Rules {
+		for _, id := range rule.Targets {
+			if !known[id] {
+				errs = append(errs, fmt.Errorf("handoff: rule %s matches unknown target %q", rule.Name, id))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Route decides who a hand-off should be assigned to: the first matching rule
+// wins, then the target chosen by the agent, then the channel's default
+// hand-off target.
+func (r *Router) Route(ctx context.Context, in Input) (*Decision, error) {
+	if in.Time.IsZero() {
+		in.Time = r.now()
+	}
+
+	for _, rule := range r.cfg.Rules {
+		if !rule.matches(in) {
+			continue
+		}
+		d := &Decision{
+			Rule:         rule.Name,
+			AssigneeID:   rule.AssigneeID,
+			AssigneeType: rule.AssigneeType,
+		}
+		if d.AssigneeType == "" {
+			d.AssigneeType = client.ParticipantTypeHumanAgent
+		}
+		return d, nil
+	}
+
+	if in.Target != "" {
+		return &Decision{
+			AssigneeID:   in.Target,
+			AssigneeType: client.ParticipantTypeHumanAgent,
+			AgentTarget:  true,
+		}, nil
+	}
+
+	if r.cfg.DisableDefault {
+		return nil, ErrNoRoute
+	}
+
+	def, err := r.api.GetDefaultHandOffTarget(ctx, &client.GetDefaultHandOffTargetParams{Channel: in.Channel})
+	if err != nil {
+		return nil, fmt.Errorf("handoff: getting default target: %w", err)
+	}
+	if def.ID == "" {
+		return nil, ErrNoRoute
+	}
+	return &Decision{
+		AssigneeID:   def.ID,
+		AssigneeType: client.ParticipantTypeHumanAgent,
+		Default:      true,
+	}, nil
+}
+
+// Assign routes the hand-off and assigns the conversation accordingly.
+func (r *Router) Assign(ctx context.Context, in Input) (*Decision, error) {
+	d, err := r.Route(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.api.AssignConversation(ctx, in.ConversationID, d.AssignmentParams()); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
@@ -0,0 +1,245 @@
+package handoff_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	client "github.com/gradientlabs-ai/gradientlabs-go"
+	"github.com/gradientlabs-ai/gradientlabs-go/handoff"
+)
+
+// fakeAPI is an in-memory handoff.SchedulerAPI.
+type fakeAPI struct {
+	fakeSender
+
+	targets       []string
+	defaultTarget string
+	defaultErr    error
+
+	assigned map[string]*client.AssignmentParams
+	messages []client.AddMessageParams
+	resumed  map[string]*client.ConversationResumeParams
+}
+
+func (f *fakeAPI) ListHandOffTargets(context.Context, ...client.CallOption) (*client.HandOffTargets, error) {
+	out := &client.HandOffTargets{}
+	for _, id := range f.targets {
+		out.Targets = append(out.Targets, &client.HandOffTarget{ID: id})
+	}
+	return out, nil
+}
+
+func (f *fakeAPI) GetDefaultHandOffTarget(context.Context, *client.GetDefaultHandOffTargetParams, ...client.CallOption) (*client.GetDefaultHandOffTargetResponse, error) {
+	if f.defaultErr != nil {
+		return nil, f.defaultErr
+	}
+	return &client.GetDefaultHandOffTargetResponse{ID: f.defaultTarget}, nil
+}
+
+func (f *fakeAPI) AssignConversation(_ context.Context, conversationID string, p *client.AssignmentParams, _ ...client.CallOption) error {
+	if f.assigned == nil {
+		f.assigned = make(map[string]*client.AssignmentParams)
+	}
+	f.assigned[conversationID] = p
+	return nil
+}
+
+func (f *fakeAPI) AddMessage(_ context.Context, _ string, p client.AddMessageParams, _ ...client.CallOption) (*client.Message, error) {
+	f.messages = append(f.messages, p)
+	return &client.Message{ID: p.ID}, nil
+}
+
+func (f *fakeAPI) ResumeConversation(_ context.Context, conversationID string, p *client.ConversationResumeParams, _ ...client.CallOption) error {
+	if f.resumed == nil {
+		f.resumed = make(map[string]*client.ConversationResumeParams)
+	}
+	f.resumed[conversationID] = p
+	return nil
+}
+
+var testRules = []handoff.Rule{
+	{
+		Name:        "fraud",
+		ReasonCodes: []string{"fraud"},
+		AssigneeID:  "fraud-team",
+	},
+	{
+		Name:         "out of hours",
+		Hours:        &handoff.BusinessHours{Open: "09:00", Close: "17:00"},
+		OutsideHours: true,
+		AssigneeID:   "night-shift",
+	},
+	{
+		Name:         "email billing",
+		Intents:      []string{"refund", "invoice"},
+		Channels:     []client.Channel{client.ChannelEmail},
+		AssigneeID:   "billing-bot",
+		AssigneeType: client.ParticipantTypeBot,
+	},
+	{
+		Name:       "vip",
+		Targets:    []string{"vip-desk"},
+		AssigneeID: "vip-team",
+	},
+}
+
+func TestRouterRoute(t *testing.T) {
+	open := time.Date(2024, 6, 3, 10, 0, 0, 0, time.UTC)
+
+	testCases := map[string]struct {
+		cfg  handoff.RouterConfig
+		api  fakeAPI
+		in   handoff.Input
+		want *handoff.Decision
+		err  error
+	}{
+		"first match wins": {
+			in:   handoff.Input{ReasonCode: "fraud", Time: time.Date(2024, 6, 3, 20, 0, 0, 0, time.UTC)},
+			want: &handoff.Decision{Rule: "fraud", AssigneeID: "fraud-team", AssigneeType: client.ParticipantTypeHumanAgent},
+		},
+		"outside hours": {
+			in:   handoff.Input{ReasonCode: "other", Time: time.Date(2024, 6, 3, 20, 0, 0, 0, time.UTC)},
+			want: &handoff.Decision{Rule: "out of hours", AssigneeID: "night-shift", AssigneeType: client.ParticipantTypeHumanAgent},
+		},
+		"intent and channel": {
+			in:   handoff.Input{Intent: "invoice", Channel: client.ChannelEmail, Time: open},
+			want: &handoff.Decision{Rule: "email billing", AssigneeID: "billing-bot", AssigneeType: client.ParticipantTypeBot},
+		},
+		"channel does not match": {
+			in:   handoff.Input{Intent: "invoice", Channel: client.ChannelChat, Time: open},
+			api:  fakeAPI{defaultTarget: "general"},
+			want: &handoff.Decision{AssigneeID: "general", AssigneeType: client.ParticipantTypeHumanAgent, Default: true},
+		},
+		"target rule": {
+			in:   handoff.Input{Target: "vip-desk", Time: open},
+			want: &handoff.Decision{Rule: "vip", AssigneeID: "vip-team", AssigneeType: client.ParticipantTypeHumanAgent},
+		},
+		"agent target before default": {
+			in:   handoff.Input{Target: "payments", Time: open},
+			api:  fakeAPI{defaultTarget: "general"},
+			want: &handoff.Decision{AssigneeID: "payments", AssigneeType: client.ParticipantTypeHumanAgent, AgentTarget: true},
+		},
+		"agent target with default disabled": {
+			cfg:  handoff.RouterConfig{DisableDefault: true},
+			in:   handoff.Input{Target: "payments", Time: open},
+			want: &handoff.Decision{AssigneeID: "payments", AssigneeType: client.ParticipantTypeHumanAgent, AgentTarget: true},
+		},
+		"default disabled": {
+			cfg: handoff.RouterConfig{DisableDefault: true},
+			in:  handoff.Input{Time: open},
+			err: handoff.ErrNoRoute,
+		},
+		"no default": {
+			in:  handoff.Input{Time: open},
+			err: handoff.ErrNoRoute,
+		},
+		"default error": {
+			in:  handoff.Input{Time: open},
+			api: fakeAPI{defaultErr: errors.New("boom")},
+			err: errors.New("handoff: getting default target: boom"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			cfg := tc.cfg
+			cfg.Rules = testRules
+			r, err := handoff.NewRouter(&tc.api, cfg)
+			if err != nil {
+				t.Fatalf("NewRouter: %v", err)
+			}
+
+			got, err := r.Route(context.Background(), tc.in)
+			if tc.err != nil {
+				if err == nil || (!errors.Is(err, tc.err) && err.Error() != tc.err.Error()) {
+					t.Fatalf("Route error = %v, want %v", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Route: %v", err)
+			}
+			if *got != *tc.want {
+				t.Errorf("Route = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRouterAssign(t *testing.T) {
+	api := &fakeAPI{}
+	r, err := handoff.NewRouter(api, handoff.RouterConfig{Rules: testRules})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	in := handoff.InputFromHandOff(&client.ConversationHandOffEvent{
+		Conversation: client.WebhookConversation{ID: "conv-1"},
+		Target:       "payments",
+	}, client.ChannelChat)
+	in.Time = time.Date(2024, 6, 3, 10, 0, 0, 0, time.UTC)
+
+	if _, err := r.Assign(context.Background(), in); err != nil {
+		t.Fatalf("Assign: %v", err)
+	}
+	p := api.assigned["conv-1"]
+	if p == nil || p.AssigneeID != "payments" || p.Reason != "Routed to the hand-off target chosen by the agent" {
+		t.Errorf("assigned %+v", p)
+	}
+}
+
+func TestNewRouterValidation(t *testing.T) {
+	if _, err := handoff.NewRouter(&fakeAPI{}, handoff.RouterConfig{Rules: []handoff.Rule{{Name: "x"}}}); err == nil {
+		t.Error("expected an error for a rule without an assignee")
+	}
+	bad := []handoff.Rule{{Name: "x", AssigneeID: "a", Hours: &handoff.BusinessHours{Open: "nine"}}}
+	if _, err := handoff.NewRouter(&fakeAPI{}, handoff.RouterConfig{Rules: bad}); err == nil {
+		t.Error("expected an error for invalid hours")
+	}
+}
+
+func TestRouterValidateTargets(t *testing.T) {
+	r, err := handoff.NewRouter(&fakeAPI{targets: []string{"vip-desk"}}, handoff.RouterConfig{Rules: testRules})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.ValidateTargets(context.Background()); err != nil {
+		t.Errorf("ValidateTargets: %v", err)
+	}
+
+	r, err = handoff.NewRouter(&fakeAPI{}, handoff.RouterConfig{Rules: testRules})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.ValidateTargets(context.Background()); err == nil {
+		t.Error("expected an error for an unknown target")
+	}
+}
+
+func TestLoadRouterConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "routes.json")
+	config := `{"rules": [{"name": "billing", "intents": ["refund"], "assignee_id": "billing",
+		"hours": {"time_zone": "Europe/London", "open": "00:00", "close": "00:00"}}]}`
+	if err := os.WriteFile(path, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := handoff.LoadRouterConfig(path)
+	if err != nil {
+		t.Fatalf("LoadRouterConfig: %v", err)
+	}
+	if len(cfg.Rules) != 1 || cfg.Rules[0].AssigneeID != "billing" || cfg.Rules[0].Hours.Close != "00:00" {
+		t.Errorf("config = %+v", cfg)
+	}
+
+	if err := os.WriteFile(path, []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := handoff.LoadRouterConfig(path); err == nil {
+		t.Error("expected a parse error")
+	}
+}
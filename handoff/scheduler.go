@@ -0,0 +1,292 @@
+package handoff
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	client "github.com/gradientlabs-ai/gradientlabs-go"
+)
+
+// SchedulerAPI is the subset of client.API used by a Scheduler.
+type SchedulerAPI interface {
+	RoutingAPI
+	EventSender
+
+	AddMessage(ctx context.Context, conversationID string, p client.AddMessageParams, opts ...client.CallOption) (*client.Message, error)
+	ResumeConversation(ctx context.Context, conversationID string, p *client.ConversationResumeParams, opts ...client.CallOption) error
+}
+
+// Capacity reports whether an assignee (e.g. a queue or team) can take on
+// another conversation.
+type Capacity interface {
+	HasCapacity(ctx context.Context, assigneeID string) (bool, error)
+}
+
+// CapacityFunc is a function that satisfies the Capacity interface.
+type CapacityFunc func(ctx context.Context, assigneeID string) (bool, error)
+
+// HasCapacity satisfies the Capacity interface.
+func (fn CapacityFunc) HasCapacity(ctx context.Context, assigneeID string) (bool, error) {
+	return fn(ctx, assigneeID)
+}
+
+// Unavailable describes why a hand-off could not be assigned.
+type Unavailable string
+
+const (
+	// UnavailableClosed means the assignee is outside of its business hours.
+	UnavailableClosed Unavailable = "closed"
+
+	// UnavailableAtCapacity means the assignee has no capacity.
+	UnavailableAtCapacity Unavailable = "at_capacity"
+)
+
+// Action is what a Scheduler does with a hand-off.
+type Action string
+
+const (
+	// ActionAssign assigns the conversation with AssignConversation.
+	ActionAssign Action = "assign"
+
+	// ActionNotify posts an internal note and sends the customer a message
+	// explaining when they can expect a reply, leaving the conversation with
+	// the support platform.
+	ActionNotify Action = "notify"
+
+	// ActionResume hands the conversation back to the AI agent with
+	// ResumeConversation, with a resource explaining why.
+	ActionResume Action = "resume"
+)
+
+// SchedulerConfig configures a Scheduler.
+type SchedulerConfig struct {
+	// Hours maps assignee IDs to their business hours. Assignees without
+	// hours are always open, unless DefaultHours is set.
+	Hours map[string]*BusinessHours `json:"hours,omitempty"`
+
+	// DefaultHours are the business hours of assignees not in Hours.
+	DefaultHours *BusinessHours `json:"default_hours,omitempty"`
+
+	// WhenUnavailable is what to do when the assignee is closed or at
+	// capacity: ActionNotify or ActionResume. Defaults to ActionNotify.
+	WhenUnavailable Action `json:"when_unavailable,omitempty"`
+
+	// ParticipantID identifies who internal notes and customer messages are
+	// from. Required for ActionNotify.
+	ParticipantID string `json:"participant_id,omitempty"`
+
+	// Platform is the support platform, used to format internal notes.
+	Platform client.SupportPlatform `json:"platform,omitempty"`
+
+	// Message returns the message sent to the customer for ActionNotify.
+	// nextOpen is the zero time if it isn't known (e.g. when at capacity).
+	// Defaults to DefaultMessage.
+	Message func(why Unavailable, nextOpen time.Time) string `json:"-"`
+
+	// ResourceName is the name of the resource set when resuming the AI
+	// agent. Defaults to "hand_off".
+	ResourceName string `json:"resource_name,omitempty"`
+}
+
+// DefaultMessage is the default customer message for ActionNotify.
+func DefaultMessage(why Unavailable, nextOpen time.Time) string {
+	if why == UnavailableClosed && !nextOpen.IsZero() {
+		return fmt.Sprintf("Thanks for your patience. Our team is currently offline, and will get back to you from %s.", nextOpen.Format("15:04 MST on Monday 2 January"))
+	}
+	return "Thanks for your patience. Our team is busier than usual, and will get back to you as soon as possible."
+}
+
+// Outcome describes what a Scheduler did with a hand-off.
+type Outcome struct {
+	// Action is what was done.
+	Action Action
+
+	// Decision is the routing decision for the hand-off.
+	Decision *Decision
+
+	// Unavailable is why the hand-off wasn't assigned, if it wasn't.
+	Unavailable Unavailable
+
+	// NextOpen is when the assignee is next open, if it was closed.
+	NextOpen time.Time
+}
+
+// Scheduler handles hand-offs by routing them with a Router and only
+// assigning them when the assignee is open and has capacity. Use NewScheduler
+// to create one.
+type Scheduler struct {
+	api      SchedulerAPI
+	router   *Router
+	capacity Capacity
+	cfg      SchedulerConfig
+	now      func() time.Time
+}
+
+// NewScheduler creates a Scheduler. capacity may be nil if assignees have
+// unlimited capacity.
+func NewScheduler(api SchedulerAPI, router *Router, capacity Capacity, cfg SchedulerConfig) (*Scheduler, error) {
+	if cfg.WhenUnavailable == "" {
+		cfg.WhenUnavailable = ActionNotify
+	}
+	switch cfg.WhenUnavailable {
+	case ActionNotify:
+		if cfg.ParticipantID == "" {
+			return nil, fmt.Errorf("handoff: participant ID is required for %s", ActionNotify)
+		}
+	case ActionResume:
+	default:
+		return nil, fmt.Errorf("handoff: invalid action when unavailable: %q", cfg.WhenUnavailable)
+	}
+
+	for id, h := range cfg.Hours {
+		if err := h.Validate(); err != nil {
+			return nil, fmt.Errorf("handoff: hours for %s: %w", id, err)
+		}
+	}
+	if cfg.DefaultHours != nil {
+		if err := cfg.DefaultHours.Validate(); err != nil {
+			return nil, fmt.Errorf("handoff: default hours: %w", err)
+		}
+	}
+
+	if cfg.Message == nil {
+		cfg.Message = DefaultMessage
+	}
+	if cfg.ResourceName == "" {
+		cfg.ResourceName = "hand_off"
+	}
+	return &Scheduler{api: api, router: router, capacity: capacity, cfg: cfg, now: time.Now}, nil
+}
+
+// HandOff schedules a `conversation.hand_off` event. The event doesn't include
+// the conversation's channel, so it must be given.
+//
+// The customer message sent for ActionNotify has an ID derived from the
+// conversation and the event's content, so a redelivered event doesn't message
+// the customer twice. Use HandOffWebhook to derive it from the webhook's ID
+// instead.
+func (s *Scheduler) HandOff(ctx context.Context, ev *client.ConversationHandOffEvent, channel client.Channel) (*Outcome, error) {
+	key := ev.Reason + "\x00" + ev.Description + "\x00" + ev.Target + "\x00" + ev.Intent + "\x00" + ev.Note
+	return s.handOff(ctx, ev, channel, key)
+}
+
+// HandOffWebhook schedules the hand-off in a `conversation.hand_off` webhook,
+// like HandOff, deriving the ID of any customer message from the webhook's ID.
+func (s *Scheduler) HandOffWebhook(ctx context.Context, w *client.Webhook, channel client.Channel) (*Outcome, error) {
+	ev, ok := w.ConversationHandOff()
+	if !ok {
+		return nil, fmt.Errorf("handoff: webhook %s is a %s event, not %s", w.ID, w.Type, client.WebhookTypeConversationHandOff)
+	}
+	return s.handOff(ctx, ev, channel, "webhook\x00"+w.ID)
+}
+
+func (s *Scheduler) handOff(ctx context.Context, ev *client.ConversationHandOffEvent, channel client.Channel, key string) (*Outcome, error) {
+	in := InputFromHandOff(ev, channel)
+	in.Time = s.now()
+
+	d, err := s.router.Route(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	out := &Outcome{Action: ActionAssign, Decision: d}
+
+	if h := s.hoursFor(d.AssigneeID); h != nil && !h.IsOpen(in.Time) {
+		out.Unavailable = UnavailableClosed
+		out.NextOpen = h.NextOpen(in.Time)
+	} else if s.capacity != nil {
+		ok, err := s.capacity.HasCapacity(ctx, d.AssigneeID)
+		if err != nil {
+			return nil, fmt.Errorf("handoff: checking capacity of %s: %w", d.AssigneeID, err)
+		}
+		if !ok {
+			out.Unavailable = UnavailableAtCapacity
+		}
+	}
+
+	if out.Unavailable == "" {
+		return out, s.api.AssignConversation(ctx, in.ConversationID, d.AssignmentParams())
+	}
+
+	out.Action = s.cfg.WhenUnavailable
+	switch out.Action {
+	case ActionResume:
+		return out, s.resume(ctx, ev, out)
+	default:
+		return out, s.notify(ctx, ev, out, key)
+	}
+}
+
+func (s *Scheduler) hoursFor(assigneeID string) *BusinessHours {
+	if h, ok := s.cfg.Hours[assigneeID]; ok {
+		return h
+	}
+	return s.cfg.DefaultHours
+}
+
+func (s *Scheduler) notify(ctx context.Context, ev *client.ConversationHandOffEvent, out *Outcome, key string) error {
+	note := NoteFromHandOff(ev)
+	note.AddField("Routed to", out.Decision.AssigneeID)
+	note.AddField("Not assigned", unavailableDescription(out.Unavailable))
+	if !out.NextOpen.IsZero() {
+		note.AddField("Next open", out.NextOpen.Format(time.RFC1123))
+	}
+
+	err := note.Post(ctx, s.api, ev.Conversation.ID, PostOptions{
+		Platform:      s.cfg.Platform,
+		ParticipantID: s.cfg.ParticipantID,
+	})
+	if err != nil {
+		return fmt.Errorf("handoff: posting note: %w", err)
+	}
+
+	_, err = s.api.AddMessage(ctx, ev.Conversation.ID, client.AddMessageParams{
+		ID:              notifyMessageID(ev.Conversation.ID, key),
+		Body:            s.cfg.Message(out.Unavailable, out.NextOpen),
+		ParticipantID:   s.cfg.ParticipantID,
+		ParticipantType: client.ParticipantTypeBot,
+		Created:         s.now(),
+	})
+	if err != nil {
+		return fmt.Errorf("handoff: sending customer message: %w", err)
+	}
+	return nil
+}
+
+func (s *Scheduler) resume(ctx context.Context, ev *client.ConversationHandOffEvent, out *Outcome) error {
+	resource := map[string]any{
+		"unavailable": string(out.Unavailable),
+		"description": unavailableDescription(out.Unavailable),
+		"assignee_id": out.Decision.AssigneeID,
+		"reason_code": ev.Reason,
+	}
+	if !out.NextOpen.IsZero() {
+		resource["next_open"] = out.NextOpen.Format(time.RFC3339)
+	}
+
+	return s.api.ResumeConversation(ctx, ev.Conversation.ID, &client.ConversationResumeParams{
+		AssigneeType: client.ParticipantTypeAIAgent,
+		Reason:       "Hand-off target is " + unavailableDescription(out.Unavailable),
+		Resources:    client.Resources{s.cfg.ResourceName: resource},
+	})
+}
+
+// notifyMessageID derives the ID of the customer message sent for
+// ActionNotify, which only allows letters, numbers and _ - + =.
+func notifyMessageID(conversationID, key string) string {
+	sum := sha256.Sum256([]byte(conversationID + "\x00" + key))
+	return "hand-off-" + hex.EncodeToString(sum[:12])
+}
+
+func unavailableDescription(why Unavailable) string {
+	switch why {
+	case UnavailableClosed:
+		return "outside of business hours"
+	case UnavailableAtCapacity:
+		return "at capacity"
+	default:
+		return string(why)
+	}
+}
@@ -0,0 +1,223 @@
+package handoff_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	client "github.com/gradientlabs-ai/gradientlabs-go"
+	"github.com/gradientlabs-ai/gradientlabs-go/handoff"
+)
+
+// closedHours returns business hours that are closed for at least the next
+// hour.
+func closedHours() *handoff.BusinessHours {
+	now := time.Now().UTC()
+	return &handoff.BusinessHours{
+		Days:  []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"},
+		Open:  now.Add(2 * time.Hour).Format("15:04"),
+		Close: now.Add(3 * time.Hour).Format("15:04"),
+	}
+}
+
+func newScheduler(t *testing.T, api *fakeAPI, capacity handoff.Capacity, cfg handoff.SchedulerConfig) *handoff.Scheduler {
+	t.Helper()
+	router, err := handoff.NewRouter(api, handoff.RouterConfig{DisableDefault: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := handoff.NewScheduler(api, router, capacity, cfg)
+	if err != nil {
+		t.Fatalf("NewScheduler: %v", err)
+	}
+	return s
+}
+
+func schedulerHandOff(conv string) *client.ConversationHandOffEvent {
+	return &client.ConversationHandOffEvent{
+		Conversation: client.WebhookConversation{ID: conv, CustomerID: "cust-1"},
+		Target:       "billing",
+		Reason:       "customer-request",
+		Note:         "Wants a refund.",
+	}
+}
+
+func atCapacity(context.Context, string) (bool, error) { return false, nil }
+
+func TestSchedulerAssigns(t *testing.T) {
+	api := &fakeAPI{}
+	s := newScheduler(t, api, handoff.CapacityFunc(func(_ context.Context, id string) (bool, error) {
+		return id == "billing", nil
+	}), handoff.SchedulerConfig{ParticipantID: "bot"})
+
+	out, err := s.HandOff(context.Background(), schedulerHandOff("conv-1"), client.ChannelChat)
+	if err != nil {
+		t.Fatalf("HandOff: %v", err)
+	}
+	if out.Action != handoff.ActionAssign || out.Unavailable != "" {
+		t.Errorf("outcome = %+v", out)
+	}
+	if p := api.assigned["conv-1"]; p == nil || p.AssigneeID != "billing" {
+		t.Errorf("assigned %+v", p)
+	}
+}
+
+func TestSchedulerNotifies(t *testing.T) {
+	testCases := map[string]struct {
+		capacity handoff.Capacity
+		hours    *handoff.BusinessHours
+		want     handoff.Unavailable
+		message  string
+	}{
+		"closed": {
+			hours:   closedHours(),
+			want:    handoff.UnavailableClosed,
+			message: "Our team is currently offline",
+		},
+		"at capacity": {
+			capacity: handoff.CapacityFunc(atCapacity),
+			want:     handoff.UnavailableAtCapacity,
+			message:  "Our team is busier than usual",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			api := &fakeAPI{}
+			cfg := handoff.SchedulerConfig{ParticipantID: "bot"}
+			if tc.hours != nil {
+				cfg.Hours = map[string]*handoff.BusinessHours{"billing": tc.hours}
+			}
+			s := newScheduler(t, api, tc.capacity, cfg)
+
+			out, err := s.HandOff(context.Background(), schedulerHandOff("conv-1"), client.ChannelChat)
+			if err != nil {
+				t.Fatalf("HandOff: %v", err)
+			}
+			if out.Action != handoff.ActionNotify || out.Unavailable != tc.want {
+				t.Errorf("outcome = %+v", out)
+			}
+			if (tc.want == handoff.UnavailableClosed) == out.NextOpen.IsZero() {
+				t.Errorf("NextOpen = %v", out.NextOpen)
+			}
+			if len(api.assigned) != 0 {
+				t.Errorf("assigned %+v", api.assigned)
+			}
+
+			if len(api.events) != 1 || api.events[0].Type != client.ConversationEventInternalNote {
+				t.Fatalf("events = %+v", api.events)
+			}
+			if len(api.messages) != 1 {
+				t.Fatalf("sent %d messages", len(api.messages))
+			}
+			m := api.messages[0]
+			if !strings.Contains(m.Body, tc.message) || m.ParticipantID != "bot" || m.ParticipantType != client.ParticipantTypeBot {
+				t.Errorf("message = %+v", m)
+			}
+		})
+	}
+}
+
+func TestSchedulerNotifyMessageID(t *testing.T) {
+	api := &fakeAPI{}
+	s := newScheduler(t, api, handoff.CapacityFunc(atCapacity), handoff.SchedulerConfig{ParticipantID: "bot"})
+	ctx := context.Background()
+
+	for _, conv := range []string{"conv-1", "conv-1", "conv-2"} {
+		if _, err := s.HandOff(ctx, schedulerHandOff(conv), client.ChannelChat); err != nil {
+			t.Fatal(err)
+		}
+	}
+	webhook := func(id string) *client.Webhook {
+		return &client.Webhook{ID: id, Type: client.WebhookTypeConversationHandOff, Data: schedulerHandOff("conv-1")}
+	}
+	for _, id := range []string{"wh-1", "wh-1", "wh-2"} {
+		if _, err := s.HandOffWebhook(ctx, webhook(id), client.ChannelChat); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ids := make([]string, len(api.messages))
+	for i, m := range api.messages {
+		ids[i] = m.ID
+		if !strings.HasPrefix(m.ID, "hand-off-") || strings.ContainsAny(m.ID, " :.") {
+			t.Errorf("invalid message ID %q", m.ID)
+		}
+	}
+	if ids[0] != ids[1] || ids[3] != ids[4] {
+		t.Errorf("redelivered hand-offs got different IDs: %q", ids)
+	}
+	if ids[1] == ids[2] || ids[4] == ids[5] || ids[0] == ids[3] {
+		t.Errorf("different hand-offs share an ID: %q", ids)
+	}
+
+	if _, err := s.HandOffWebhook(ctx, &client.Webhook{ID: "wh-3", Type: client.WebhookTypeAgentMessage}, client.ChannelChat); err == nil {
+		t.Error("expected an error for a non-hand-off webhook")
+	}
+}
+
+func TestSchedulerResumes(t *testing.T) {
+	api := &fakeAPI{}
+	s := newScheduler(t, api, nil, handoff.SchedulerConfig{
+		DefaultHours:    closedHours(),
+		WhenUnavailable: handoff.ActionResume,
+		ResourceName:    "team_status",
+	})
+
+	out, err := s.HandOff(context.Background(), schedulerHandOff("conv-1"), client.ChannelChat)
+	if err != nil {
+		t.Fatalf("HandOff: %v", err)
+	}
+	if out.Action != handoff.ActionResume {
+		t.Errorf("outcome = %+v", out)
+	}
+
+	p := api.resumed["conv-1"]
+	if p == nil || p.AssigneeType != client.ParticipantTypeAIAgent {
+		t.Fatalf("resumed %+v", p)
+	}
+	resource, ok := p.Resources["team_status"].(map[string]any)
+	if !ok || resource["unavailable"] != "closed" || resource["assignee_id"] != "billing" || resource["next_open"] == nil {
+		t.Errorf("resource = %+v", p.Resources)
+	}
+	if len(api.messages) != 0 || len(api.events) != 0 {
+		t.Error("resuming also notified the customer")
+	}
+}
+
+func TestSchedulerErrors(t *testing.T) {
+	api := &fakeAPI{}
+	router, err := handoff.NewRouter(api, handoff.RouterConfig{DisableDefault: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := handoff.NewScheduler(api, router, nil, handoff.SchedulerConfig{}); err == nil {
+		t.Error("expected an error without a participant ID")
+	}
+	if _, err := handoff.NewScheduler(api, router, nil, handoff.SchedulerConfig{WhenUnavailable: "shrug"}); err == nil {
+		t.Error("expected an error for an invalid action")
+	}
+	if _, err := handoff.NewScheduler(api, router, nil, handoff.SchedulerConfig{
+		ParticipantID: "bot",
+		DefaultHours:  &handoff.BusinessHours{Open: "nine"},
+	}); err == nil {
+		t.Error("expected an error for invalid hours")
+	}
+
+	boom := errors.New("boom")
+	s := newScheduler(t, api, handoff.CapacityFunc(func(context.Context, string) (bool, error) {
+		return false, boom
+	}), handoff.SchedulerConfig{ParticipantID: "bot"})
+	if _, err := s.HandOff(context.Background(), schedulerHandOff("conv-1"), client.ChannelChat); !errors.Is(err, boom) {
+		t.Errorf("HandOff error = %v", err)
+	}
+
+	ev := schedulerHandOff("conv-1")
+	ev.Target = ""
+	if _, err := s.HandOff(context.Background(), ev, client.ChannelChat); !errors.Is(err, handoff.ErrNoRoute) {
+		t.Errorf("HandOff error = %v", err)
+	}
+}
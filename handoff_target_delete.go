@@ -14,15 +14,6 @@ type HandOffTargetDeleteParams struct {
 // is in use - either in a procedure, or in an intent.
 //
 // Note: requires a `Management` API key.
-func (c *Client) DeleteHandOffTarget(ctx context.Context, p *HandOffTargetDeleteParams) error {
-	rsp, err := c.makeRequest(ctx, http.MethodDelete, "hand-off-targets", p)
-	if err != nil {
-		return err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return err
-	}
-	return nil
+func (c *Client) DeleteHandOffTarget(ctx context.Context, p *HandOffTargetDeleteParams, opts ...CallOption) error {
+	return c.do(ctx, "DeleteHandOffTarget", http.MethodDelete, "hand-off-targets", p, nil, opts...)
 }
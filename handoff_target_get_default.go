@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 )
@@ -21,20 +20,10 @@ type GetDefaultHandOffTargetResponse struct {
 // GetDefaultHandOffTarget gets the current default hand-off target for the company.
 //
 // Note: requires a `Management` API key.
-func (c *Client) GetDefaultHandOffTarget(ctx context.Context, p *GetDefaultHandOffTargetParams) (*GetDefaultHandOffTargetResponse, error) {
+func (c *Client) GetDefaultHandOffTarget(ctx context.Context, p *GetDefaultHandOffTargetParams, opts ...CallOption) (*GetDefaultHandOffTargetResponse, error) {
 	path := fmt.Sprintf("hand-off-targets/default?channel=%s", p.Channel)
-	rsp, err := c.makeRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return nil, err
-	}
-
 	var response GetDefaultHandOffTargetResponse
-	if err := json.NewDecoder(rsp.Body).Decode(&response); err != nil {
+	if err := c.do(ctx, "GetDefaultHandOffTarget", http.MethodGet, path, nil, &response, opts...); err != nil {
 		return nil, err
 	}
 	return &response, nil
@@ -20,15 +20,6 @@ type SetDefaultHandOffTargetParams struct {
 // or procedure.
 //
 // Note: requires a `Management` API key.
-func (c *Client) SetDefaultHandOffTarget(ctx context.Context, p *SetDefaultHandOffTargetParams) error {
-	rsp, err := c.makeRequest(ctx, http.MethodPut, "hand-off-targets/default", p)
-	if err != nil {
-		return err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return err
-	}
-	return nil
+func (c *Client) SetDefaultHandOffTarget(ctx context.Context, p *SetDefaultHandOffTargetParams, opts ...CallOption) error {
+	return c.do(ctx, "SetDefaultHandOffTarget", http.MethodPut, "hand-off-targets/default", p, nil, opts...)
 }
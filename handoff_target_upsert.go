@@ -17,15 +17,6 @@ type UpsertHandOffTargetParams struct {
 // UpsertHandOffTarget inserts or updates a hand-off target.
 //
 // Note: requires a `Management` API key.
-func (c *Client) UpsertHandOffTarget(ctx context.Context, p *UpsertHandOffTargetParams) error {
-	rsp, err := c.makeRequest(ctx, http.MethodPost, "hand-off-targets", p)
-	if err != nil {
-		return err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return err
-	}
-	return nil
+func (c *Client) UpsertHandOffTarget(ctx context.Context, p *UpsertHandOffTargetParams, opts ...CallOption) error {
+	return c.do(ctx, "UpsertHandOffTarget", http.MethodPost, "hand-off-targets", p, nil, opts...)
 }
@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 )
 
@@ -13,19 +12,9 @@ type HandOffTargets struct {
 // ListHandOffTargets returns all of your hand off targets.
 //
 // Note: requires a `Management` API key.
-func (c *Client) ListHandOffTargets(ctx context.Context) (*HandOffTargets, error) {
-	rsp, err := c.makeRequest(ctx, http.MethodGet, "hand-off-targets", nil)
-	if err != nil {
-		return nil, err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return nil, err
-	}
-
+func (c *Client) ListHandOffTargets(ctx context.Context, opts ...CallOption) (*HandOffTargets, error) {
 	var targets HandOffTargets
-	if err := json.NewDecoder(rsp.Body).Decode(&targets); err != nil {
+	if err := c.do(ctx, "ListHandOffTargets", http.MethodGet, "hand-off-targets", nil, &targets, opts...); err != nil {
 		return nil, err
 	}
 	return &targets, nil
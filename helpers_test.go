@@ -0,0 +1,55 @@
+package client
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a client that sends its requests to handler.
+func newTestClient(t *testing.T, handler http.HandlerFunc, opts ...Option) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	c, err := NewClient(append([]Option{WithURL(srv.URL), WithAPIKey("test-key")}, opts...)...)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return c
+}
+
+// writeJSON writes a JSON response with the given status code.
+func writeJSON(w http.ResponseWriter, status int, body string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(body))
+}
+
+// signedWebhookRequest builds a webhook request signed with the given key.
+func signedWebhookRequest(t *testing.T, target, signingKey, body string) *http.Request {
+	t.Helper()
+
+	ts := time.Now()
+	sig, err := (WebhookVerifier{secret: []byte(signingKey)}).computeSignature(ts, []byte(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	req.Header.Set(signatureHeader, fmt.Sprintf("t=%d,v1=%s", ts.Unix(), hex.EncodeToString(sig)))
+	return req
+}
+
+const testAgentMessageWebhook = `{
+	"id": "wh-1",
+	"type": "agent.message",
+	"sequence_number": 1,
+	"timestamp": "2024-01-01T00:00:00Z",
+	"data": {"conversation": {"id": "conv-1", "customer_id": "cust-1"}, "body": "Hello"}
+}`
@@ -0,0 +1,101 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotencyMode determines how the client chooses the Idempotency-Key header
+// sent with mutating (i.e. non-GET) requests, so they can be safely retried
+// without creating duplicates.
+type IdempotencyMode int
+
+const (
+	// IdempotencyRandom generates a new random key for every call. The key is
+	// reused if the call is retried. This is the default.
+	IdempotencyRandom IdempotencyMode = iota
+
+	// IdempotencyDeterministic derives the key from the operation, path and
+	// params, so repeating a call with identical params (e.g. after a crash)
+	// is also de-duplicated.
+	IdempotencyDeterministic
+
+	// IdempotencyDisabled only sends a key when one has been given with
+	// ContextWithIdempotencyKey.
+	IdempotencyDisabled
+)
+
+// WithIdempotency sets how the client generates idempotency keys for mutating
+// requests.
+func WithIdempotency(mode IdempotencyMode) Option {
+	return idempotencyOption{mode}
+}
+
+type idempotencyOption struct{ mode IdempotencyMode }
+
+func (idempotencyOption) isClientOption() {}
+
+type idempotencyKeyContextKey struct{}
+
+// ContextWithIdempotencyKey returns a context that causes the client to send
+// the given Idempotency-Key with mutating requests made using it, instead of
+// generating one.
+func ContextWithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, key)
+}
+
+// IdempotencyKeyFromContext returns the key set with ContextWithIdempotencyKey,
+// if any.
+func IdempotencyKeyFromContext(ctx context.Context) (string, bool) {
+	key, ok := ctx.Value(idempotencyKeyContextKey{}).(string)
+	return key, ok && key != ""
+}
+
+func (c *Client) idempotencyKey(ctx context.Context, req *Request) (string, error) {
+	if req.Method == http.MethodGet {
+		return "", nil
+	}
+
+	// A key given with WithCallHeader is sent as-is.
+	if key := req.Header.Get(idempotencyKeyHeader); key != "" {
+		return key, nil
+	}
+	if key, ok := IdempotencyKeyFromContext(ctx); ok {
+		return key, nil
+	}
+
+	switch c.idempotency {
+	case IdempotencyDeterministic:
+		return deterministicIdempotencyKey(req)
+	case IdempotencyDisabled:
+		return "", nil
+	default:
+		return randomIdempotencyKey()
+	}
+}
+
+func deterministicIdempotencyKey(req *Request) (string, error) {
+	h := sha256.New()
+	_, _ = io.WriteString(h, req.Operation+"\n"+req.Method+" "+req.Path+"\n")
+	if req.Params != nil {
+		if err := json.NewEncoder(h).Encode(req.Params); err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)[:16]), nil
+}
+
+func randomIdempotencyKey() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,111 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestIdempotencyKeys(t *testing.T) {
+	params := StartConversationParams{ID: "conv-1", CustomerID: "cust-1", Channel: ChannelChat}
+
+	testCases := map[string]struct {
+		mode     IdempotencyMode
+		ctxKey   string
+		callKey  string
+		header   string
+		wantSame bool // whether repeating the call sends the same key
+		wantNone bool
+	}{
+		"random":               {mode: IdempotencyRandom},
+		"deterministic":        {mode: IdempotencyDeterministic, wantSame: true},
+		"disabled":             {mode: IdempotencyDisabled, wantNone: true},
+		"context key":          {mode: IdempotencyDisabled, ctxKey: "ctx-key", wantSame: true},
+		"call option key":      {mode: IdempotencyRandom, callKey: "call-key", wantSame: true},
+		"call option over ctx": {mode: IdempotencyRandom, ctxKey: "ctx-key", callKey: "call-key", wantSame: true},
+		"call header key":      {mode: IdempotencyRandom, header: "header-key", wantSame: true},
+		"call header over all": {mode: IdempotencyDeterministic, ctxKey: "ctx-key", callKey: "call-key", header: "header-key", wantSame: true},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var keys []string
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				keys = append(keys, r.Header.Get(idempotencyKeyHeader))
+				writeJSON(w, http.StatusOK, `{"id":"conv-1"}`)
+			}, WithIdempotency(tc.mode))
+
+			ctx := context.Background()
+			if tc.ctxKey != "" {
+				ctx = ContextWithIdempotencyKey(ctx, tc.ctxKey)
+			}
+			var opts []CallOption
+			if tc.callKey != "" {
+				opts = append(opts, WithCallIdempotencyKey(tc.callKey))
+			}
+			if tc.header != "" {
+				opts = append(opts, WithCallHeader(idempotencyKeyHeader, tc.header))
+			}
+
+			for i := 0; i < 2; i++ {
+				if _, err := c.StartConversation(ctx, params, opts...); err != nil {
+					t.Fatalf("StartConversation: %v", err)
+				}
+			}
+
+			switch {
+			case tc.wantNone:
+				if keys[0] != "" || keys[1] != "" {
+					t.Errorf("keys = %q, want none", keys)
+				}
+			case keys[0] == "":
+				t.Errorf("no key sent")
+			case tc.wantSame != (keys[0] == keys[1]):
+				t.Errorf("keys = %q, want same: %v", keys, tc.wantSame)
+			}
+			for _, want := range []string{tc.header, tc.callKey, tc.ctxKey} {
+				if want == "" {
+					continue
+				}
+				if keys[0] != want {
+					t.Errorf("key = %q, want %q", keys[0], want)
+				}
+				break
+			}
+		})
+	}
+}
+
+func TestIdempotencyKeyNotSentWithGet(t *testing.T) {
+	var key string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		key = r.Header.Get(idempotencyKeyHeader)
+		writeJSON(w, http.StatusOK, `{"id":"conv-1"}`)
+	}, WithIdempotency(IdempotencyDeterministic))
+
+	ctx := ContextWithIdempotencyKey(context.Background(), "ignored")
+	if _, err := c.ReadConversation(ctx, "conv-1", &ReadParams{}); err != nil {
+		t.Fatalf("ReadConversation: %v", err)
+	}
+	if key != "" {
+		t.Errorf("Idempotency-Key = %q, want none", key)
+	}
+}
+
+func TestDeterministicIdempotencyKeyDependsOnParams(t *testing.T) {
+	key := func(p StartConversationParams) string {
+		k, err := deterministicIdempotencyKey(&Request{Operation: "StartConversation", Method: http.MethodPost, Path: "conversations", Params: p})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return k
+	}
+
+	a := key(StartConversationParams{ID: "conv-1"})
+	if a != key(StartConversationParams{ID: "conv-1"}) {
+		t.Error("identical params gave different keys")
+	}
+	if a == key(StartConversationParams{ID: "conv-2"}) {
+		t.Error("different params gave the same key")
+	}
+}
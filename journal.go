@@ -0,0 +1,291 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// JournalEntryKind distinguishes actions taken via the client from webhooks
+// received from Gradient Labs.
+type JournalEntryKind string
+
+const (
+	// JournalEntryAction is an API call made against a conversation.
+	JournalEntryAction JournalEntryKind = "action"
+
+	// JournalEntryWebhook is a webhook received by Client.ParseWebhook.
+	JournalEntryWebhook JournalEntryKind = "webhook"
+)
+
+// JournalEntry records a single action or webhook.
+type JournalEntry struct {
+	// Time is when the action was taken or the webhook was received.
+	Time time.Time `json:"time"`
+
+	// Kind distinguishes actions from webhooks.
+	Kind JournalEntryKind `json:"kind"`
+
+	// ConversationID identifies the conversation the entry relates to.
+	ConversationID string `json:"conversation_id,omitempty"`
+
+	// Operation is the name of the Client method that was called (e.g.
+	// "AddMessage"). Only set for actions.
+	Operation string `json:"operation,omitempty"`
+
+	// Method and Path describe the HTTP request. Only set for actions.
+	Method string `json:"method,omitempty"`
+	Path   string `json:"path,omitempty"`
+
+	// Params is the JSON-encoded request body, with sensitive fields (e.g.
+	// conversation_token) replaced by JournalRedacted. Only set for actions.
+	Params json.RawMessage `json:"params,omitempty"`
+
+	// IdempotencyKey is the Idempotency-Key that was sent with the action, so
+	// that replaying it cannot create duplicates.
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
+
+	// Duration is how long the action took.
+	Duration time.Duration `json:"duration,omitempty"`
+
+	// StatusCode is the HTTP status code of a failed action, if the API
+	// responded.
+	StatusCode int `json:"status_code,omitempty"`
+
+	// Error describes why the action failed. Empty if it succeeded.
+	Error string `json:"error,omitempty"`
+
+	// WebhookID, WebhookType and SequenceNumber identify a received webhook.
+	WebhookID      string      `json:"webhook_id,omitempty"`
+	WebhookType    WebhookType `json:"webhook_type,omitempty"`
+	SequenceNumber int         `json:"sequence_number,omitempty"`
+
+	// WebhookData is the JSON-encoded webhook event data.
+	WebhookData json.RawMessage `json:"webhook_data,omitempty"`
+}
+
+// Succeeded reports whether the entry records a successful action (webhooks
+// always succeed).
+func (e *JournalEntry) Succeeded() bool {
+	return e.Error == ""
+}
+
+// JournalRedacted replaces the values of sensitive fields in
+// JournalEntry.Params.
+const JournalRedacted = "[REDACTED]"
+
+// journalRedactedFields are the JSON object keys whose values are redacted
+// wherever they appear in journaled params.
+var journalRedactedFields = map[string]bool{
+	"conversation_token": true,
+	"token":              true,
+	"api_key":            true,
+	"password":           true,
+	"secret":             true,
+}
+
+// Journal is an append-only log of every action taken against a conversation
+// via the client, and every webhook it received. See the journal package for a
+// file-based implementation.
+type Journal interface {
+	Record(ctx context.Context, e *JournalEntry) error
+}
+
+// JournalError is passed to the handler set with WithJournalErrorHandler when an
+// action or webhook could not be recorded in the journal.
+type JournalError struct {
+	Err error
+}
+
+// Error satisfies the error interface.
+func (e *JournalError) Error() string {
+	return fmt.Sprintf("recording journal entry: %v", e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *JournalError) Unwrap() error {
+	return e.Err
+}
+
+// WithJournal records every action the client takes against a conversation
+// (e.g. AddMessage, AssignConversation, FinishConversation), and every webhook
+// parsed by Client.ParseWebhook, in the given journal.
+//
+// Actions and webhooks that cannot be recorded are handled as normal (so a
+// successful call still returns its result), and a *JournalError is passed to
+// the handler set with WithJournalErrorHandler instead.
+func WithJournal(j Journal) Option {
+	return journalOption{j}
+}
+
+// WithJournalErrorHandler sets a function that is called with a *JournalError
+// when an action or webhook could not be recorded in the journal. If it isn't
+// set, those errors are ignored.
+func WithJournalErrorHandler(fn func(error)) Option {
+	return journalErrorHandlerOption{fn}
+}
+
+type journalOption struct{ journal Journal }
+type journalErrorHandlerOption struct{ fn func(error) }
+
+func (journalOption) isClientOption()             {}
+func (journalErrorHandlerOption) isClientOption() {}
+
+func journalMiddleware(j Journal, onError func(error)) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request, out any) error {
+			convID, ok := journaledConversation(req, out)
+			if !ok {
+				return next(ctx, req, out)
+			}
+
+			started := time.Now()
+			callErr := next(ctx, req, out)
+
+			if convID == "" {
+				convID, _ = journaledConversation(req, out)
+			}
+			entry := &JournalEntry{
+				Time:           started,
+				Kind:           JournalEntryAction,
+				ConversationID: convID,
+				Operation:      req.Operation,
+				Method:         req.Method,
+				Path:           req.Path,
+				IdempotencyKey: req.Header.Get(idempotencyKeyHeader),
+				Duration:       time.Since(started),
+			}
+			if req.Params != nil {
+				entry.Params = journalParams(req.Params)
+			}
+			if callErr != nil {
+				entry.Error = callErr.Error()
+				var re *ResponseError
+				if errors.As(callErr, &re) {
+					entry.StatusCode = re.StatusCode
+				}
+			}
+
+			// A failure to record the action mustn't make it look like the
+			// action failed, or the caller may repeat it.
+			if err := j.Record(ctx, entry); err != nil && onError != nil {
+				onError(&JournalError{err})
+			}
+			return callErr
+		}
+	}
+}
+
+// journaledConversation reports whether the request is an action against a
+// conversation that should be journaled, and if so, the conversation's ID (if
+// it is known yet).
+func journaledConversation(req *Request, out any) (string, bool) {
+	switch req.Operation {
+	case "StartConversation":
+		if conv, ok := out.(*Conversation); ok && conv.ID != "" {
+			return conv.ID, true
+		}
+		if p, ok := req.Params.(StartConversationParams); ok {
+			return p.ID, true
+		}
+		return "", true
+	case "StartOutboundConversation":
+		if rsp, ok := out.(*StartOutboundConversationResponse); ok {
+			return rsp.ConversationID, true
+		}
+		return "", true
+	}
+
+	if req.Method == http.MethodGet {
+		return "", false
+	}
+	path, _, _ := strings.Cut(req.Path, "?")
+	parts := strings.Split(path, "/")
+	if len(parts) < 3 || parts[0] != "conversations" {
+		return "", false
+	}
+	return parts[1], true
+}
+
+// journalParams encodes params as JSON, redacting sensitive fields.
+func journalParams(params any) json.RawMessage {
+	b, err := json.Marshal(params)
+	if err != nil {
+		return nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return b
+	}
+	redactJournalValue(v)
+	if b, err = json.Marshal(v); err != nil {
+		return nil
+	}
+	return b
+}
+
+func redactJournalValue(v any) {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, vv := range t {
+			if journalRedactedFields[strings.ToLower(k)] {
+				t[k] = JournalRedacted
+			} else {
+				redactJournalValue(vv)
+			}
+		}
+	case []any:
+		for _, vv := range t {
+			redactJournalValue(vv)
+		}
+	}
+}
+
+// journalWebhook records a received webhook. Failures are reported to the
+// journal error handler rather than returned, so they never cause a correctly
+// signed webhook to be rejected.
+func (c *Client) journalWebhook(ctx context.Context, w *Webhook) {
+	if c.journal == nil {
+		return
+	}
+
+	entry := &JournalEntry{
+		Time:           time.Now(),
+		Kind:           JournalEntryWebhook,
+		WebhookID:      w.ID,
+		WebhookType:    w.Type,
+		SequenceNumber: w.SequenceNumber,
+	}
+	if b, err := json.Marshal(w.Data); err == nil {
+		entry.WebhookData = b
+	}
+	entry.ConversationID = webhookConversationID(w)
+
+	if err := c.journal.Record(ctx, entry); err != nil && c.journalErrors != nil {
+		c.journalErrors(&JournalError{err})
+	}
+}
+
+func webhookConversationID(w *Webhook) string {
+	switch d := w.Data.(type) {
+	case *AgentMessageEvent:
+		return d.Conversation.ID
+	case *ConversationHandOffEvent:
+		return d.Conversation.ID
+	case *ConversationFinishedEvent:
+		return d.Conversation.ID
+	case *ActionExecuteEvent:
+		return d.Conversation.ID
+	case *ResourcePullEvent:
+		return d.Conversation.ID
+	}
+	return ""
+}
@@ -0,0 +1,138 @@
+// Package journal provides a file-based client.Journal that writes one JSON
+// entry per line (JSONL), and tooling to read and replay journals.
+//
+//	j, err := journal.OpenFile("conversations.jsonl")
+//	if err != nil {
+//		...
+//	}
+//	defer j.Close()
+//
+//	c, err := client.NewClient(
+//		client.WithAPIKey(apiKey),
+//		client.WithJournal(j),
+//	)
+package journal
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	client "github.com/gradientlabs-ai/gradientlabs-go"
+)
+
+// File is an append-only JSONL client.Journal. It is safe for concurrent use.
+type File struct {
+	mu   sync.Mutex
+	f    *os.File
+	sync bool
+}
+
+// Option customises a File.
+type Option func(*File)
+
+// WithSync makes the File fsync after every entry, so entries survive a crash
+// at the cost of throughput.
+func WithSync() Option {
+	return func(f *File) { f.sync = true }
+}
+
+// OpenFile opens (or creates) the journal at path for appending.
+func OpenFile(path string, opts ...Option) (*File, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	j := &File{f: f}
+	for _, opt := range opts {
+		opt(j)
+	}
+	return j, nil
+}
+
+// Record satisfies the client.Journal interface.
+func (j *File) Record(_ context.Context, e *client.JournalEntry) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if _, err := j.f.Write(b); err != nil {
+		return err
+	}
+	if j.sync {
+		return j.f.Sync()
+	}
+	return nil
+}
+
+// Close closes the underlying file.
+func (j *File) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return j.f.Close()
+}
+
+// Read calls fn with each entry in the JSONL journal read from r, in order. It
+// stops at the first error returned by fn.
+func Read(r io.Reader, fn func(*client.JournalEntry) error) error {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	line := 0
+	for sc.Scan() {
+		line++
+		if len(sc.Bytes()) == 0 {
+			continue
+		}
+
+		var e client.JournalEntry
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			return fmt.Errorf("journal line %d: %w", line, err)
+		}
+		if err := fn(&e); err != nil {
+			return err
+		}
+	}
+	return sc.Err()
+}
+
+// ReadFile calls fn with each entry in the journal file at path, in order.
+func ReadFile(path string, fn func(*client.JournalEntry) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return Read(f, fn)
+}
+
+// Filter returns a function for Read that only passes on entries for which
+// keep returns true.
+func Filter(keep func(*client.JournalEntry) bool, fn func(*client.JournalEntry) error) func(*client.JournalEntry) error {
+	return func(e *client.JournalEntry) error {
+		if !keep(e) {
+			return nil
+		}
+		return fn(e)
+	}
+}
+
+// ForConversation returns a predicate for Filter matching entries for the
+// given conversation.
+func ForConversation(conversationID string) func(*client.JournalEntry) bool {
+	return func(e *client.JournalEntry) bool {
+		return e.ConversationID == conversationID
+	}
+}
@@ -0,0 +1,162 @@
+package journal_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	client "github.com/gradientlabs-ai/gradientlabs-go"
+	"github.com/gradientlabs-ai/gradientlabs-go/journal"
+)
+
+func action(op, convID, params string) *client.JournalEntry {
+	return &client.JournalEntry{
+		Time:           time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+		Kind:           client.JournalEntryAction,
+		ConversationID: convID,
+		Operation:      op,
+		Params:         json.RawMessage(params),
+		IdempotencyKey: "key-" + op,
+	}
+}
+
+func writeJournal(t *testing.T, entries ...*client.JournalEntry) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "journal.jsonl")
+	j, err := journal.OpenFile(path, journal.WithSync())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		if err := j.Record(context.Background(), e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := j.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestFileRoundTrip(t *testing.T) {
+	path := writeJournal(t,
+		action("AddMessage", "conv-1", `{"id":"m1"}`),
+		action("FinishConversation", "conv-2", `{}`),
+		action("AssignConversation", "conv-1", `{"assignee_type":"Agent"}`),
+	)
+
+	var ops []string
+	err := journal.ReadFile(path, journal.Filter(journal.ForConversation("conv-1"), func(e *client.JournalEntry) error {
+		ops = append(ops, e.Operation)
+		return nil
+	}))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if strings.Join(ops, ",") != "AddMessage,AssignConversation" {
+		t.Errorf("ops = %v", ops)
+	}
+}
+
+func TestReadStopsOnError(t *testing.T) {
+	errStop := errors.New("stop")
+	var n int
+	err := journal.Read(strings.NewReader("{\"operation\":\"a\"}\n\n{\"operation\":\"b\"}\n"), func(*client.JournalEntry) error {
+		n++
+		return errStop
+	})
+	if !errors.Is(err, errStop) || n != 1 {
+		t.Errorf("Read = %v after %d entries, want %v after 1", err, n, errStop)
+	}
+
+	if err := journal.Read(strings.NewReader("not json\n"), func(*client.JournalEntry) error { return nil }); err == nil {
+		t.Error("expected an error for a malformed line")
+	}
+}
+
+func TestReplayFile(t *testing.T) {
+	failed := action("CancelConversation", "conv-1", `{}`)
+	failed.Error = "409 conflict"
+
+	path := writeJournal(t,
+		action("StartConversation", "conv-1", `{"id":"conv-1","customer_id":"cust-1","channel":"web","conversation_token":"[REDACTED]"}`),
+		action("AddResource", "conv-1", `{"order":"1234"}`),
+		failed,
+		&client.JournalEntry{Kind: client.JournalEntryWebhook, WebhookID: "wh-1"},
+		action("FinishConversation", "conv-1", `{"reason":"resolved"}`),
+	)
+
+	type request struct {
+		path, key string
+		body      map[string]any
+	}
+	var requests []request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := request{path: r.URL.Path, key: r.Header.Get("Idempotency-Key")}
+		_ = json.NewDecoder(r.Body).Decode(&req.body)
+		requests = append(requests, req)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"conv-1"}`))
+	}))
+	defer srv.Close()
+
+	c, err := client.NewClient(client.WithURL(srv.URL), client.WithAPIKey("key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	skipped, err := journal.ReplayFile(context.Background(), c, path)
+	if err != nil {
+		t.Fatalf("ReplayFile: %v", err)
+	}
+
+	if len(skipped) != 1 || skipped[0].Operation != "AddResource" {
+		t.Errorf("skipped = %+v, want the AddResource entry", skipped)
+	}
+	if len(requests) != 2 {
+		t.Fatalf("requests = %+v, want StartConversation and FinishConversation", requests)
+	}
+
+	start := requests[0]
+	if start.path != "/conversations" || start.key != "key-StartConversation" {
+		t.Errorf("start request = %+v", start)
+	}
+	if _, ok := start.body["conversation_token"]; ok {
+		t.Errorf("start body = %v, want the redacted token omitted", start.body)
+	}
+	if finish := requests[1]; finish.path != "/conversations/conv-1/finish" || finish.body["reason"] != "resolved" {
+		t.Errorf("finish request = %+v", finish)
+	}
+}
+
+func TestReplayUnknownOperation(t *testing.T) {
+	err := journal.Replay(context.Background(), nil, action("AddResource", "conv-1", `{}`))
+	if !errors.Is(err, journal.ErrNotReplayable) {
+		t.Errorf("error = %v, want ErrNotReplayable", err)
+	}
+}
+
+func TestOpenFileAppends(t *testing.T) {
+	path := writeJournal(t, action("AddMessage", "conv-1", `{}`))
+
+	j, err := journal.OpenFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = j.Record(context.Background(), action("FinishConversation", "conv-1", `{}`))
+	_ = j.Close()
+
+	b, _ := os.ReadFile(path)
+	if n := strings.Count(string(b), "\n"); n != 2 {
+		t.Errorf("lines = %d, want 2", n)
+	}
+}
@@ -0,0 +1,160 @@
+package journal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	client "github.com/gradientlabs-ai/gradientlabs-go"
+)
+
+// ErrNotReplayable is returned by Replay for actions it doesn't know how to
+// re-execute (e.g. the deprecated AddResource).
+var ErrNotReplayable = errors.New("journal: action cannot be replayed")
+
+// Replay re-executes a successful action entry against the given API (e.g. to
+// rebuild state in another environment).
+//
+// The entry's recorded idempotency key is reused, so the API ignores a replay of
+// an action it has already processed, but only for as long as it retains the
+// key. Replaying older entries against the same workspace may perform the
+// action again.
+//
+// Redacted params (see client.JournalRedacted) are omitted, so e.g. a replayed
+// StartConversation won't have a conversation token. Webhook entries and failed
+// actions are skipped.
+func Replay(ctx context.Context, api client.ConversationAPI, e *client.JournalEntry) error {
+	if e.Kind != client.JournalEntryAction || !e.Succeeded() {
+		return nil
+	}
+
+	var opts []client.CallOption
+	if e.IdempotencyKey != "" {
+		opts = append(opts, client.WithCallIdempotencyKey(e.IdempotencyKey))
+	}
+	id := e.ConversationID
+
+	switch e.Operation {
+	case "StartConversation":
+		var p client.StartConversationParams
+		if err := decode(e, &p); err != nil {
+			return err
+		}
+		_, err := api.StartConversation(ctx, p, opts...)
+		return err
+	case "StartOutboundConversation":
+		var p client.StartOutboundConversationParams
+		if err := decode(e, &p); err != nil {
+			return err
+		}
+		_, err := api.StartOutboundConversation(ctx, p, opts...)
+		return err
+	case "AddMessage":
+		var p client.AddMessageParams
+		if err := decode(e, &p); err != nil {
+			return err
+		}
+		_, err := api.AddMessage(ctx, id, p, opts...)
+		return err
+	case "AddConversationEvent":
+		var p client.EventParams
+		if err := decode(e, &p); err != nil {
+			return err
+		}
+		return api.AddConversationEvent(ctx, id, &p, opts...)
+	case "AssignConversation":
+		var p client.AssignmentParams
+		if err := decode(e, &p); err != nil {
+			return err
+		}
+		return api.AssignConversation(ctx, id, &p, opts...)
+	case "RateConversation":
+		var p client.RatingParams
+		if err := decode(e, &p); err != nil {
+			return err
+		}
+		return api.RateConversation(ctx, id, &p, opts...)
+	case "FinishConversation":
+		var p client.FinishParams
+		if err := decode(e, &p); err != nil {
+			return err
+		}
+		return api.FinishConversation(ctx, id, p, opts...)
+	case "CancelConversation":
+		var p client.CancelParams
+		if err := decode(e, &p); err != nil {
+			return err
+		}
+		return api.CancelConversation(ctx, id, p, opts...)
+	case "ResumeConversation":
+		var p client.ConversationResumeParams
+		if err := decode(e, &p); err != nil {
+			return err
+		}
+		return api.ResumeConversation(ctx, id, &p, opts...)
+	case "ReturnAsyncToolResult":
+		var p client.ReturnAsyncToolResultParams
+		if err := decode(e, &p); err != nil {
+			return err
+		}
+		return api.ReturnAsyncToolResult(ctx, id, p, opts...)
+	default:
+		return fmt.Errorf("%w: %s", ErrNotReplayable, e.Operation)
+	}
+}
+
+// ReplayFile replays every successful action in the journal file at path, in
+// order, stopping at the first error. Actions that cannot be replayed are
+// skipped and returned, so they can be reported.
+func ReplayFile(ctx context.Context, api client.ConversationAPI, path string) (skipped []*client.JournalEntry, err error) {
+	err = ReadFile(path, func(e *client.JournalEntry) error {
+		err := Replay(ctx, api, e)
+		switch {
+		case errors.Is(err, ErrNotReplayable):
+			skipped = append(skipped, e)
+		case err != nil:
+			return fmt.Errorf("replaying %s on %s at %s: %w", e.Operation, e.ConversationID, e.Time, err)
+		}
+		return nil
+	})
+	return skipped, err
+}
+
+func decode(e *client.JournalEntry, v any) error {
+	if len(e.Params) == 0 {
+		return nil
+	}
+
+	var params any
+	if err := json.Unmarshal(e.Params, &params); err != nil {
+		return fmt.Errorf("journal: decoding %s params: %w", e.Operation, err)
+	}
+	b, err := json.Marshal(omitRedacted(params))
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("journal: decoding %s params: %w", e.Operation, err)
+	}
+	return nil
+}
+
+// omitRedacted removes redacted fields from decoded JSON params.
+func omitRedacted(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, vv := range t {
+			if vv == client.JournalRedacted {
+				delete(t, k)
+			} else {
+				t[k] = omitRedacted(vv)
+			}
+		}
+	case []any:
+		for i, vv := range t {
+			t[i] = omitRedacted(vv)
+		}
+	}
+	return v
+}
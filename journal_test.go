@@ -0,0 +1,156 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type memoryJournal struct {
+	mu      sync.Mutex
+	entries []*JournalEntry
+	err     error
+}
+
+func (j *memoryJournal) Record(_ context.Context, e *JournalEntry) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.err != nil {
+		return j.err
+	}
+	j.entries = append(j.entries, e)
+	return nil
+}
+
+func TestJournalRecordsActions(t *testing.T) {
+	j := &memoryJournal{}
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/conversations":
+			writeJSON(w, http.StatusOK, `{"id":"conv-1"}`)
+		case "/conversations/conv-1/read":
+			writeJSON(w, http.StatusOK, `{"id":"conv-1"}`)
+		default:
+			writeJSON(w, http.StatusConflict, `{"message":"already finished"}`)
+		}
+	}, WithJournal(j))
+	ctx := context.Background()
+
+	if _, err := c.StartConversation(ctx, StartConversationParams{
+		ID: "conv-1", CustomerID: "cust-1", Channel: ChannelChat, ConversationToken: "very-secret",
+	}); err != nil {
+		t.Fatalf("StartConversation: %v", err)
+	}
+	if _, err := c.ReadConversation(ctx, "conv-1", &ReadParams{}); err != nil {
+		t.Fatalf("ReadConversation: %v", err)
+	}
+	if err := c.FinishConversation(ctx, "conv-1", FinishParams{Reason: "done"}); err == nil {
+		t.Fatal("expected FinishConversation to fail")
+	}
+
+	if len(j.entries) != 2 {
+		t.Fatalf("entries = %d, want 2 (reads are not journaled)", len(j.entries))
+	}
+
+	start := j.entries[0]
+	if start.Operation != "StartConversation" || start.ConversationID != "conv-1" || !start.Succeeded() {
+		t.Errorf("start entry = %+v", start)
+	}
+	if start.IdempotencyKey == "" {
+		t.Error("start entry has no idempotency key")
+	}
+	if strings.Contains(string(start.Params), "very-secret") {
+		t.Errorf("params contain the conversation token: %s", start.Params)
+	}
+	var params map[string]any
+	_ = json.Unmarshal(start.Params, &params)
+	if params["conversation_token"] != JournalRedacted || params["customer_id"] != "cust-1" {
+		t.Errorf("params = %s", start.Params)
+	}
+
+	finish := j.entries[1]
+	if finish.Succeeded() || finish.StatusCode != http.StatusConflict || finish.ConversationID != "conv-1" {
+		t.Errorf("finish entry = %+v", finish)
+	}
+}
+
+func TestJournalErrorDoesNotFailAction(t *testing.T) {
+	errDisk := errors.New("disk full")
+
+	var reported error
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, `{"id":"conv-1"}`)
+	},
+		WithJournal(&memoryJournal{err: errDisk}),
+		WithJournalErrorHandler(func(err error) { reported = err }),
+	)
+
+	conv, err := c.StartConversation(context.Background(), StartConversationParams{
+		ID:         "conv-1",
+		CustomerID: "cust-1",
+		Channel:    ChannelChat,
+	})
+	if err != nil || conv == nil || conv.ID != "conv-1" {
+		t.Fatalf("StartConversation = %+v, %v, want the conversation despite the journal error", conv, err)
+	}
+
+	var je *JournalError
+	if !errors.As(reported, &je) || !errors.Is(reported, errDisk) {
+		t.Errorf("reported error = %v, want a JournalError wrapping %v", reported, errDisk)
+	}
+}
+
+func TestJournalWebhooks(t *testing.T) {
+	j := &memoryJournal{}
+	c, err := NewClient(WithAPIKey("key"), WithWebhookSigningKey("secret"), WithJournal(j))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := c.ParseWebhook(signedWebhookRequest(t, "/", "secret", testAgentMessageWebhook)); err != nil {
+		t.Fatalf("ParseWebhook: %v", err)
+	}
+	if len(j.entries) != 1 {
+		t.Fatalf("entries = %d, want 1", len(j.entries))
+	}
+	if e := j.entries[0]; e.Kind != JournalEntryWebhook || e.WebhookID != "wh-1" || e.ConversationID != "conv-1" {
+		t.Errorf("entry = %+v", e)
+	}
+}
+
+func TestJournalErrorDoesNotRejectWebhook(t *testing.T) {
+	errDisk := errors.New("disk full")
+
+	var reported error
+	c, err := NewClient(
+		WithAPIKey("key"),
+		WithWebhookSigningKey("secret"),
+		WithJournal(&memoryJournal{err: errDisk}),
+		WithJournalErrorHandler(func(err error) { reported = err }),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	webhook, _, err := c.ParseWebhook(signedWebhookRequest(t, "/", "secret", testAgentMessageWebhook))
+	if err != nil || webhook == nil {
+		t.Fatalf("ParseWebhook = %v, %v, want the webhook despite the journal error", webhook, err)
+	}
+
+	var je *JournalError
+	if !errors.As(reported, &je) || !errors.Is(reported, errDisk) {
+		t.Errorf("reported error = %v, want a JournalError wrapping %v", reported, errDisk)
+	}
+}
+
+func TestJournalParamsKeepLargeIntegers(t *testing.T) {
+	got := journalParams(map[string]any{"order_id": uint64(1<<53 + 1), "token": "secret"})
+	if want := `{"order_id":9007199254740993,"token":"[REDACTED]"}`; string(got) != want {
+		t.Errorf("params = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,144 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// Request describes a single API operation as it passes through the
+// middleware chain.
+type Request struct {
+	// Operation is the name of the Client method being called (e.g.
+	// "StartConversation").
+	Operation string
+
+	// Method is the HTTP method that will be used (e.g. http.MethodPost).
+	Method string
+
+	// BaseURL is the API's base URL (e.g. https://api.gradient-labs.ai).
+	BaseURL string
+
+	// Path is the request path relative to the base URL, including any query
+	// string.
+	Path string
+
+	// Params is the typed value that will be encoded as the JSON request body
+	// (e.g. StartConversationParams), or nil if the request has no body.
+	//
+	// Middleware may replace it to mutate the request.
+	Params any
+
+	// Header contains any additional headers to send with the request (e.g.
+	// Idempotency-Key).
+	Header http.Header
+
+	// MaxRetries is the number of times the request will be retried if it
+	// fails with a transient error.
+	MaxRetries int
+}
+
+// Handler executes an API operation. If out is non-nil, it is a pointer to the
+// typed response value (e.g. *Conversation) that the handler must populate.
+type Handler func(ctx context.Context, req *Request, out any) error
+
+// Middleware wraps a Handler to add behaviour around every API operation, such
+// as auditing, caching, request mutation or fault injection.
+type Middleware func(next Handler) Handler
+
+// WithMiddleware adds middleware to the client. Middleware is applied in the
+// order given, so the first one is the outermost (i.e. it sees the request
+// first and the response last).
+func WithMiddleware(mw ...Middleware) Option {
+	return middlewareOption{mw}
+}
+
+type middlewareOption struct{ middleware []Middleware }
+
+func (middlewareOption) isClientOption() {}
+
+func chainMiddleware(h Handler, mw []Middleware) Handler {
+	for i := len(mw) - 1; i >= 0; i-- {
+		h = mw[i](h)
+	}
+	return h
+}
+
+func (c *Client) do(ctx context.Context, op, method, path string, params, out any, opts ...CallOption) error {
+	h := c.handler
+	if h == nil {
+		h = c.send
+	}
+
+	req := &Request{
+		Operation:  op,
+		Method:     method,
+		BaseURL:    c.url,
+		Path:       path,
+		Params:     params,
+		Header:     make(http.Header),
+		MaxRetries: c.maxRetries,
+	}
+
+	for _, opt := range opts {
+		switch t := opt.(type) {
+		case callTimeoutOption:
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, t.timeout)
+			defer cancel()
+		case callHeaderOption:
+			req.Header.Add(t.key, t.value)
+		case callURLOption:
+			req.BaseURL = t.url
+		case callRetriesOption:
+			req.MaxRetries = t.retries
+		case responseCaptureOption:
+			ctx = ContextWithResponseCapture(ctx, t.meta)
+		case callIdempotencyKeyOption:
+			ctx = ContextWithIdempotencyKey(ctx, t.key)
+		}
+	}
+
+	// The key is chosen before the middleware chain runs, so any retries made
+	// by middleware (or by the client itself) reuse it.
+	key, err := c.idempotencyKey(ctx, req)
+	if err != nil {
+		return err
+	}
+	if key != "" {
+		req.Header.Set(idempotencyKeyHeader, key)
+	}
+
+	return h(ctx, req, out)
+}
+
+// send is the innermost Handler, which performs the HTTP request.
+func (c *Client) send(ctx context.Context, req *Request, out any) error {
+	apiKey, err := c.credentials.APIKey(ctx)
+	if err != nil {
+		return err
+	}
+
+	started := time.Now()
+	rsp, attempts, err := c.roundTrip(ctx, apiKey, req)
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+
+	if meta := responseCaptureFromContext(ctx); meta != nil {
+		if err := captureResponse(meta, rsp, started, attempts); err != nil {
+			return err
+		}
+	}
+
+	if err := responseError(rsp); err != nil {
+		return err
+	}
+
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(rsp.Body).Decode(out)
+}
@@ -0,0 +1,116 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestMiddlewareOrder(t *testing.T) {
+	var calls []string
+	record := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context, req *Request, out any) error {
+				calls = append(calls, name+":before")
+				err := next(ctx, req, out)
+				calls = append(calls, name+":after")
+				return err
+			}
+		}
+	}
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "server")
+		writeJSON(w, http.StatusOK, `{"id":"conv-1"}`)
+	}, WithMiddleware(record("outer"), record("inner")))
+
+	if _, err := c.ReadConversation(context.Background(), "conv-1", &ReadParams{}); err != nil {
+		t.Fatalf("ReadConversation: %v", err)
+	}
+
+	want := []string{"outer:before", "inner:before", "server", "inner:after", "outer:after"}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+}
+
+func TestMiddlewareSeesOperation(t *testing.T) {
+	var got *Request
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, `{"id":"conv-1"}`)
+	}, WithMiddleware(func(next Handler) Handler {
+		return func(ctx context.Context, req *Request, out any) error {
+			got = req
+			return next(ctx, req, out)
+		}
+	}))
+
+	params := StartConversationParams{ID: "conv-1", CustomerID: "cust-1", Channel: ChannelChat}
+	if _, err := c.StartConversation(context.Background(), params); err != nil {
+		t.Fatalf("StartConversation: %v", err)
+	}
+
+	if got.Operation != "StartConversation" {
+		t.Errorf("Operation = %q, want StartConversation", got.Operation)
+	}
+	if got.Method != http.MethodPost || got.Path != "conversations" {
+		t.Errorf("request = %s %s, want POST conversations", got.Method, got.Path)
+	}
+	if p, ok := got.Params.(StartConversationParams); !ok || p.ID != "conv-1" {
+		t.Errorf("Params = %#v, want the StartConversationParams", got.Params)
+	}
+}
+
+func TestMiddlewareMutatesParams(t *testing.T) {
+	var body map[string]any
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		writeJSON(w, http.StatusOK, `{"id":"conv-1"}`)
+	}, WithMiddleware(func(next Handler) Handler {
+		return func(ctx context.Context, req *Request, out any) error {
+			if p, ok := req.Params.(StartConversationParams); ok {
+				p.TrafficGroupID = "group-1"
+				req.Params = p
+			}
+			return next(ctx, req, out)
+		}
+	}))
+
+	params := StartConversationParams{ID: "conv-1", CustomerID: "cust-1", Channel: ChannelChat}
+	if _, err := c.StartConversation(context.Background(), params); err != nil {
+		t.Fatalf("StartConversation: %v", err)
+	}
+	if body["traffic_group_id"] != "group-1" {
+		t.Errorf("traffic_group_id = %v, want group-1", body["traffic_group_id"])
+	}
+}
+
+func TestMiddlewareShortCircuits(t *testing.T) {
+	errInjected := errors.New("injected")
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request should not have been sent")
+	}, WithMiddleware(func(Handler) Handler {
+		return func(ctx context.Context, req *Request, out any) error {
+			if conv, ok := out.(*Conversation); ok {
+				conv.ID = "cached"
+				return nil
+			}
+			return errInjected
+		}
+	}))
+
+	conv, err := c.ReadConversation(context.Background(), "conv-1", &ReadParams{})
+	if err != nil {
+		t.Fatalf("ReadConversation: %v", err)
+	}
+	if conv.ID != "cached" {
+		t.Errorf("ID = %q, want cached", conv.ID)
+	}
+
+	if err := c.FinishConversation(context.Background(), "conv-1", FinishParams{}); !errors.Is(err, errInjected) {
+		t.Errorf("FinishConversation error = %v, want %v", err, errInjected)
+	}
+}
@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 	"time"
 )
@@ -30,19 +29,9 @@ type CreateNoteParams struct {
 }
 
 // CreateNote creates a new note.
-func (c *Client) CreateNote(ctx context.Context, p *CreateNoteParams) (*Note, error) {
-	rsp, err := c.makeRequest(ctx, http.MethodPost, "notes", p)
-	if err != nil {
-		return nil, err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return nil, err
-	}
-
+func (c *Client) CreateNote(ctx context.Context, p *CreateNoteParams, opts ...CallOption) (*Note, error) {
 	var note Note
-	if err := json.NewDecoder(rsp.Body).Decode(&note); err != nil {
+	if err := c.do(ctx, "CreateNote", http.MethodPost, "notes", p, &note, opts...); err != nil {
 		return nil, err
 	}
 	return &note, nil
@@ -7,15 +7,6 @@ import (
 )
 
 // DeleteNote marks a note as deleted.
-func (c *Client) DeleteNote(ctx context.Context, noteID string) error {
-	rsp, err := c.makeRequest(ctx, http.MethodDelete, fmt.Sprintf("notes/%s", noteID), nil)
-	if err != nil {
-		return err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return err
-	}
-	return nil
+func (c *Client) DeleteNote(ctx context.Context, noteID string, opts ...CallOption) error {
+	return c.do(ctx, "DeleteNote", http.MethodDelete, fmt.Sprintf("notes/%s", noteID), nil, nil, opts...)
 }
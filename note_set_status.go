@@ -13,15 +13,6 @@ type SetNoteStatusParams struct {
 }
 
 // SetNoteStatus updates a note's status.
-func (c *Client) SetNoteStatus(ctx context.Context, noteID string, p *SetNoteStatusParams) error {
-	rsp, err := c.makeRequest(ctx, http.MethodPost, fmt.Sprintf("notes/%s/status", noteID), p)
-	if err != nil {
-		return err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return err
-	}
-	return nil
+func (c *Client) SetNoteStatus(ctx context.Context, noteID string, p *SetNoteStatusParams, opts ...CallOption) error {
+	return c.do(ctx, "SetNoteStatus", http.MethodPost, fmt.Sprintf("notes/%s/status", noteID), p, nil, opts...)
 }
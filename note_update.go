@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"time"
@@ -28,19 +27,9 @@ type UpdateNoteParams struct {
 }
 
 // UpdateNote updates an existing note's contents.
-func (c *Client) UpdateNote(ctx context.Context, noteID string, p *UpdateNoteParams) (*Note, error) {
-	rsp, err := c.makeRequest(ctx, http.MethodPost, fmt.Sprintf("notes/%s", noteID), p)
-	if err != nil {
-		return nil, err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return nil, err
-	}
-
+func (c *Client) UpdateNote(ctx context.Context, noteID string, p *UpdateNoteParams, opts ...CallOption) (*Note, error) {
 	var note Note
-	if err := json.NewDecoder(rsp.Body).Decode(&note); err != nil {
+	if err := c.do(ctx, "UpdateNote", http.MethodPost, fmt.Sprintf("notes/%s", noteID), p, &note, opts...); err != nil {
 		return nil, err
 	}
 	return &note, nil
@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 )
 
@@ -79,7 +78,7 @@ type StartOutboundConversationParams struct {
 	// where keys are resource type names and values are the corresponding data.
 	// Example: {"customer_profile": {"tier": "premium", "lifetime_value": 5000}}
 	// The data will be made available to the AI agent for context during conversation processing.
-	Resources json.RawMessage `json:"resources,omitempty"`
+	Resources Resources `json:"resources,omitempty"`
 }
 
 // StartOutboundConversationResponse contains the response from starting an outbound conversation.
@@ -98,19 +97,16 @@ type StartOutboundConversationResponse struct {
 //
 // If Body and Subject are provided, that message will be sent as the initial message.
 // Otherwise, the AI agent will generate an appropriate initial message based on the procedure.
-func (c *Client) StartOutboundConversation(ctx context.Context, p StartOutboundConversationParams) (*StartOutboundConversationResponse, error) {
-	rsp, err := c.makeRequest(ctx, http.MethodPost, "outbound/conversations", p)
-	if err != nil {
-		return nil, err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
+//
+// The parameters are checked with StartOutboundConversationParams.Validate
+// before the request is sent.
+func (c *Client) StartOutboundConversation(ctx context.Context, p StartOutboundConversationParams, opts ...CallOption) (*StartOutboundConversationResponse, error) {
+	if err := p.Validate(); err != nil {
 		return nil, err
 	}
 
 	var result StartOutboundConversationResponse
-	if err := json.NewDecoder(rsp.Body).Decode(&result); err != nil {
+	if err := c.do(ctx, "StartOutboundConversation", http.MethodPost, "outbound/conversations", p, &result, opts...); err != nil {
 		return nil, err
 	}
 	return &result, nil
@@ -1,5 +1,7 @@
 package client
 
+import "context"
+
 type PaginationInfo struct {
 	// Next is a cursor to retrieve the next page of results.
 	Next *string `json:"next,omitempty"`
@@ -7,3 +9,80 @@ type PaginationInfo struct {
 	// Prev is a cursor to retrieve the previous page of results.
 	Prev *string `json:"prev,omitempty"`
 }
+
+// PageFunc fetches the page of results at the given cursor, which is empty for
+// the first page.
+type PageFunc[T any] func(ctx context.Context, cursor string) ([]T, *PaginationInfo, error)
+
+// Iterator steps through the results of a paginated list endpoint, fetching
+// pages as needed:
+//
+//	it := client.IterateConversations(c, client.ListConversationsParams{})
+//	for it.Next(ctx) {
+//		conv := it.Value()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type Iterator[T any] struct {
+	fetch  PageFunc[T]
+	cursor string
+	page   []T
+	index  int
+	done   bool
+	err    error
+}
+
+// NewIterator creates an Iterator that fetches pages with fetch.
+func NewIterator[T any](fetch PageFunc[T]) *Iterator[T] {
+	return &Iterator[T]{fetch: fetch, index: -1}
+}
+
+// Next advances to the next result, fetching the next page if necessary. It
+// returns false when there are no more results or an error occurred.
+func (it *Iterator[T]) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+
+	it.index++
+	for it.index >= len(it.page) {
+		if it.done {
+			return false
+		}
+
+		page, info, err := it.fetch(ctx, it.cursor)
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		it.page, it.index = page, 0
+		if info == nil || info.Next == nil || *info.Next == "" || *info.Next == it.cursor {
+			it.done = true
+		} else {
+			it.cursor = *info.Next
+		}
+	}
+	return true
+}
+
+// Value returns the current result.
+func (it *Iterator[T]) Value() T {
+	return it.page[it.index]
+}
+
+// Err returns the error that stopped iteration, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// All consumes the iterator, returning all of the remaining results.
+func (it *Iterator[T]) All(ctx context.Context) ([]T, error) {
+	var all []T
+	for it.Next(ctx) {
+		all = append(all, it.Value())
+	}
+	return all, it.Err()
+}
@@ -0,0 +1,317 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrUnknownWorkspace is returned when a ClientPool has no client configured
+// for the requested workspace.
+var ErrUnknownWorkspace = errors.New("unknown workspace")
+
+// WorkspaceConfig configures the Client for a single workspace (e.g. brand) in
+// a ClientPool.
+type WorkspaceConfig struct {
+	// URL optionally overrides the default base URL.
+	URL string `json:"url,omitempty"`
+
+	// APIKey is the workspace's API key. Prefer APIKeyEnv or APIKeyFile to
+	// avoid storing keys in the config file.
+	APIKey string `json:"api_key,omitempty"`
+
+	// APIKeyEnv is the name of an environment variable containing the API key.
+	APIKeyEnv string `json:"api_key_env,omitempty"`
+
+	// APIKeyFile is the path to a file containing the API key. The file is
+	// watched for changes, so the key can be rotated without a restart.
+	APIKeyFile string `json:"api_key_file,omitempty"`
+
+	// WebhookSigningKey is the workspace's webhook signing key.
+	WebhookSigningKey string `json:"webhook_signing_key,omitempty"`
+
+	// WebhookSigningKeyEnv is the name of an environment variable containing
+	// the webhook signing key. It takes precedence over WebhookSigningKey, which
+	// is used instead if the variable is empty. NewClientPool returns an error if
+	// the variable is empty and there's no WebhookSigningKey to fall back on.
+	WebhookSigningKeyEnv string `json:"webhook_signing_key_env,omitempty"`
+}
+
+// PoolConfig configures a ClientPool.
+type PoolConfig struct {
+	// Workspaces maps each workspace name to its configuration.
+	Workspaces map[string]WorkspaceConfig `json:"workspaces"`
+
+	// RequestsPerSecond optionally limits the combined rate of requests made by
+	// all of the pool's clients. Zero means no limit.
+	RequestsPerSecond float64 `json:"requests_per_second,omitempty"`
+
+	// Burst is the maximum number of requests that may be made at once when
+	// RequestsPerSecond is set. Defaults to 1.
+	Burst int `json:"burst,omitempty"`
+}
+
+// LoadPoolConfig reads a JSON-encoded PoolConfig from the given file.
+func LoadPoolConfig(path string) (*PoolConfig, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg PoolConfig
+	if err := json.Unmarshal(b, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// ClientPool holds a Client per workspace, for when you run the agent for
+// several brands. Its clients share one HTTP transport and request rate budget.
+type ClientPool struct {
+	clients map[string]*Client
+}
+
+// NewClientPool creates a Client for each workspace in the config. The given
+// options are applied to every client before the workspace-specific ones.
+//
+// Unless a transport is given with WithTransport, the clients share a single
+// clone of http.DefaultTransport so connections are pooled across workspaces.
+// The rate limit is applied to each HTTP request, including retries.
+func NewClientPool(cfg *PoolConfig, opts ...Option) (*ClientPool, error) {
+	var transport http.RoundTripper
+	for _, opt := range opts {
+		if t, ok := opt.(transportOption); ok {
+			transport = t.transport
+		}
+	}
+	if transport == nil {
+		transport = http.DefaultTransport
+		if t, ok := http.DefaultTransport.(*http.Transport); ok {
+			transport = t.Clone()
+		}
+	}
+	if cfg.RequestsPerSecond > 0 {
+		transport = &rateLimitedTransport{
+			limiter: newRateLimiter(cfg.RequestsPerSecond, cfg.Burst),
+			next:    transport,
+		}
+	}
+	shared := append(append([]Option{}, opts...), WithTransport(transport))
+
+	pool := &ClientPool{clients: make(map[string]*Client, len(cfg.Workspaces))}
+	for name, ws := range cfg.Workspaces {
+		wsOpts := append([]Option{}, shared...)
+
+		if ws.URL != "" {
+			wsOpts = append(wsOpts, WithURL(ws.URL))
+		}
+
+		var creds ChainCredentials
+		if ws.APIKey != "" {
+			creds = append(creds, StaticCredentials(ws.APIKey))
+		}
+		if ws.APIKeyEnv != "" {
+			creds = append(creds, EnvCredentials(ws.APIKeyEnv))
+		}
+		if ws.APIKeyFile != "" {
+			creds = append(creds, NewFileCredentials(ws.APIKeyFile, 10*time.Second))
+		}
+		if len(creds) == 0 {
+			return nil, fmt.Errorf("workspace %q has no API key configured", name)
+		}
+		wsOpts = append(wsOpts, WithCredentials(creds))
+
+		signingKey := ws.WebhookSigningKey
+		if ws.WebhookSigningKeyEnv != "" {
+			if key := os.Getenv(ws.WebhookSigningKeyEnv); key != "" {
+				signingKey = key
+			} else if signingKey == "" {
+				return nil, fmt.Errorf("workspace %q: environment variable %s (webhook signing key) is empty", name, ws.WebhookSigningKeyEnv)
+			}
+		}
+		if signingKey != "" {
+			wsOpts = append(wsOpts, WithWebhookSigningKey(signingKey))
+		}
+
+		c, err := NewClient(wsOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("workspace %q: %w", name, err)
+		}
+		pool.clients[name] = c
+	}
+	return pool, nil
+}
+
+// Client returns the client for the given workspace.
+func (p *ClientPool) Client(workspace string) (*Client, error) {
+	c, ok := p.clients[workspace]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownWorkspace, workspace)
+	}
+	return c, nil
+}
+
+// Workspaces returns the names of the pool's workspaces in sorted order.
+func (p *ClientPool) Workspaces() []string {
+	names := make([]string, 0, len(p.clients))
+	for name := range p.clients {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// WorkspaceResolver determines which workspace a webhook request was sent for.
+type WorkspaceResolver func(req *http.Request) (string, error)
+
+// WorkspaceFromHeader resolves the workspace from the named request header.
+func WorkspaceFromHeader(name string) WorkspaceResolver {
+	return func(req *http.Request) (string, error) {
+		ws := req.Header.Get(name)
+		if ws == "" {
+			return "", fmt.Errorf("%w: %s header is empty", ErrUnknownWorkspace, name)
+		}
+		return ws, nil
+	}
+}
+
+// WorkspaceFromPath resolves the workspace from a segment of the request path.
+// Segments are counted from zero, and negative indexes count back from the end
+// (e.g. -1 is the last segment of "/webhooks/brand-a").
+func WorkspaceFromPath(index int) WorkspaceResolver {
+	return func(req *http.Request) (string, error) {
+		segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+		i := index
+		if i < 0 {
+			i += len(segments)
+		}
+		if i < 0 || i >= len(segments) || segments[i] == "" {
+			return "", fmt.Errorf("%w: no segment %d in path %q", ErrUnknownWorkspace, index, req.URL.Path)
+		}
+		return segments[i], nil
+	}
+}
+
+// ParseWebhook resolves the workspace the request was sent for, and parses and
+// verifies it using that workspace's webhook signing key. Webhooks for
+// workspaces without a signing key are rejected with
+// ErrInvalidWebhookSignature.
+func (p *ClientPool) ParseWebhook(req *http.Request, resolve WorkspaceResolver) (workspace string, webhook *Webhook, token string, err error) {
+	workspace, err = resolve(req)
+	if err != nil {
+		return "", nil, "", err
+	}
+
+	c, err := p.Client(workspace)
+	if err != nil {
+		return "", nil, "", err
+	}
+
+	if len(c.webhookVerifier.secret) == 0 {
+		// Without a key, anyone could sign a webhook for the workspace.
+		return "", nil, "", fmt.Errorf("%w: workspace %q has no webhook signing key", ErrInvalidWebhookSignature, workspace)
+	}
+
+	webhook, token, err = c.ParseWebhook(req)
+	if err != nil {
+		return "", nil, "", err
+	}
+	return workspace, webhook, token, nil
+}
+
+// PoolWebhookFunc handles a verified webhook for the given workspace.
+type PoolWebhookFunc func(w http.ResponseWriter, req *http.Request, workspace string, webhook *Webhook, token string)
+
+// WebhookHandler returns an http.Handler that serves webhooks for all of the
+// pool's workspaces from a single endpoint. Requests for unknown workspaces get
+// a 404 response, and requests with invalid signatures (or for workspaces
+// without a webhook signing key) get a 401.
+func (p *ClientPool) WebhookHandler(resolve WorkspaceResolver, fn PoolWebhookFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		workspace, webhook, token, err := p.ParseWebhook(req, resolve)
+		switch {
+		case errors.Is(err, ErrUnknownWorkspace):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case errors.Is(err, ErrInvalidWebhookSignature):
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			fn(w, req, workspace, webhook, token)
+		}
+	})
+}
+
+// rateLimiter is a token bucket shared by the clients in a ClientPool.
+type rateLimiter struct {
+	mu       sync.Mutex
+	rate     float64
+	burst    float64
+	tokens   float64
+	lastFill time.Time
+}
+
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{
+		rate:     rate,
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		lastFill: time.Now(),
+	}
+}
+
+// wait blocks until a token is available or the context is done.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		l.tokens += now.Sub(l.lastFill).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+		l.lastFill = now
+
+		if l.tokens >= 1 {
+			l.tokens--
+			l.mu.Unlock()
+			return nil
+		}
+		delay := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+		l.mu.Unlock()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// rateLimitedTransport waits for the rate limiter before each HTTP request.
+type rateLimitedTransport struct {
+	limiter *rateLimiter
+	next    http.RoundTripper
+}
+
+// RoundTrip satisfies the http.RoundTripper interface.
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.wait(req.Context()); err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, err
+	}
+	return t.next.RoundTrip(req)
+}
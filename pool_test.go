@@ -0,0 +1,297 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewClientPoolRequiresAPIKey(t *testing.T) {
+	_, err := NewClientPool(&PoolConfig{
+		Workspaces: map[string]WorkspaceConfig{"brand-a": {}},
+	})
+	if err == nil {
+		t.Fatal("expected an error for a workspace without an API key")
+	}
+}
+
+func TestNewClientPoolWebhookSigningKeyEnv(t *testing.T) {
+	testCases := map[string]struct {
+		literal, env string
+		wantKey      string
+		wantErr      bool
+	}{
+		"env": {
+			env:     "from-env",
+			wantKey: "from-env",
+		},
+		"env takes precedence": {
+			literal: "literal",
+			env:     "from-env",
+			wantKey: "from-env",
+		},
+		"empty env falls back to literal": {
+			literal: "literal",
+			wantKey: "literal",
+		},
+		"empty env without literal": {
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("GLABS_TEST_SIGNING_KEY", tc.env)
+
+			pool, err := NewClientPool(&PoolConfig{
+				Workspaces: map[string]WorkspaceConfig{
+					"brand-a": {
+						APIKey:               "key",
+						WebhookSigningKey:    tc.literal,
+						WebhookSigningKeyEnv: "GLABS_TEST_SIGNING_KEY",
+					},
+				},
+			})
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewClientPool: %v", err)
+			}
+
+			c, _ := pool.Client("brand-a")
+			if got := string(c.webhookVerifier.secret); got != tc.wantKey {
+				t.Errorf("signing key = %q, want %q", got, tc.wantKey)
+			}
+		})
+	}
+}
+
+func TestClientPoolCredentials(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "key")
+	if err := os.WriteFile(keyFile, []byte("from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GLABS_TEST_API_KEY", "from-env")
+
+	auth := make(chan string, 3)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth <- r.Header.Get("Authorization")
+		writeJSON(w, http.StatusOK, `{"id":"conv-1"}`)
+	}))
+	defer srv.Close()
+
+	pool, err := NewClientPool(&PoolConfig{
+		Workspaces: map[string]WorkspaceConfig{
+			"literal": {URL: srv.URL, APIKey: "literal"},
+			"env":     {URL: srv.URL, APIKeyEnv: "GLABS_TEST_API_KEY"},
+			"file":    {URL: srv.URL, APIKeyFile: keyFile},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewClientPool: %v", err)
+	}
+
+	if got := strings.Join(pool.Workspaces(), ","); got != "env,file,literal" {
+		t.Errorf("Workspaces = %s, want env,file,literal", got)
+	}
+
+	for ws, want := range map[string]string{"literal": "literal", "env": "from-env", "file": "from-file"} {
+		c, err := pool.Client(ws)
+		if err != nil {
+			t.Fatalf("Client(%q): %v", ws, err)
+		}
+		if _, err := c.ReadConversation(context.Background(), "conv-1", &ReadParams{}); err != nil {
+			t.Fatalf("%s: ReadConversation: %v", ws, err)
+		}
+		if got := <-auth; got != "Bearer "+want {
+			t.Errorf("%s: Authorization = %q, want Bearer %s", ws, got, want)
+		}
+	}
+
+	if _, err := pool.Client("missing"); !errors.Is(err, ErrUnknownWorkspace) {
+		t.Errorf("Client(missing) error = %v, want ErrUnknownWorkspace", err)
+	}
+}
+
+func TestClientPoolWebhookHandler(t *testing.T) {
+	pool, err := NewClientPool(&PoolConfig{
+		Workspaces: map[string]WorkspaceConfig{
+			"brand-a": {APIKey: "key-a", WebhookSigningKey: "secret-a"},
+			"brand-b": {APIKey: "key-b", WebhookSigningKey: "secret-b"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewClientPool: %v", err)
+	}
+
+	var gotWorkspace string
+	handler := pool.WebhookHandler(WorkspaceFromPath(-1), func(w http.ResponseWriter, _ *http.Request, workspace string, webhook *Webhook, _ string) {
+		gotWorkspace = workspace
+		w.WriteHeader(http.StatusOK)
+	})
+
+	testCases := map[string]struct {
+		target, key string
+		wantStatus  int
+		wantWS      string
+	}{
+		"valid":          {target: "/webhooks/brand-b", key: "secret-b", wantStatus: http.StatusOK, wantWS: "brand-b"},
+		"other key":      {target: "/webhooks/brand-b", key: "secret-a", wantStatus: http.StatusUnauthorized},
+		"unknown":        {target: "/webhooks/brand-c", key: "secret-a", wantStatus: http.StatusNotFound},
+		"missing prefix": {target: "/", key: "secret-a", wantStatus: http.StatusNotFound},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			gotWorkspace = ""
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, signedWebhookRequest(t, tc.target, tc.key, testAgentMessageWebhook))
+
+			if rec.Code != tc.wantStatus {
+				t.Errorf("status = %d, want %d (%s)", rec.Code, tc.wantStatus, rec.Body)
+			}
+			if gotWorkspace != tc.wantWS {
+				t.Errorf("workspace = %q, want %q", gotWorkspace, tc.wantWS)
+			}
+		})
+	}
+}
+
+func TestWorkspaceFromHeader(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	if _, err := WorkspaceFromHeader("X-Workspace")(req); !errors.Is(err, ErrUnknownWorkspace) {
+		t.Errorf("error = %v, want ErrUnknownWorkspace", err)
+	}
+
+	req.Header.Set("X-Workspace", "brand-a")
+	if ws, err := WorkspaceFromHeader("X-Workspace")(req); err != nil || ws != "brand-a" {
+		t.Errorf("workspace = %q, %v, want brand-a", ws, err)
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	l := newRateLimiter(1000, 2)
+	ctx := context.Background()
+
+	started := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := l.wait(ctx); err != nil {
+			t.Fatalf("wait: %v", err)
+		}
+	}
+	// The burst of 2 is immediate, and the other 2 need a millisecond each.
+	if elapsed := time.Since(started); elapsed < time.Millisecond {
+		t.Errorf("4 requests took %v, expected them to be throttled", elapsed)
+	}
+
+	slow := newRateLimiter(0.001, 1)
+	_ = slow.wait(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := slow.wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("wait error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (fn roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) { return fn(req) }
+
+func TestNewClientPoolTransport(t *testing.T) {
+	// An instrumented default transport mustn't make NewClientPool panic.
+	defaultTransport := http.DefaultTransport
+	http.DefaultTransport = roundTripperFunc(defaultTransport.RoundTrip)
+	t.Cleanup(func() { http.DefaultTransport = defaultTransport })
+
+	custom := roundTripperFunc(defaultTransport.RoundTrip)
+	testCases := map[string]struct {
+		opts    []Option
+		rps     float64
+		wantTyp string
+	}{
+		"default":           {wantTyp: "client.roundTripperFunc"},
+		"custom":            {opts: []Option{WithTransport(custom)}, wantTyp: "client.roundTripperFunc"},
+		"custom rate limit": {opts: []Option{WithTransport(custom)}, rps: 10, wantTyp: "*client.rateLimitedTransport"},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			pool, err := NewClientPool(&PoolConfig{
+				Workspaces:        map[string]WorkspaceConfig{"a": {APIKey: "key-a"}, "b": {APIKey: "key-b"}},
+				RequestsPerSecond: tc.rps,
+			}, tc.opts...)
+			if err != nil {
+				t.Fatalf("NewClientPool: %v", err)
+			}
+
+			a, _ := pool.Client("a")
+			b, _ := pool.Client("b")
+			if got := fmt.Sprintf("%T", a.httpClient.Transport); got != tc.wantTyp {
+				t.Errorf("transport = %s, want %s", got, tc.wantTyp)
+			}
+			if fmt.Sprintf("%p", a.httpClient.Transport) != fmt.Sprintf("%p", b.httpClient.Transport) {
+				t.Error("expected the workspaces to share a transport")
+			}
+		})
+	}
+}
+
+func TestRateLimitedTransport(t *testing.T) {
+	var requests int
+	rt := &rateLimitedTransport{
+		limiter: newRateLimiter(0.001, 1),
+		next: roundTripperFunc(func(*http.Request) (*http.Response, error) {
+			requests++
+			return &http.Response{StatusCode: http.StatusServiceUnavailable, Body: http.NoBody}, nil
+		}),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+
+	if _, err := rt.RoundTrip(req); err != nil {
+		t.Fatalf("first attempt: %v", err)
+	}
+	// A retry of the same call is charged again.
+	if _, err := rt.RoundTrip(req); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("second attempt error = %v, want context.DeadlineExceeded", err)
+	}
+	if requests != 1 {
+		t.Errorf("requests = %d, want 1", requests)
+	}
+}
+
+func TestClientPoolWebhookWithoutSigningKey(t *testing.T) {
+	pool, err := NewClientPool(&PoolConfig{
+		Workspaces: map[string]WorkspaceConfig{"brand-a": {APIKey: "key-a"}},
+	})
+	if err != nil {
+		t.Fatalf("NewClientPool: %v", err)
+	}
+
+	var called bool
+	handler := pool.WebhookHandler(WorkspaceFromPath(-1), func(http.ResponseWriter, *http.Request, string, *Webhook, string) {
+		called = true
+	})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, signedWebhookRequest(t, "/webhooks/brand-a", "", testAgentMessageWebhook))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+	if called {
+		t.Error("webhook was handled despite the workspace having no signing key")
+	}
+}
@@ -0,0 +1,418 @@
+// Package presence forwards typing indicators and read receipts from a chat
+// transport to the Gradient Labs API, so the agent knows when the customer is
+// typing or has seen its messages without every integration having to manage
+// the event stream itself.
+package presence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	client "github.com/gradientlabs-ai/gradientlabs-go"
+)
+
+const (
+	defaultTypingDebounce = 500 * time.Millisecond
+	defaultTypingInterval = 5 * time.Second
+	defaultReceiptDelay   = time.Second
+	defaultMaxAttempts    = 3
+
+	// closeRetryDelay is the delay before retrying receipts that failed to
+	// send when closing, which doubles after each attempt.
+	closeRetryDelay = 100 * time.Millisecond
+)
+
+// EventSender is the subset of client.ConversationAPI used to send events.
+type EventSender interface {
+	AddConversationEvent(ctx context.Context, conversationID string, p *client.EventParams, opts ...client.CallOption) error
+}
+
+// Event is a typing indicator or receipt reported by a chat transport.
+type Event struct {
+	// Type is one of client.ConversationEventTypeTyping,
+	// client.ConversationEventTypeMessageDelivered or
+	// client.ConversationEventTypeMessageRead.
+	Type client.ConversationEventType
+
+	// ParticipantID identifies who is typing or received the message.
+	ParticipantID string
+
+	// ParticipantType identifies the type of participant.
+	ParticipantType client.ParticipantType
+
+	// MessageID identifies the message that was delivered or read. It is
+	// ignored for typing indicators.
+	MessageID string
+
+	// Timestamp is when the event occurred. Defaults to when it was handled.
+	Timestamp time.Time
+}
+
+// Config customises a Session.
+type Config struct {
+	// TypingDebounce is how long to wait after the first typing indicator
+	// before sending it, so a single keypress doesn't produce an event.
+	// Defaults to 500ms.
+	TypingDebounce time.Duration
+
+	// TypingInterval is the minimum time between typing indicators sent for
+	// the same participant. Defaults to 5 seconds.
+	TypingInterval time.Duration
+
+	// ReceiptDelay is how long receipts are collected for before being sent.
+	// Duplicate receipts for the same message within a batch are only sent
+	// once. Defaults to 1 second.
+	ReceiptDelay time.Duration
+
+	// MaxAttempts is the number of times a receipt is sent before it is given
+	// up on. Typing indicators are never retried, as they are stale by the
+	// time a retry would happen. Defaults to 3.
+	MaxAttempts int
+
+	// OnError is called when an event fails to send.
+	OnError func(e Event, err error)
+}
+
+// Session forwards the events for a single conversation. Use NewSession to
+// create one, and run it in the background with:
+//
+//	go session.Run(ctx)
+type Session struct {
+	sender         EventSender
+	conversationID string
+	cfg            Config
+
+	events  chan Event
+	done    chan struct{}
+	stopped chan struct{}
+
+	mu       sync.Mutex
+	running  bool
+	closed   bool
+	closeCtx context.Context
+}
+
+// NewSession creates a Session that sends events for the given conversation.
+func NewSession(sender EventSender, conversationID string, cfg Config) *Session {
+	if cfg.TypingDebounce <= 0 {
+		cfg.TypingDebounce = defaultTypingDebounce
+	}
+	if cfg.TypingInterval <= 0 {
+		cfg.TypingInterval = defaultTypingInterval
+	}
+	if cfg.ReceiptDelay <= 0 {
+		cfg.ReceiptDelay = defaultReceiptDelay
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = defaultMaxAttempts
+	}
+	return &Session{
+		sender:         sender,
+		conversationID: conversationID,
+		cfg:            cfg,
+		events:         make(chan Event, 64),
+		done:           make(chan struct{}),
+		stopped:        make(chan struct{}),
+	}
+}
+
+// Handle queues an event to be sent. It returns false if the session has been
+// closed.
+func (s *Session) Handle(e Event) bool {
+	if e.Timestamp.IsZero() {
+		e.Timestamp = time.Now()
+	}
+	select {
+	case <-s.done:
+		return false
+	default:
+	}
+	select {
+	case s.events <- e:
+		return true
+	case <-s.done:
+		return false
+	}
+}
+
+// Typing reports that the participant is typing.
+func (s *Session) Typing(participantID string, participantType client.ParticipantType) bool {
+	return s.Handle(Event{
+		Type:            client.ConversationEventTypeTyping,
+		ParticipantID:   participantID,
+		ParticipantType: participantType,
+	})
+}
+
+// Delivered reports that the message was delivered to the participant.
+func (s *Session) Delivered(participantID string, participantType client.ParticipantType, messageID string) bool {
+	return s.Handle(Event{
+		Type:            client.ConversationEventTypeMessageDelivered,
+		ParticipantID:   participantID,
+		ParticipantType: participantType,
+		MessageID:       messageID,
+	})
+}
+
+// Read reports that the message was read by the participant.
+func (s *Session) Read(participantID string, participantType client.ParticipantType, messageID string) bool {
+	return s.Handle(Event{
+		Type:            client.ConversationEventTypeMessageRead,
+		ParticipantID:   participantID,
+		ParticipantType: participantType,
+		MessageID:       messageID,
+	})
+}
+
+// Close stops the session, sending any receipts that are still waiting
+// (including those queued but not yet handled), and waits for Run to return.
+// If Run has not started, Close sends the queued receipts itself and a later
+// call to Run returns immediately.
+//
+// The receipts are sent using the context given to the first call to Close.
+// If it is done before they have all been sent, the rest are dropped and Close
+// returns the context's error.
+func (s *Session) Close(ctx context.Context) error {
+	s.mu.Lock()
+	first := !s.closed
+	running := s.running
+	if first {
+		s.closed = true
+		s.closeCtx = ctx
+		close(s.done)
+	}
+	s.mu.Unlock()
+
+	if first && !running {
+		s.flushQueue(ctx, make(map[receiptKey]*receipt))
+		close(s.stopped)
+	}
+
+	select {
+	case <-s.stopped:
+		return ctx.Err()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// typingState tracks the typing indicators for a single participant.
+type typingState struct {
+	event    Event
+	pending  bool
+	due      time.Time
+	lastSent time.Time
+}
+
+// receipt is a receipt waiting to be sent.
+type receipt struct {
+	event    Event
+	attempts int
+}
+
+type receiptKey struct {
+	typ           client.ConversationEventType
+	participantID string
+	messageID     string
+}
+
+// Run sends events until the context is cancelled or Close is called. When
+// closed, any receipts that are still waiting are sent (with up to MaxAttempts
+// attempts each, using the context given to Close) before it returns; when the
+// context is cancelled, they are dropped.
+func (s *Session) Run(ctx context.Context) error {
+	s.mu.Lock()
+	switch {
+	case s.closed:
+		s.mu.Unlock()
+		return nil
+	case s.running:
+		s.mu.Unlock()
+		return errors.New("presence: session is already running")
+	}
+	s.running = true
+	s.mu.Unlock()
+	defer close(s.stopped)
+
+	var (
+		typing      = make(map[string]*typingState)
+		receipts    = make(map[receiptKey]*receipt)
+		receiptsDue time.Time
+	)
+
+	timer := time.NewTimer(time.Hour)
+	defer timer.Stop()
+
+	for {
+		next := receiptsDue
+		for _, t := range typing {
+			if t.pending && (next.IsZero() || t.due.Before(next)) {
+				next = t.due
+			}
+		}
+
+		var wake <-chan time.Time
+		if !next.IsZero() {
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+			timer.Reset(time.Until(next))
+			wake = timer.C
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case <-s.done:
+			s.mu.Lock()
+			closeCtx := s.closeCtx
+			s.mu.Unlock()
+
+			s.flushQueue(closeCtx, receipts)
+			return nil
+
+		case e := <-s.events:
+			now := time.Now()
+			switch e.Type {
+			case client.ConversationEventTypeTyping:
+				t, ok := typing[e.ParticipantID]
+				if !ok {
+					t = &typingState{}
+					typing[e.ParticipantID] = t
+				}
+				t.event = e
+				if !t.pending {
+					t.pending = true
+					t.due = now.Add(s.cfg.TypingDebounce)
+					if throttled := t.lastSent.Add(s.cfg.TypingInterval); throttled.After(t.due) {
+						t.due = throttled
+					}
+				}
+			default:
+				addReceipt(receipts, e)
+				if receiptsDue.IsZero() {
+					receiptsDue = now.Add(s.cfg.ReceiptDelay)
+				}
+			}
+
+		case <-wake:
+			now := time.Now()
+			for _, t := range typing {
+				if !t.pending || t.due.After(now) {
+					continue
+				}
+				t.pending = false
+				t.lastSent = now
+				if err := s.send(ctx, t.event); err != nil {
+					s.reportError(t.event, err)
+				}
+			}
+
+			if !receiptsDue.IsZero() && !receiptsDue.After(now) {
+				s.sendReceipts(ctx, receipts)
+				receiptsDue = time.Time{}
+				if len(receipts) != 0 {
+					receiptsDue = now.Add(s.cfg.ReceiptDelay)
+				}
+			}
+		}
+	}
+}
+
+// flushQueue adds the receipts still in the queue to the batch and sends them
+// all, retrying each up to MaxAttempts times with exponential backoff. Typing indicators still in the queue are stale, so they are
+// dropped.
+func (s *Session) flushQueue(ctx context.Context, receipts map[receiptKey]*receipt) {
+drain:
+	for {
+		select {
+		case e := <-s.events:
+			if e.Type != client.ConversationEventTypeTyping {
+				addReceipt(receipts, e)
+			}
+		default:
+			break drain
+		}
+	}
+
+	backoff := closeRetryDelay
+	for {
+		s.sendReceipts(ctx, receipts)
+		if len(receipts) == 0 {
+			return
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+// addReceipt adds the receipt to the batch, unless it is a duplicate.
+func addReceipt(receipts map[receiptKey]*receipt, e Event) {
+	key := receiptKey{e.Type, e.ParticipantID, e.MessageID}
+	if _, ok := receipts[key]; !ok {
+		receipts[key] = &receipt{event: e}
+	}
+}
+
+// sendReceipts sends the batch of receipts, keeping any that failed and can
+// be retried.
+func (s *Session) sendReceipts(ctx context.Context, receipts map[receiptKey]*receipt) {
+	for key, r := range receipts {
+		r.attempts++
+		err := s.send(ctx, r.event)
+		if err == nil {
+			delete(receipts, key)
+			continue
+		}
+
+		s.reportError(r.event, err)
+		if r.attempts >= s.cfg.MaxAttempts {
+			delete(receipts, key)
+		}
+	}
+}
+
+func (s *Session) send(ctx context.Context, e Event) error {
+	ts := e.Timestamp
+	p := &client.EventParams{
+		Type:            e.Type,
+		ParticipantID:   e.ParticipantID,
+		ParticipantType: e.ParticipantType,
+		Timestamp:       &ts,
+		IdempotencyKey:  s.idempotencyKey(e),
+	}
+	if e.Type != client.ConversationEventTypeTyping && e.MessageID != "" {
+		id := e.MessageID
+		p.MessageID = &id
+	}
+	return s.sender.AddConversationEvent(ctx, s.conversationID, p)
+}
+
+// idempotencyKey identifies the event so retried receipts are only recorded
+// once. Each typing indicator is identified by its timestamp.
+func (s *Session) idempotencyKey(e Event) string {
+	if e.Type == client.ConversationEventTypeTyping {
+		return fmt.Sprintf("%s:%s:%s:%d", s.conversationID, e.Type, e.ParticipantID, e.Timestamp.UnixNano())
+	}
+	return fmt.Sprintf("%s:%s:%s:%s", s.conversationID, e.Type, e.ParticipantID, e.MessageID)
+}
+
+func (s *Session) reportError(e Event, err error) {
+	if s.cfg.OnError != nil {
+		s.cfg.OnError(e, err)
+	}
+}
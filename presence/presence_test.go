@@ -0,0 +1,233 @@
+package presence_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	client "github.com/gradientlabs-ai/gradientlabs-go"
+	"github.com/gradientlabs-ai/gradientlabs-go/presence"
+)
+
+// fakeSender records the events it is sent, failing with err if set.
+type fakeSender struct {
+	mu     sync.Mutex
+	err    error
+	events []*client.EventParams
+}
+
+func (f *fakeSender) AddConversationEvent(_ context.Context, _ string, p *client.EventParams, _ ...client.CallOption) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.events = append(f.events, p)
+	return f.err
+}
+
+func (f *fakeSender) sent() []*client.EventParams {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*client.EventParams(nil), f.events...)
+}
+
+func start(t *testing.T, s *presence.Session) <-chan error {
+	t.Helper()
+	errs := make(chan error, 1)
+	go func() { errs <- s.Run(context.Background()) }()
+	return errs
+}
+
+func TestReceiptsSentOnClose(t *testing.T) {
+	sender := &fakeSender{}
+	s := presence.NewSession(sender, "conv-1", presence.Config{ReceiptDelay: time.Hour})
+	errs := start(t, s)
+
+	s.Delivered("cust-1", client.ParticipantTypeCustomer, "msg-1")
+	s.Delivered("cust-1", client.ParticipantTypeCustomer, "msg-1")
+	s.Read("cust-1", client.ParticipantTypeCustomer, "msg-1")
+	s.Close(context.Background())
+
+	if err := <-errs; err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	sent := sender.sent()
+	if len(sent) != 2 {
+		t.Fatalf("sent %d events, want 2", len(sent))
+	}
+	for _, p := range sent {
+		if p.MessageID == nil || *p.MessageID != "msg-1" {
+			t.Errorf("MessageID = %v", p.MessageID)
+		}
+		if p.IdempotencyKey == "" {
+			t.Error("IdempotencyKey is empty")
+		}
+	}
+
+	if s.Typing("cust-1", client.ParticipantTypeCustomer) {
+		t.Error("Handle accepted an event after Close")
+	}
+}
+
+func TestReceiptsRetried(t *testing.T) {
+	sender := &fakeSender{err: errors.New("unavailable")}
+	var failures int
+	s := presence.NewSession(sender, "conv-1", presence.Config{
+		ReceiptDelay: time.Hour,
+		MaxAttempts:  2,
+		OnError:      func(presence.Event, error) { failures++ },
+	})
+	errs := start(t, s)
+
+	s.Read("cust-1", client.ParticipantTypeCustomer, "msg-1")
+	started := time.Now()
+	s.Close(context.Background())
+	<-errs
+
+	if elapsed := time.Since(started); elapsed < 50*time.Millisecond {
+		t.Errorf("retried after %v, expected a backoff between attempts", elapsed)
+	}
+	if got := len(sender.sent()); got != 2 {
+		t.Errorf("sent %d times, want 2", got)
+	}
+	if failures != 2 {
+		t.Errorf("OnError called %d times, want 2", failures)
+	}
+	if p := sender.sent(); p[0].IdempotencyKey != p[1].IdempotencyKey {
+		t.Error("retries used different idempotency keys")
+	}
+}
+
+func TestReceiptBatching(t *testing.T) {
+	sender := &fakeSender{}
+	s := presence.NewSession(sender, "conv-1", presence.Config{ReceiptDelay: 10 * time.Millisecond})
+	start(t, s)
+	defer s.Close(context.Background())
+
+	s.Read("cust-1", client.ParticipantTypeCustomer, "msg-1")
+
+	deadline := time.Now().Add(time.Second)
+	for len(sender.sent()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if got := sender.sent(); len(got) != 1 || got[0].Type != client.ConversationEventTypeMessageRead {
+		t.Errorf("sent %+v", got)
+	}
+}
+
+func TestTypingDebounced(t *testing.T) {
+	sender := &fakeSender{}
+	s := presence.NewSession(sender, "conv-1", presence.Config{
+		TypingDebounce: 20 * time.Millisecond,
+		TypingInterval: time.Hour,
+	})
+	start(t, s)
+
+	for i := 0; i < 5; i++ {
+		s.Typing("cust-1", client.ParticipantTypeCustomer)
+	}
+	time.Sleep(100 * time.Millisecond)
+	s.Typing("cust-1", client.ParticipantTypeCustomer)
+	s.Close(context.Background())
+
+	sent := sender.sent()
+	if len(sent) != 1 {
+		t.Fatalf("sent %d typing indicators, want 1", len(sent))
+	}
+	if sent[0].Type != client.ConversationEventTypeTyping || sent[0].MessageID != nil {
+		t.Errorf("sent %+v", sent[0])
+	}
+}
+
+func TestCloseBeforeRun(t *testing.T) {
+	s := presence.NewSession(&fakeSender{}, "conv-1", presence.Config{})
+
+	closed := make(chan struct{})
+	go func() {
+		s.Close(context.Background())
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("Close blocked without Run")
+	}
+
+	if err := s.Run(context.Background()); err != nil {
+		t.Errorf("Run after Close: %v", err)
+	}
+	s.Close(context.Background())
+}
+
+func TestRunTwice(t *testing.T) {
+	sender := &fakeSender{}
+	s := presence.NewSession(sender, "conv-1", presence.Config{ReceiptDelay: time.Millisecond})
+	errs := start(t, s)
+	defer func() {
+		s.Close(context.Background())
+		<-errs
+	}()
+
+	// Wait for the first Run to send a receipt, so it is known to be running.
+	s.Read("cust-1", client.ParticipantTypeCustomer, "msg-1")
+	deadline := time.Now().Add(time.Second)
+	for len(sender.sent()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := s.Run(context.Background()); err == nil {
+		t.Error("second Run did not fail")
+	}
+}
+
+func TestContextCancelled(t *testing.T) {
+	sender := &fakeSender{}
+	s := presence.NewSession(sender, "conv-1", presence.Config{ReceiptDelay: time.Hour})
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() { errs <- s.Run(ctx) }()
+
+	s.Read("cust-1", client.ParticipantTypeCustomer, "msg-1")
+	cancel()
+	if err := <-errs; !errors.Is(err, context.Canceled) {
+		t.Errorf("Run = %v", err)
+	}
+	s.Close(context.Background())
+	if got := len(sender.sent()); got != 0 {
+		t.Errorf("sent %d receipts after cancellation", got)
+	}
+}
+
+// hungSender blocks until the context is done.
+type hungSender struct{}
+
+func (hungSender) AddConversationEvent(ctx context.Context, _ string, _ *client.EventParams, _ ...client.CallOption) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestCloseDeadline(t *testing.T) {
+	for name, run := range map[string]bool{"running": true, "not running": false} {
+		t.Run(name, func(t *testing.T) {
+			s := presence.NewSession(hungSender{}, "conv-1", presence.Config{ReceiptDelay: time.Hour})
+			if run {
+				start(t, s)
+			}
+			s.Read("cust-1", client.ParticipantTypeCustomer, "msg-1")
+
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer cancel()
+
+			closed := make(chan error, 1)
+			go func() { closed <- s.Close(ctx) }()
+			select {
+			case err := <-closed:
+				if !errors.Is(err, context.DeadlineExceeded) {
+					t.Errorf("Close = %v, want context.DeadlineExceeded", err)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("Close blocked past its deadline")
+			}
+		})
+	}
+}
@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 )
@@ -26,7 +25,7 @@ type ProcedureListResponse struct {
 // ListProcedures lists procedures.
 //
 // Note: requires a `Management` API key.
-func (c *Client) ListProcedures(ctx context.Context, p *ProcedureListParams) (*ProcedureListResponse, error) {
+func (c *Client) ListProcedures(ctx context.Context, p *ProcedureListParams, opts ...CallOption) (*ProcedureListResponse, error) {
 	path := "procedures"
 	if p.Cursor != "" {
 		path = fmt.Sprintf("%v?cursor=%v", path, p.Cursor)
@@ -35,18 +34,8 @@ func (c *Client) ListProcedures(ctx context.Context, p *ProcedureListParams) (*P
 		path = fmt.Sprintf("%v?status=%v", path, p.Status)
 	}
 
-	rsp, err := c.makeRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return nil, err
-	}
-
 	var procs ProcedureListResponse
-	if err := json.NewDecoder(rsp.Body).Decode(&procs); err != nil {
+	if err := c.do(ctx, "ListProcedures", http.MethodGet, path, nil, &procs, opts...); err != nil {
 		return nil, err
 	}
 	return &procs, nil
@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 )
@@ -10,19 +9,9 @@ import (
 // ReadProcedure returns a procedure.
 //
 // Note: requires a `Management` API key.
-func (c *Client) ReadProcedure(ctx context.Context, procedureID string) (*Procedure, error) {
-	rsp, err := c.makeRequest(ctx, http.MethodGet, fmt.Sprintf("procedure/%s", procedureID), nil)
-	if err != nil {
-		return nil, err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return nil, err
-	}
-
+func (c *Client) ReadProcedure(ctx context.Context, procedureID string, opts ...CallOption) (*Procedure, error) {
 	var proc Procedure
-	if err := json.NewDecoder(rsp.Body).Decode(&proc); err != nil {
+	if err := c.do(ctx, "ReadProcedure", http.MethodGet, fmt.Sprintf("procedure/%s", procedureID), nil, &proc, opts...); err != nil {
 		return nil, err
 	}
 	return &proc, nil
@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 )
@@ -19,19 +18,9 @@ type ProcedureLimitParams struct {
 // SetProcedureLimit updates the daily usage limit of a procedure.
 //
 // Note: requires a `Management` API key.
-func (c *Client) SetProcedureLimit(ctx context.Context, procedureID string, p *ProcedureLimitParams) (*Procedure, error) {
-	rsp, err := c.makeRequest(ctx, http.MethodPost, fmt.Sprintf("procedure/%s/limit", procedureID), p)
-	if err != nil {
-		return nil, err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return nil, err
-	}
-
+func (c *Client) SetProcedureLimit(ctx context.Context, procedureID string, p *ProcedureLimitParams, opts ...CallOption) (*Procedure, error) {
 	var proc Procedure
-	if err := json.NewDecoder(rsp.Body).Decode(&proc); err != nil {
+	if err := c.do(ctx, "SetProcedureLimit", http.MethodPost, fmt.Sprintf("procedure/%s/limit", procedureID), p, &proc, opts...); err != nil {
 		return nil, err
 	}
 	return &proc, nil
@@ -19,15 +19,6 @@ type SetProcedureGatedVersionParams struct {
 // SetProcedureGatedVersion sets the gated version of the procedure.
 //
 // Note: SetProcedureGatedVersion requires a `Management` API key.
-func (c *Client) SetProcedureGatedVersion(ctx context.Context, procedureID string, version int, p *SetProcedureGatedVersionParams) error {
-	rsp, err := c.makeRequest(ctx, http.MethodPost, fmt.Sprintf("procedures/%s/versions/%d/set-gated", procedureID, version), p)
-	if err != nil {
-		return err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return err
-	}
-	return nil
+func (c *Client) SetProcedureGatedVersion(ctx context.Context, procedureID string, version int, p *SetProcedureGatedVersionParams, opts ...CallOption) error {
+	return c.do(ctx, "SetProcedureGatedVersion", http.MethodPost, fmt.Sprintf("procedures/%s/versions/%d/set-gated", procedureID, version), p, nil, opts...)
 }
@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"time"
@@ -39,19 +38,9 @@ type ListProcedureVersionsResponse struct {
 	Versions []*ProcedureVersion `json:"versions"`
 }
 
-func (c *Client) ListProcedureVersions(ctx context.Context, procedureID string) (*ListProcedureVersionsResponse, error) {
-	rsp, err := c.makeRequest(ctx, http.MethodGet, fmt.Sprintf("procedures/%s/versions", procedureID), nil)
-	if err != nil {
-		return nil, err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return nil, err
-	}
-
+func (c *Client) ListProcedureVersions(ctx context.Context, procedureID string, opts ...CallOption) (*ListProcedureVersionsResponse, error) {
 	var result ListProcedureVersionsResponse
-	if err := json.NewDecoder(rsp.Body).Decode(&result); err != nil {
+	if err := c.do(ctx, "ListProcedureVersions", http.MethodGet, fmt.Sprintf("procedures/%s/versions", procedureID), nil, &result, opts...); err != nil {
 		return nil, err
 	}
 	return &result, nil
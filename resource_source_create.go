@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 )
 
@@ -19,19 +18,9 @@ type ResourceSourceCreateParams struct {
 // CreateResourceSource creates a new resource source.
 //
 // Note: requires a `Management` API key.
-func (c *Client) CreateResourceSource(ctx context.Context, req *ResourceSourceCreateParams) (*ResourceSource, error) {
-	rsp, err := c.makeRequest(ctx, http.MethodPost, "resource-sources", req)
-	if err != nil {
-		return nil, err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return nil, err
-	}
-
+func (c *Client) CreateResourceSource(ctx context.Context, req *ResourceSourceCreateParams, opts ...CallOption) (*ResourceSource, error) {
 	var rs ResourceSource
-	if err := json.NewDecoder(rsp.Body).Decode(&rs); err != nil {
+	if err := c.do(ctx, "CreateResourceSource", http.MethodPost, "resource-sources", req, &rs, opts...); err != nil {
 		return nil, err
 	}
 	return &rs, nil
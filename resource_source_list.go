@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 )
 
@@ -14,19 +13,9 @@ type ResourceSourceListResponse struct {
 // ListResourceSources lists all resource sources accessible to the caller's company.
 //
 // Note: requires a `Management` API key.
-func (c *Client) ListResourceSources(ctx context.Context) (*ResourceSourceListResponse, error) {
-	rsp, err := c.makeRequest(ctx, http.MethodGet, "resource-sources", nil)
-	if err != nil {
-		return nil, err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return nil, err
-	}
-
+func (c *Client) ListResourceSources(ctx context.Context, opts ...CallOption) (*ResourceSourceListResponse, error) {
 	var result ResourceSourceListResponse
-	if err := json.NewDecoder(rsp.Body).Decode(&result); err != nil {
+	if err := c.do(ctx, "ListResourceSources", http.MethodGet, "resource-sources", nil, &result, opts...); err != nil {
 		return nil, err
 	}
 	return &result, nil
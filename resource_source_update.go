@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 )
@@ -20,19 +19,9 @@ type ResourceSourceUpdateParams struct {
 // UpdateResourceSource updates an existing resource source.
 //
 // Note: requires a `Management` API key.
-func (c *Client) UpdateResourceSource(ctx context.Context, id string, req *ResourceSourceUpdateParams) (*ResourceSource, error) {
-	rsp, err := c.makeRequest(ctx, http.MethodPut, fmt.Sprintf("resource-sources/%s", id), req)
-	if err != nil {
-		return nil, err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return nil, err
-	}
-
+func (c *Client) UpdateResourceSource(ctx context.Context, id string, req *ResourceSourceUpdateParams, opts ...CallOption) (*ResourceSource, error) {
 	var rs ResourceSource
-	if err := json.NewDecoder(rsp.Body).Decode(&rs); err != nil {
+	if err := c.do(ctx, "UpdateResourceSource", http.MethodPut, fmt.Sprintf("resource-sources/%s", id), req, &rs, opts...); err != nil {
 		return nil, err
 	}
 	return &rs, nil
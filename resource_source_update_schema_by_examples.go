@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 )
@@ -35,19 +34,9 @@ type UpdateResourceSourceSchemaByExamplesParams struct {
 // your resource source returns, and the system automatically infers the schema from these examples.
 //
 // Note: requires a `Management` API key.
-func (c *Client) UpdateResourceSourceSchemaByExamples(ctx context.Context, id string, req *UpdateResourceSourceSchemaByExamplesParams) (*ResourceSource, error) {
-	rsp, err := c.makeRequest(ctx, http.MethodPost, fmt.Sprintf("resource-sources/%s/schema-by-examples", id), req)
-	if err != nil {
-		return nil, err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return nil, err
-	}
-
+func (c *Client) UpdateResourceSourceSchemaByExamples(ctx context.Context, id string, req *UpdateResourceSourceSchemaByExamplesParams, opts ...CallOption) (*ResourceSource, error) {
 	var rs ResourceSource
-	if err := json.NewDecoder(rsp.Body).Decode(&rs); err != nil {
+	if err := c.do(ctx, "UpdateResourceSourceSchemaByExamples", http.MethodPost, fmt.Sprintf("resource-sources/%s/schema-by-examples", id), req, &rs, opts...); err != nil {
 		return nil, err
 	}
 	return &rs, nil
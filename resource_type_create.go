@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 )
 
@@ -19,19 +18,9 @@ type ResourceTypeCreateParams struct {
 // CreateResourceType creates a new resource type.
 //
 // Note: requires a `Management` API key.
-func (c *Client) CreateResourceType(ctx context.Context, req *ResourceTypeCreateParams) (*ResourceType, error) {
-	rsp, err := c.makeRequest(ctx, http.MethodPost, "resource-types", req)
-	if err != nil {
-		return nil, err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return nil, err
-	}
-
+func (c *Client) CreateResourceType(ctx context.Context, req *ResourceTypeCreateParams, opts ...CallOption) (*ResourceType, error) {
 	var rt ResourceType
-	if err := json.NewDecoder(rsp.Body).Decode(&rt); err != nil {
+	if err := c.do(ctx, "CreateResourceType", http.MethodPost, "resource-types", req, &rt, opts...); err != nil {
 		return nil, err
 	}
 	return &rt, nil
@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 )
 
@@ -14,19 +13,9 @@ type ResourceTypeListResponse struct {
 // ListResourceTypes lists all resource types accessible to the caller's company.
 //
 // Note: requires a `Management` API key.
-func (c *Client) ListResourceTypes(ctx context.Context) (*ResourceTypeListResponse, error) {
-	rsp, err := c.makeRequest(ctx, http.MethodGet, "resource-types", nil)
-	if err != nil {
-		return nil, err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return nil, err
-	}
-
+func (c *Client) ListResourceTypes(ctx context.Context, opts ...CallOption) (*ResourceTypeListResponse, error) {
 	var result ResourceTypeListResponse
-	if err := json.NewDecoder(rsp.Body).Decode(&result); err != nil {
+	if err := c.do(ctx, "ListResourceTypes", http.MethodGet, "resource-types", nil, &result, opts...); err != nil {
 		return nil, err
 	}
 	return &result, nil
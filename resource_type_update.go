@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 )
@@ -20,19 +19,9 @@ type ResourceTypeUpdateParams struct {
 // UpdateResourceType updates an existing resource type.
 //
 // Note: requires a `Management` API key.
-func (c *Client) UpdateResourceType(ctx context.Context, id string, req *ResourceTypeUpdateParams) (*ResourceType, error) {
-	rsp, err := c.makeRequest(ctx, http.MethodPut, fmt.Sprintf("resource-types/%s", id), req)
-	if err != nil {
-		return nil, err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return nil, err
-	}
-
+func (c *Client) UpdateResourceType(ctx context.Context, id string, req *ResourceTypeUpdateParams, opts ...CallOption) (*ResourceType, error) {
 	var rt ResourceType
-	if err := json.NewDecoder(rsp.Body).Decode(&rt); err != nil {
+	if err := c.do(ctx, "UpdateResourceType", http.MethodPut, fmt.Sprintf("resource-types/%s", id), req, &rt, opts...); err != nil {
 		return nil, err
 	}
 	return &rt, nil
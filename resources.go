@@ -0,0 +1,273 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Resources are the documents attached to a conversation for the AI agent to
+// use (e.g. the customer's order details), keyed by resource name. They can be
+// passed when starting, resuming or starting an outbound conversation, and each
+// resource can be passed to AddResource.
+//
+// Resource names can be anything consisting of letters, numbers, or any of the
+// following characters: _ - + =.
+type Resources map[string]any
+
+// ResourcesFromJSON decodes resources from a JSON object, e.g. one that was
+// previously passed as StartOutboundConversationParams.Resources. Numbers are
+// decoded as json.Number so that large integers aren't rounded.
+func ResourcesFromJSON(b []byte) (Resources, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var r Resources
+	if err := dec.Decode(&r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Set adds (or replaces) a resource. The value can be anything that can be
+// encoded as JSON (e.g. a struct); it is stored in its decoded form so that it
+// can be merged and validated. A nil Resources is allocated as needed.
+func (r *Resources) Set(name string, value any) error {
+	v, err := normaliseResource(value)
+	if err != nil {
+		return fmt.Errorf("resource %s: %w", name, err)
+	}
+	if *r == nil {
+		*r = make(Resources)
+	}
+	(*r)[name] = v
+	return nil
+}
+
+// SetField sets a single field of a resource, creating the resource if needed.
+// The path is split on dots to set nested fields (e.g. "address.city"). A nil
+// Resources is allocated as needed.
+func (r *Resources) SetField(name, path string, value any) error {
+	v, err := normaliseResource(value)
+	if err != nil {
+		return fmt.Errorf("resource %s: %s: %w", name, path, err)
+	}
+
+	obj, ok := (*r)[name].(map[string]any)
+	if !ok {
+		if _, exists := (*r)[name]; exists {
+			return fmt.Errorf("resource %s is not an object", name)
+		}
+		obj = make(map[string]any)
+		if *r == nil {
+			*r = make(Resources)
+		}
+		(*r)[name] = obj
+	}
+
+	keys := strings.Split(path, ".")
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := obj[key].(map[string]any)
+		if !ok {
+			if _, exists := obj[key]; exists {
+				return fmt.Errorf("resource %s: %s is not an object", name, key)
+			}
+			next = make(map[string]any)
+			obj[key] = next
+		}
+		obj = next
+	}
+	obj[keys[len(keys)-1]] = v
+	return nil
+}
+
+// Decode decodes the named resource into v (e.g. a pointer to a struct). It
+// returns false if there is no such resource.
+func (r Resources) Decode(name string, v any) (bool, error) {
+	value, ok := r[name]
+	if !ok {
+		return false, nil
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return true, err
+	}
+	return true, json.Unmarshal(b, v)
+}
+
+// Merge returns a copy of r with other merged into it. Objects are merged
+// recursively, and any other values in other replace those in r.
+func (r Resources) Merge(other Resources) Resources {
+	out := make(Resources, len(r)+len(other))
+	for name, v := range r {
+		out[name] = v
+	}
+	for name, v := range other {
+		out[name] = mergeResource(out[name], v)
+	}
+	return out
+}
+
+func mergeResource(dst, src any) any {
+	d, dok := dst.(map[string]any)
+	s, sok := src.(map[string]any)
+	if !dok || !sok {
+		return src
+	}
+
+	out := make(map[string]any, len(d)+len(s))
+	for k, v := range d {
+		out[k] = v
+	}
+	for k, v := range s {
+		out[k] = mergeResource(out[k], v)
+	}
+	return out
+}
+
+// normaliseResource converts a value into its decoded JSON form (i.e. maps,
+// slices, strings, json.Numbers, bools and nil). Numbers are kept as
+// json.Number so that large integers (e.g. IDs) aren't rounded.
+func normaliseResource(value any) (any, error) {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// ResourceAttributeError describes a resource attribute that doesn't match the
+// type in its resource type's schema.
+type ResourceAttributeError struct {
+	// Resource is the name of the resource.
+	Resource string
+
+	// Path is the attribute's path within the resource (e.g. "$.address.city").
+	Path string
+
+	// Want is the type expected by the schema.
+	Want AttributeType
+
+	// Value is the offending value.
+	Value any
+}
+
+// Error satisfies the error interface.
+func (e *ResourceAttributeError) Error() string {
+	return fmt.Sprintf("resource %s: %s should be a %s, got %#v", e.Resource, e.Path, e.Want, e.Value)
+}
+
+// Validate checks the resources against the given schemas, keyed by resource
+// name (e.g. from ResourceType.Schema). Attributes missing from a resource, and
+// resources without a schema, are allowed. Every mismatch is returned as a
+// *ResourceAttributeError, joined with errors.Join.
+func (r Resources) Validate(schemas map[string]*Schema) error {
+	names := make([]string, 0, len(r))
+	for name := range r {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []error
+	for _, name := range names {
+		schema := schemas[name]
+		if schema == nil {
+			continue
+		}
+		doc, err := normaliseResource(r[name])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("resource %s: %w", name, err))
+			continue
+		}
+		for _, attr := range schema.Attributes {
+			for _, v := range resolveAttribute(doc, attr.Path) {
+				if !attributeMatches(attr.Type, v) {
+					errs = append(errs, &ResourceAttributeError{
+						Resource: name,
+						Path:     attr.Path,
+						Want:     attr.Type,
+						Value:    v,
+					})
+				}
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// resolveAttribute returns the values at a simple JSONPath (e.g. "$.a.b" or
+// "$.items[*].quantity") within a decoded JSON document.
+func resolveAttribute(doc any, path string) []any {
+	values := []any{doc}
+	for _, part := range strings.Split(strings.TrimPrefix(path, "$."), ".") {
+		all := strings.HasSuffix(part, "[*]")
+		key := strings.TrimSuffix(part, "[*]")
+
+		var next []any
+		for _, v := range values {
+			obj, ok := v.(map[string]any)
+			if !ok {
+				continue
+			}
+			child, ok := obj[key]
+			if !ok || child == nil {
+				continue
+			}
+			if items, ok := child.([]any); ok && all {
+				next = append(next, items...)
+			} else {
+				next = append(next, child)
+			}
+		}
+		values = next
+	}
+	return values
+}
+
+func attributeMatches(t AttributeType, v any) bool {
+	switch t {
+	case AttributeTypeString:
+		_, ok := v.(string)
+		return ok
+	case AttributeTypeDate:
+		s, ok := v.(string)
+		if !ok {
+			return false
+		}
+		_, err := time.Parse("2006-01-02", s)
+		return err == nil
+	case AttributeTypeTimestamp:
+		s, ok := v.(string)
+		if !ok {
+			return false
+		}
+		_, err := time.Parse(time.RFC3339, s)
+		return err == nil
+	case AttributeTypeBoolean:
+		_, ok := v.(bool)
+		return ok
+	case AttributeTypeNumber:
+		switch v.(type) {
+		case json.Number, float64:
+			return true
+		default:
+			return false
+		}
+	case AttributeTypeArray:
+		_, ok := v.([]any)
+		return ok
+	default:
+		return true
+	}
+}
@@ -0,0 +1,187 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestResourcesSetOnNil(t *testing.T) {
+	var r Resources
+	if err := r.Set("order", struct {
+		ID string `json:"id"`
+	}{"ord-1"}); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	want := Resources{"order": map[string]any{"id": "ord-1"}}
+	if !reflect.DeepEqual(r, want) {
+		t.Errorf("resources = %#v, want %#v", r, want)
+	}
+
+	var p StartOutboundConversationParams
+	if err := p.Resources.SetField("customer", "address.city", "London"); err != nil {
+		t.Fatalf("SetField: %v", err)
+	}
+	want = Resources{"customer": map[string]any{"address": map[string]any{"city": "London"}}}
+	if !reflect.DeepEqual(p.Resources, want) {
+		t.Errorf("resources = %#v, want %#v", p.Resources, want)
+	}
+}
+
+func TestResourcesSetField(t *testing.T) {
+	testCases := map[string]struct {
+		resources Resources
+		path      string
+		want      Resources
+		err       bool
+	}{
+		"new resource": {
+			resources: Resources{},
+			path:      "plan",
+			want:      Resources{"customer": map[string]any{"plan": "gold"}},
+		},
+		"existing object": {
+			resources: Resources{"customer": map[string]any{"name": "Jane"}},
+			path:      "plan",
+			want:      Resources{"customer": map[string]any{"name": "Jane", "plan": "gold"}},
+		},
+		"resource is not an object": {
+			resources: Resources{"customer": "Jane"},
+			path:      "plan",
+			err:       true,
+		},
+		"field is not an object": {
+			resources: Resources{"customer": map[string]any{"plan": "gold"}},
+			path:      "plan.tier",
+			err:       true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.resources.SetField("customer", tc.path, "gold")
+			if tc.err {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SetField: %v", err)
+			}
+			if !reflect.DeepEqual(tc.resources, tc.want) {
+				t.Errorf("resources = %#v, want %#v", tc.resources, tc.want)
+			}
+		})
+	}
+}
+
+func TestResourcesMerge(t *testing.T) {
+	base := Resources{
+		"customer": map[string]any{"name": "Jane", "address": map[string]any{"city": "London"}},
+		"order":    "ord-1",
+	}
+	got := base.Merge(Resources{
+		"customer": map[string]any{"address": map[string]any{"postcode": "N1"}},
+		"order":    "ord-2",
+	})
+
+	want := Resources{
+		"customer": map[string]any{"name": "Jane", "address": map[string]any{"city": "London", "postcode": "N1"}},
+		"order":    "ord-2",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("merged = %#v, want %#v", got, want)
+	}
+	if base["order"] != "ord-1" {
+		t.Errorf("Merge modified the original resources")
+	}
+}
+
+func TestResourcesDecode(t *testing.T) {
+	r, err := ResourcesFromJSON([]byte(`{"order":{"id":"ord-1","total":12.5}}`))
+	if err != nil {
+		t.Fatalf("ResourcesFromJSON: %v", err)
+	}
+
+	var order struct {
+		ID    string  `json:"id"`
+		Total float64 `json:"total"`
+	}
+	ok, err := r.Decode("order", &order)
+	if err != nil || !ok {
+		t.Fatalf("Decode = %v, %v", ok, err)
+	}
+	if order.ID != "ord-1" || order.Total != 12.5 {
+		t.Errorf("order = %+v", order)
+	}
+
+	if ok, err := r.Decode("missing", &order); ok || err != nil {
+		t.Errorf("Decode(missing) = %v, %v, want false, nil", ok, err)
+	}
+}
+
+func TestResourcesValidate(t *testing.T) {
+	r := Resources{
+		"order": map[string]any{
+			"placed": "2024-01-02",
+			"items":  []any{map[string]any{"quantity": 1.0}, map[string]any{"quantity": "two"}},
+		},
+		"other": map[string]any{"anything": true},
+	}
+	schemas := map[string]*Schema{
+		"order": {Attributes: []Attribute{
+			{Path: "$.placed", Type: AttributeTypeDate},
+			{Path: "$.items[*].quantity", Type: AttributeTypeNumber},
+			{Path: "$.missing", Type: AttributeTypeString},
+		}},
+	}
+
+	err := r.Validate(schemas)
+	var attrErr *ResourceAttributeError
+	if !errors.As(err, &attrErr) {
+		t.Fatalf("Validate = %v, want a *ResourceAttributeError", err)
+	}
+	if attrErr.Resource != "order" || attrErr.Path != "$.items[*].quantity" || attrErr.Value != "two" {
+		t.Errorf("error = %+v", attrErr)
+	}
+
+	delete(r, "order")
+	if err := r.Validate(schemas); err != nil {
+		t.Errorf("Validate = %v, want nil", err)
+	}
+}
+
+func TestResourcesLargeIntegers(t *testing.T) {
+	const id uint64 = 1<<53 + 1
+
+	var r Resources
+	if err := r.Set("order", map[string]any{"id": id}); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if err := r.SetField("customer", "id", id); err != nil {
+		t.Fatalf("SetField: %v", err)
+	}
+	b, err := json.Marshal(r)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if want := `{"customer":{"id":9007199254740993},"order":{"id":9007199254740993}}`; string(b) != want {
+		t.Errorf("resources = %s, want %s", b, want)
+	}
+
+	fromJSON, err := ResourcesFromJSON(b)
+	if err != nil {
+		t.Fatalf("ResourcesFromJSON: %v", err)
+	}
+	if b2, _ := json.Marshal(fromJSON); string(b2) != string(b) {
+		t.Errorf("round trip = %s, want %s", b2, b)
+	}
+
+	schemas := map[string]*Schema{
+		"order": {Attributes: []Attribute{{Path: "$.id", Type: AttributeTypeNumber}}},
+	}
+	if err := r.Validate(schemas); err != nil {
+		t.Errorf("Validate = %v, want nil", err)
+	}
+}
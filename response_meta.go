@@ -0,0 +1,77 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// ResponseMeta contains details of the HTTP response to an API call, for when
+// you need more than the decoded result (e.g. for auditing or to inspect
+// rate-limit headers).
+type ResponseMeta struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+
+	// Header contains the response headers.
+	Header http.Header
+
+	// Body is the raw response body.
+	Body []byte
+
+	// Started is the time at which the request was sent.
+	Started time.Time
+
+	// Duration is how long it took to receive the full response.
+	Duration time.Duration
+
+	// Attempts is the number of HTTP requests that were made (e.g. 2 if the
+	// request was retried with a refreshed API key).
+	Attempts int
+}
+
+// RequestID returns the server-assigned identifier for the request, if the
+// response included one.
+func (m *ResponseMeta) RequestID() string {
+	if m.Header == nil {
+		return ""
+	}
+	return m.Header.Get("X-Request-Id")
+}
+
+type responseCaptureContextKey struct{}
+
+// ContextWithResponseCapture returns a context that causes the client to
+// populate meta with the details of the response to calls made using it. If
+// the context is used for several calls, meta describes the last one.
+func ContextWithResponseCapture(ctx context.Context, meta *ResponseMeta) context.Context {
+	return context.WithValue(ctx, responseCaptureContextKey{}, meta)
+}
+
+func responseCaptureFromContext(ctx context.Context) *ResponseMeta {
+	meta, _ := ctx.Value(responseCaptureContextKey{}).(*ResponseMeta)
+	return meta
+}
+
+// captureResponse buffers the response body so it can be recorded in meta and
+// still be decoded afterwards.
+func captureResponse(meta *ResponseMeta, rsp *http.Response, started time.Time, attempts int) error {
+	body, err := io.ReadAll(rsp.Body)
+	if err != nil {
+		return err
+	}
+	_ = rsp.Body.Close()
+	rsp.Body = io.NopCloser(bytes.NewReader(body))
+
+	*meta = ResponseMeta{
+		StatusCode: rsp.StatusCode,
+		Header:     rsp.Header.Clone(),
+		Body:       body,
+		Started:    started,
+		Duration:   time.Since(started),
+		Attempts:   attempts,
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestResponseCapture(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-1")
+		writeJSON(w, http.StatusOK, `{"id":"conv-1"}`)
+	})
+
+	var meta ResponseMeta
+	conv, err := c.ReadConversation(context.Background(), "conv-1", &ReadParams{}, WithResponseCapture(&meta))
+	if err != nil {
+		t.Fatalf("ReadConversation: %v", err)
+	}
+
+	if conv.ID != "conv-1" {
+		t.Errorf("ID = %q, want conv-1 (the body must still be decoded)", conv.ID)
+	}
+	if meta.StatusCode != http.StatusOK {
+		t.Errorf("StatusCode = %d, want 200", meta.StatusCode)
+	}
+	if meta.RequestID() != "req-1" {
+		t.Errorf("RequestID = %q, want req-1", meta.RequestID())
+	}
+	if string(meta.Body) != `{"id":"conv-1"}` {
+		t.Errorf("Body = %s", meta.Body)
+	}
+	if meta.Attempts != 1 || meta.Started.IsZero() {
+		t.Errorf("Attempts = %d, Started = %v", meta.Attempts, meta.Started)
+	}
+}
+
+func TestResponseCaptureOnError(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusBadRequest, `{"message":"bad request","details":{"trace_id":"trace-1"}}`)
+	})
+
+	var meta ResponseMeta
+	ctx := ContextWithResponseCapture(context.Background(), &meta)
+	_, err := c.ReadConversation(ctx, "conv-1", &ReadParams{})
+
+	var re *ResponseError
+	if !errors.As(err, &re) {
+		t.Fatalf("error = %v, want a ResponseError", err)
+	}
+	if re.Message != "bad request" || re.TraceID() != "trace-1" {
+		t.Errorf("ResponseError = %+v, want the decoded body", re)
+	}
+	if meta.StatusCode != http.StatusBadRequest || len(meta.Body) == 0 {
+		t.Errorf("meta = %+v, want the error response", meta)
+	}
+}
+
+func TestResponseMetaRequestIDWithoutHeader(t *testing.T) {
+	if id := (&ResponseMeta{}).RequestID(); id != "" {
+		t.Errorf("RequestID = %q, want empty", id)
+	}
+}
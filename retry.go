@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	baseRetryDelay = 250 * time.Millisecond
+	maxRetryDelay  = 10 * time.Second
+)
+
+// WithRetries sets the number of times a call will be retried if it fails with
+// a transient error (i.e. a network error, a 429, or a 5xx status code). The
+// default is not to retry.
+//
+// Mutating calls are only retried when they carry an Idempotency-Key (see
+// WithIdempotency), so retries cannot create duplicates.
+func WithRetries(retries int) Option {
+	return retriesOption{retries}
+}
+
+type retriesOption struct{ retries int }
+
+func (retriesOption) isClientOption() {}
+
+// roundTrip makes the HTTP request, retrying it once with a refreshed API key if
+// the key is rejected, and up to r.MaxRetries times on transient errors. It
+// returns the final response along with the number of attempts made.
+func (c *Client) roundTrip(ctx context.Context, apiKey string, r *Request) (*http.Response, int, error) {
+	var (
+		attempts  int
+		retries   int
+		refreshed bool
+	)
+	for {
+		attempts++
+		rsp, err := c.makeRequest(ctx, apiKey, r)
+
+		// If the key was rejected, give the provider a chance to supply a
+		// fresh one (e.g. because it has been rotated) and retry once.
+		if err == nil && rsp.StatusCode == http.StatusUnauthorized && !refreshed {
+			if fresh, ok := c.refreshAPIKey(ctx, apiKey); ok {
+				_ = rsp.Body.Close()
+				apiKey, refreshed = fresh, true
+				continue
+			}
+		}
+
+		if retries >= r.MaxRetries || !isRetryable(ctx, r, rsp, err) {
+			return rsp, attempts, err
+		}
+
+		delay := retryDelay(retries, rsp)
+		if rsp != nil {
+			_ = rsp.Body.Close()
+		}
+		retries++
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, attempts, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func isRetryable(ctx context.Context, r *Request, rsp *http.Response, err error) bool {
+	if r.Method != http.MethodGet && r.Header.Get(idempotencyKeyHeader) == "" {
+		return false
+	}
+	if err != nil {
+		return ctx.Err() == nil && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	return rsp.StatusCode == http.StatusTooManyRequests || rsp.StatusCode >= 500
+}
+
+func retryDelay(retries int, rsp *http.Response) time.Duration {
+	if rsp != nil {
+		if secs, err := strconv.Atoi(rsp.Header.Get("Retry-After")); err == nil && secs >= 0 {
+			return time.Duration(secs) * time.Second
+		}
+	}
+
+	delay := baseRetryDelay << retries
+	if delay <= 0 || delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	return delay
+}
@@ -0,0 +1,171 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// flakyHandler fails the first n requests with the given status code, asking
+// for them to be retried immediately.
+func flakyHandler(n, status int, keys *[]string) http.HandlerFunc {
+	var requests int
+	return func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if keys != nil {
+			*keys = append(*keys, r.Header.Get(idempotencyKeyHeader))
+		}
+		if requests <= n {
+			w.Header().Set("Retry-After", "0")
+			writeJSON(w, status, `{}`)
+			return
+		}
+		writeJSON(w, http.StatusOK, `{"id":"conv-1"}`)
+	}
+}
+
+func TestRetries(t *testing.T) {
+	start := StartConversationParams{ID: "conv-1", CustomerID: "cust-1", Channel: ChannelChat}
+
+	testCases := map[string]struct {
+		failures, status int
+		opts             []Option
+		callOpts         []CallOption
+		post             bool
+		wantAttempts     int
+		wantErr          bool
+	}{
+		"no retries by default": {
+			failures: 1, status: http.StatusServiceUnavailable,
+			wantAttempts: 1, wantErr: true,
+		},
+		"retries transient errors": {
+			failures: 2, status: http.StatusServiceUnavailable,
+			opts:         []Option{WithRetries(3)},
+			wantAttempts: 3,
+		},
+		"retries rate limiting": {
+			failures: 1, status: http.StatusTooManyRequests,
+			opts:         []Option{WithRetries(1)},
+			wantAttempts: 2,
+		},
+		"gives up after max retries": {
+			failures: 5, status: http.StatusBadGateway,
+			opts:         []Option{WithRetries(2)},
+			wantAttempts: 3, wantErr: true,
+		},
+		"does not retry client errors": {
+			failures: 1, status: http.StatusBadRequest,
+			opts:         []Option{WithRetries(3)},
+			wantAttempts: 1, wantErr: true,
+		},
+		"call option overrides client": {
+			failures: 2, status: http.StatusServiceUnavailable,
+			opts:         []Option{WithRetries(0)},
+			callOpts:     []CallOption{WithCallRetries(2)},
+			wantAttempts: 3,
+		},
+		"retries mutating calls with a key": {
+			failures: 1, status: http.StatusServiceUnavailable,
+			opts:         []Option{WithRetries(1)},
+			post:         true,
+			wantAttempts: 2,
+		},
+		"does not retry mutating calls without a key": {
+			failures: 1, status: http.StatusServiceUnavailable,
+			opts:         []Option{WithRetries(1), WithIdempotency(IdempotencyDisabled)},
+			post:         true,
+			wantAttempts: 1, wantErr: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var keys []string
+			c := newTestClient(t, flakyHandler(tc.failures, tc.status, &keys), tc.opts...)
+
+			var (
+				meta ResponseMeta
+				err  error
+			)
+			opts := append([]CallOption{WithResponseCapture(&meta)}, tc.callOpts...)
+			if tc.post {
+				_, err = c.StartConversation(context.Background(), start, opts...)
+			} else {
+				_, err = c.ReadConversation(context.Background(), "conv-1", &ReadParams{}, opts...)
+			}
+
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("error = %v, want error: %v", err, tc.wantErr)
+			}
+			if len(keys) != tc.wantAttempts || meta.Attempts != tc.wantAttempts {
+				t.Errorf("requests = %d, Attempts = %d, want %d", len(keys), meta.Attempts, tc.wantAttempts)
+			}
+			for _, k := range keys[1:] {
+				if k != keys[0] {
+					t.Errorf("retry sent Idempotency-Key %q, want %q", k, keys[0])
+				}
+			}
+		})
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	rsp := &http.Response{Header: http.Header{"Retry-After": {"3"}}}
+	if d := retryDelay(0, rsp); d != 3*time.Second {
+		t.Errorf("delay with Retry-After = %v, want 3s", d)
+	}
+
+	for retries, want := range map[int]time.Duration{
+		0:  baseRetryDelay,
+		2:  4 * baseRetryDelay,
+		10: maxRetryDelay,
+		80: maxRetryDelay,
+	} {
+		if d := retryDelay(retries, nil); d != want {
+			t.Errorf("retryDelay(%d) = %v, want %v", retries, d, want)
+		}
+	}
+}
+
+func TestRetryStopsWhenContextDone(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		writeJSON(w, http.StatusServiceUnavailable, `{}`)
+	}, WithRetries(5))
+
+	_, err := c.ReadConversation(context.Background(), "conv-1", &ReadParams{}, WithCallTimeout(20*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestCallOptions(t *testing.T) {
+	var got *http.Request
+	canary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		writeJSON(w, http.StatusOK, `{"id":"conv-1"}`)
+	}))
+	defer canary.Close()
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request sent to the client's base URL")
+	})
+
+	_, err := c.ReadConversation(context.Background(), "conv-1", &ReadParams{},
+		WithCallURL(canary.URL),
+		WithCallHeader("X-Test", "value"),
+	)
+	if err != nil {
+		t.Fatalf("ReadConversation: %v", err)
+	}
+	if got.Header.Get("X-Test") != "value" {
+		t.Errorf("X-Test = %q, want value", got.Header.Get("X-Test"))
+	}
+	if got.URL.Path != "/conversations/conv-1/read" {
+		t.Errorf("path = %q", got.URL.Path)
+	}
+}
@@ -15,17 +15,8 @@ type RevokeSecretParams struct {
 // RevokeSecret permanently deletes a secret. This action cannot be undone.
 //
 // Note: requires a `Management` API key.
-func (c *Client) RevokeSecret(ctx context.Context, p *RevokeSecretParams) error {
+func (c *Client) RevokeSecret(ctx context.Context, p *RevokeSecretParams, opts ...CallOption) error {
 	path := fmt.Sprintf("secrets/%s", p.Name)
 
-	rsp, err := c.makeRequest(ctx, http.MethodDelete, path, nil)
-	if err != nil {
-		return err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return err
-	}
-	return nil
+	return c.do(ctx, "RevokeSecret", http.MethodDelete, path, nil, nil, opts...)
 }
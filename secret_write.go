@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"time"
@@ -28,32 +27,11 @@ type WriteSecretParams struct {
 // it will be updated with the new value and configuration.
 //
 // Note: requires a `Management` API key.
-func (c *Client) WriteSecret(ctx context.Context, p *WriteSecretParams) (*Secret, error) {
+func (c *Client) WriteSecret(ctx context.Context, p *WriteSecretParams, opts ...CallOption) (*Secret, error) {
 	path := fmt.Sprintf("secrets/%s", p.Name)
 
-	// Prepare the request body without the Name field (it's in the path)
-	body := struct {
-		Value                string                `json:"value"`
-		Expiry               *time.Time            `json:"expiry,omitempty"`
-		RefreshMechanismHTTP *RefreshMechanismHTTP `json:"refresh_mechanism_http,omitempty"`
-	}{
-		Value:                p.Value,
-		Expiry:               p.Expiry,
-		RefreshMechanismHTTP: p.RefreshMechanismHTTP,
-	}
-
-	rsp, err := c.makeRequest(ctx, http.MethodPut, path, body)
-	if err != nil {
-		return nil, err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return nil, err
-	}
-
 	var secret Secret
-	if err := json.NewDecoder(rsp.Body).Decode(&secret); err != nil {
+	if err := c.do(ctx, "WriteSecret", http.MethodPut, path, p, &secret, opts...); err != nil {
 		return nil, err
 	}
 	return &secret, nil
@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 )
 
@@ -14,19 +13,9 @@ type SecretsListResponse struct {
 // ListSecrets returns all of your secrets.
 //
 // Note: requires a `Management` API key.
-func (c *Client) ListSecrets(ctx context.Context) (*SecretsListResponse, error) {
-	rsp, err := c.makeRequest(ctx, http.MethodGet, "secrets", nil)
-	if err != nil {
-		return nil, err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return nil, err
-	}
-
+func (c *Client) ListSecrets(ctx context.Context, opts ...CallOption) (*SecretsListResponse, error) {
 	var secrets SecretsListResponse
-	if err := json.NewDecoder(rsp.Body).Decode(&secrets); err != nil {
+	if err := c.do(ctx, "ListSecrets", http.MethodGet, "secrets", nil, &secrets, opts...); err != nil {
 		return nil, err
 	}
 	return &secrets, nil
@@ -0,0 +1,132 @@
+package store
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Memory is an in-memory Store, useful in tests and for short-lived processes.
+type Memory struct {
+	mu            sync.Mutex
+	conversations map[string]*Conversation
+	messages      map[string][]*Message
+}
+
+// NewMemory creates an empty in-memory Store.
+func NewMemory() *Memory {
+	return &Memory{
+		conversations: make(map[string]*Conversation),
+		messages:      make(map[string][]*Message),
+	}
+}
+
+// GetConversation satisfies the Store interface.
+func (m *Memory) GetConversation(_ context.Context, id string) (*Conversation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	conv, ok := m.conversations[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	cp := *conv
+	return &cp, nil
+}
+
+// ListConversations satisfies the Store interface.
+func (m *Memory) ListConversations(_ context.Context, q Query) ([]*Conversation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var out []*Conversation
+	for _, conv := range m.conversations {
+		if matchesQuery(conv, q) {
+			cp := *conv
+			out = append(out, &cp)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Updated.After(out[j].Updated) })
+	if q.Limit > 0 && len(out) > q.Limit {
+		out = out[:q.Limit]
+	}
+	return out, nil
+}
+
+// UpdateConversation satisfies the Store interface.
+func (m *Memory) UpdateConversation(_ context.Context, id string, fn func(*Conversation)) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	conv, ok := m.conversations[id]
+	if !ok {
+		conv = &Conversation{ID: id}
+		m.conversations[id] = conv
+	}
+	fn(conv)
+	conv.ID = id
+	conv.Updated = time.Now()
+	if conv.Created.IsZero() {
+		conv.Created = conv.Updated
+	}
+	return nil
+}
+
+// AddMessage satisfies the Store interface.
+func (m *Memory) AddMessage(_ context.Context, msg *Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	cp := *msg
+	msgs := m.messages[msg.ConversationID]
+	for i, existing := range msgs {
+		if existing.ID == msg.ID {
+			msgs[i] = &cp
+			return nil
+		}
+	}
+	msgs = append(msgs, &cp)
+	sort.SliceStable(msgs, func(i, j int) bool { return msgs[i].Created.Before(msgs[j].Created) })
+	m.messages[msg.ConversationID] = msgs
+	return nil
+}
+
+// Messages satisfies the Store interface.
+func (m *Memory) Messages(_ context.Context, conversationID string) ([]*Message, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	msgs := m.messages[conversationID]
+	out := make([]*Message, len(msgs))
+	for i, msg := range msgs {
+		cp := *msg
+		out[i] = &cp
+	}
+	return out, nil
+}
+
+func matchesQuery(conv *Conversation, q Query) bool {
+	if len(q.Status) > 0 {
+		found := false
+		for _, s := range q.Status {
+			if conv.Status == s {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if q.AssigneeID != "" && conv.AssigneeID != q.AssigneeID {
+		return false
+	}
+	if q.CustomerID != "" && conv.CustomerID != q.CustomerID {
+		return false
+	}
+	if !q.UpdatedBefore.IsZero() && !conv.Updated.Before(q.UpdatedBefore) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,289 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	client "github.com/gradientlabs-ai/gradientlabs-go"
+)
+
+// Dialect captures the differences between the SQL databases supported by
+// SQL.
+type Dialect struct {
+	name string
+
+	// placeholder returns the bind parameter for the nth (1-based) argument.
+	placeholder func(n int) string
+
+	// lockSuffix is appended to a SELECT to lock the selected row.
+	lockSuffix string
+}
+
+var (
+	// SQLite is the Dialect for SQLite (version 3.24 or later).
+	SQLite = Dialect{
+		name:        "sqlite",
+		placeholder: func(int) string { return "?" },
+	}
+
+	// Postgres is the Dialect for PostgreSQL.
+	Postgres = Dialect{
+		name:        "postgres",
+		placeholder: func(n int) string { return fmt.Sprintf("$%d", n) },
+		lockSuffix:  " FOR UPDATE",
+	}
+)
+
+// SQL is a Store backed by a SQLite or PostgreSQL database. It works with any
+// database/sql driver for those databases, which you must import yourself.
+//
+// Times are stored as Unix milliseconds so the schema is identical across
+// dialects.
+type SQL struct {
+	db      *sql.DB
+	dialect Dialect
+}
+
+// NewSQL creates a Store backed by the given database. Call Migrate to create
+// its tables.
+func NewSQL(db *sql.DB, dialect Dialect) *SQL {
+	return &SQL{db: db, dialect: dialect}
+}
+
+const conversationColumns = "id, customer_id, channel, status, assignee_id, assignee_type, intent, " +
+	"hand_off_target, hand_off_reason, hand_off_note, finish_reason, created, updated"
+
+const messageColumns = "conversation_id, id, body, subject, participant_id, participant_type, created"
+
+// Migrate creates the store's tables and indexes if they do not exist.
+func (s *SQL) Migrate(ctx context.Context) error {
+	stmts := []string{
+		`CREATE TABLE IF NOT EXISTS glabs_conversations (
+			id              TEXT PRIMARY KEY,
+			customer_id     TEXT NOT NULL DEFAULT '',
+			channel         TEXT NOT NULL DEFAULT '',
+			status          TEXT NOT NULL DEFAULT '',
+			assignee_id     TEXT NOT NULL DEFAULT '',
+			assignee_type   TEXT NOT NULL DEFAULT '',
+			intent          TEXT NOT NULL DEFAULT '',
+			hand_off_target TEXT NOT NULL DEFAULT '',
+			hand_off_reason TEXT NOT NULL DEFAULT '',
+			hand_off_note   TEXT NOT NULL DEFAULT '',
+			finish_reason   TEXT NOT NULL DEFAULT '',
+			created         BIGINT NOT NULL,
+			updated         BIGINT NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS glabs_conversations_status_updated ON glabs_conversations (status, updated)`,
+		`CREATE TABLE IF NOT EXISTS glabs_messages (
+			conversation_id  TEXT NOT NULL,
+			id               TEXT NOT NULL,
+			body             TEXT NOT NULL DEFAULT '',
+			subject          TEXT NOT NULL DEFAULT '',
+			participant_id   TEXT NOT NULL DEFAULT '',
+			participant_type TEXT NOT NULL DEFAULT '',
+			created          BIGINT NOT NULL,
+			PRIMARY KEY (conversation_id, id)
+		)`,
+	}
+	for _, stmt := range stmts {
+		if _, err := s.db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("store: migrating %s database: %w", s.dialect.name, err)
+		}
+	}
+	return nil
+}
+
+// GetConversation satisfies the Store interface.
+func (s *SQL) GetConversation(ctx context.Context, id string) (*Conversation, error) {
+	row := s.db.QueryRowContext(ctx,
+		"SELECT "+conversationColumns+" FROM glabs_conversations WHERE id = "+s.dialect.placeholder(1), id)
+	return scanConversation(row)
+}
+
+// ListConversations satisfies the Store interface.
+func (s *SQL) ListConversations(ctx context.Context, q Query) ([]*Conversation, error) {
+	var (
+		where []string
+		args  []any
+	)
+	arg := func(v any) string {
+		args = append(args, v)
+		return s.dialect.placeholder(len(args))
+	}
+
+	if len(q.Status) > 0 {
+		ph := make([]string, len(q.Status))
+		for i, st := range q.Status {
+			ph[i] = arg(string(st))
+		}
+		where = append(where, "status IN ("+strings.Join(ph, ", ")+")")
+	}
+	if q.AssigneeID != "" {
+		where = append(where, "assignee_id = "+arg(q.AssigneeID))
+	}
+	if q.CustomerID != "" {
+		where = append(where, "customer_id = "+arg(q.CustomerID))
+	}
+	if !q.UpdatedBefore.IsZero() {
+		where = append(where, "updated < "+arg(q.UpdatedBefore.UnixMilli()))
+	}
+
+	query := "SELECT " + conversationColumns + " FROM glabs_conversations"
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	query += " ORDER BY updated DESC"
+	if q.Limit > 0 {
+		query += " LIMIT " + arg(q.Limit)
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*Conversation
+	for rows.Next() {
+		conv, err := scanConversation(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, conv)
+	}
+	return out, rows.Err()
+}
+
+// UpdateConversation satisfies the Store interface.
+//
+// The row is inserted (if absent) before it is read, so that concurrent
+// updates of a new conversation are serialised: in PostgreSQL the SELECT locks
+// the row, and in SQLite the INSERT takes the database's write lock.
+func (s *SQL) UpdateConversation(ctx context.Context, id string, fn func(*Conversation)) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	now := time.Now()
+	_, err = tx.ExecContext(ctx,
+		"INSERT INTO glabs_conversations (id, created, updated) VALUES ("+
+			s.dialect.placeholder(1)+", "+s.dialect.placeholder(2)+", "+s.dialect.placeholder(3)+
+			") ON CONFLICT (id) DO NOTHING",
+		id, now.UnixMilli(), now.UnixMilli(),
+	)
+	if err != nil {
+		return err
+	}
+
+	row := tx.QueryRowContext(ctx,
+		"SELECT "+conversationColumns+" FROM glabs_conversations WHERE id = "+s.dialect.placeholder(1)+s.dialect.lockSuffix, id)
+	conv, err := scanConversation(row)
+	if err != nil {
+		return err
+	}
+
+	fn(conv)
+	conv.ID = id
+	conv.Updated = time.Now()
+	if conv.Created.IsZero() {
+		conv.Created = conv.Updated
+	}
+
+	sets := []string{
+		"customer_id", "channel", "status", "assignee_id", "assignee_type", "intent",
+		"hand_off_target", "hand_off_reason", "hand_off_note", "finish_reason", "created", "updated",
+	}
+	for i, col := range sets {
+		sets[i] = col + " = " + s.dialect.placeholder(i+1)
+	}
+	_, err = tx.ExecContext(ctx,
+		"UPDATE glabs_conversations SET "+strings.Join(sets, ", ")+" WHERE id = "+s.dialect.placeholder(len(sets)+1),
+		conv.CustomerID, string(conv.Channel), string(conv.Status), conv.AssigneeID,
+		string(conv.AssigneeType), conv.Intent, conv.HandOffTarget, conv.HandOffReason,
+		conv.HandOffNote, conv.FinishReason, conv.Created.UnixMilli(), conv.Updated.UnixMilli(), conv.ID,
+	)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// AddMessage satisfies the Store interface.
+func (s *SQL) AddMessage(ctx context.Context, m *Message) error {
+	ph := make([]string, 7)
+	for i := range ph {
+		ph[i] = s.dialect.placeholder(i + 1)
+	}
+	_, err := s.db.ExecContext(ctx,
+		"INSERT INTO glabs_messages ("+messageColumns+") VALUES ("+strings.Join(ph, ", ")+`)
+		ON CONFLICT (conversation_id, id) DO UPDATE SET
+			body = excluded.body,
+			subject = excluded.subject,
+			participant_id = excluded.participant_id,
+			participant_type = excluded.participant_type,
+			created = excluded.created`,
+		m.ConversationID, m.ID, m.Body, m.Subject, m.ParticipantID, string(m.ParticipantType), m.Created.UnixMilli(),
+	)
+	return err
+}
+
+// Messages satisfies the Store interface.
+func (s *SQL) Messages(ctx context.Context, conversationID string) ([]*Message, error) {
+	rows, err := s.db.QueryContext(ctx,
+		"SELECT "+messageColumns+" FROM glabs_messages WHERE conversation_id = "+s.dialect.placeholder(1)+" ORDER BY created, id",
+		conversationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*Message
+	for rows.Next() {
+		var (
+			m               Message
+			participantType string
+			created         int64
+		)
+		if err := rows.Scan(&m.ConversationID, &m.ID, &m.Body, &m.Subject, &m.ParticipantID, &participantType, &created); err != nil {
+			return nil, err
+		}
+		m.ParticipantType = client.ParticipantType(participantType)
+		m.Created = time.UnixMilli(created)
+		out = append(out, &m)
+	}
+	return out, rows.Err()
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanConversation(row scanner) (*Conversation, error) {
+	var (
+		conv                          Conversation
+		channel, status, assigneeType string
+		created, updated              int64
+	)
+	err := row.Scan(&conv.ID, &conv.CustomerID, &channel, &status, &conv.AssigneeID, &assigneeType,
+		&conv.Intent, &conv.HandOffTarget, &conv.HandOffReason, &conv.HandOffNote, &conv.FinishReason,
+		&created, &updated)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	conv.Channel = client.Channel(channel)
+	conv.Status = client.Status(status)
+	conv.AssigneeType = client.ParticipantType(assigneeType)
+	conv.Created = time.UnixMilli(created)
+	conv.Updated = time.UnixMilli(updated)
+	return &conv, nil
+}
@@ -0,0 +1,175 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeDriver is a database/sql driver that understands just enough of the
+// statements used by SQL.UpdateConversation and GetConversation to keep
+// conversations in memory, and records every statement it runs.
+type fakeDriver struct {
+	mu    sync.Mutex
+	rows  map[string][]driver.Value
+	stmts []string
+}
+
+func (d *fakeDriver) Open(string) (driver.Conn, error) { return &fakeConn{d: d}, nil }
+
+type fakeConn struct{ d *fakeDriver }
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{d: c.d, query: query}, nil
+}
+func (c *fakeConn) Close() error              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) { return fakeTx{}, nil }
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error   { return nil }
+func (fakeTx) Rollback() error { return nil }
+
+type fakeStmt struct {
+	d     *fakeDriver
+	query string
+}
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	s.d.stmts = append(s.d.stmts, s.query)
+
+	switch {
+	case strings.HasPrefix(s.query, "INSERT INTO glabs_conversations (id, created, updated)"):
+		id := args[0].(string)
+		if _, ok := s.d.rows[id]; !ok {
+			s.d.rows[id] = []driver.Value{id, "", "", "", "", "", "", "", "", "", "", args[1], args[2]}
+		}
+	case strings.HasPrefix(s.query, "UPDATE glabs_conversations SET"):
+		id := args[len(args)-1].(string)
+		if _, ok := s.d.rows[id]; !ok {
+			return nil, errors.New("update of missing row")
+		}
+		s.d.rows[id] = append([]driver.Value{id}, args[:len(args)-1]...)
+	default:
+		return nil, errors.New("unexpected statement: " + s.query)
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	s.d.stmts = append(s.d.stmts, s.query)
+
+	row, ok := s.d.rows[args[0].(string)]
+	if !ok {
+		return &fakeRows{}, nil
+	}
+	return &fakeRows{rows: [][]driver.Value{append([]driver.Value(nil), row...)}}, nil
+}
+
+type fakeRows struct{ rows [][]driver.Value }
+
+func (r *fakeRows) Columns() []string { return strings.Split(conversationColumns, ", ") }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+func newFakeSQL(t *testing.T, dialect Dialect) (*SQL, *fakeDriver) {
+	t.Helper()
+
+	d := &fakeDriver{rows: make(map[string][]driver.Value)}
+	name := "storetest-" + t.Name()
+	sql.Register(name, d)
+
+	db, err := sql.Open(name, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return NewSQL(db, dialect), d
+}
+
+func TestSQLUpdateConversationInsertsBeforeLocking(t *testing.T) {
+	testCases := map[string]struct {
+		dialect    Dialect
+		wantSelect string
+	}{
+		"postgres": {dialect: Postgres, wantSelect: "WHERE id = $1 FOR UPDATE"},
+		"sqlite":   {dialect: SQLite, wantSelect: "WHERE id = ?"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			s, d := newFakeSQL(t, tc.dialect)
+			ctx := context.Background()
+
+			err := s.UpdateConversation(ctx, "conv-1", func(c *Conversation) {
+				c.CustomerID = "cust-1"
+				c.Status = "active"
+			})
+			if err != nil {
+				t.Fatalf("UpdateConversation: %v", err)
+			}
+			err = s.UpdateConversation(ctx, "conv-1", func(c *Conversation) {
+				if c.CustomerID != "cust-1" {
+					t.Errorf("second update saw CustomerID %q, want cust-1", c.CustomerID)
+				}
+				c.AssigneeID = "agent-1"
+			})
+			if err != nil {
+				t.Fatalf("UpdateConversation: %v", err)
+			}
+
+			if len(d.stmts) != 6 {
+				t.Fatalf("statements = %q, want 3 per update", d.stmts)
+			}
+			for i, prefix := range []string{"INSERT", "SELECT", "UPDATE"} {
+				if !strings.HasPrefix(d.stmts[i], prefix) {
+					t.Errorf("statement %d = %q, want %s", i, d.stmts[i], prefix)
+				}
+			}
+			if !strings.HasSuffix(d.stmts[0], "ON CONFLICT (id) DO NOTHING") {
+				t.Errorf("insert = %q, want it to leave existing rows alone", d.stmts[0])
+			}
+			if !strings.HasSuffix(d.stmts[1], tc.wantSelect) {
+				t.Errorf("select = %q, want suffix %q", d.stmts[1], tc.wantSelect)
+			}
+
+			conv, err := s.GetConversation(ctx, "conv-1")
+			if err != nil {
+				t.Fatalf("GetConversation: %v", err)
+			}
+			if conv.CustomerID != "cust-1" || conv.Status != "active" || conv.AssigneeID != "agent-1" {
+				t.Errorf("conversation = %+v", conv)
+			}
+			if conv.Created.IsZero() || conv.Updated.Before(conv.Created) {
+				t.Errorf("Created = %v, Updated = %v", conv.Created, conv.Updated)
+			}
+		})
+	}
+}
+
+func TestSQLGetConversationNotFound(t *testing.T) {
+	s, _ := newFakeSQL(t, SQLite)
+	if _, err := s.GetConversation(context.Background(), "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("error = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,145 @@
+// Package store maintains a local mirror of each conversation's state (status,
+// assignee, messages, hand-off notes and finish reasons) so support tooling can
+// query it without hitting the API.
+//
+// Nothing is mirrored automatically: the store only sees the client's calls if
+// Middleware is added to the client, and only sees the agent's actions if each
+// received webhook is passed to ApplyWebhook. Wire up both, or the mirror will
+// miss updates:
+//
+//	s := store.NewSQL(db, store.SQLite)
+//	if err := s.Migrate(ctx); err != nil {
+//		...
+//	}
+//	c, err := client.NewClient(
+//		client.WithAPIKey(apiKey),
+//		client.WithMiddleware(store.Middleware(s)),
+//	)
+//	...
+//	webhook, _, err := c.ParseWebhook(req)
+//	...
+//	err = store.ApplyWebhook(ctx, s, webhook)
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	client "github.com/gradientlabs-ai/gradientlabs-go"
+)
+
+// ErrNotFound is returned when a conversation is not in the store.
+var ErrNotFound = errors.New("conversation not found")
+
+// Conversation is the locally mirrored state of a conversation.
+type Conversation struct {
+	// ID uniquely identifies the conversation.
+	ID string `json:"id"`
+
+	// CustomerID identifies the customer.
+	CustomerID string `json:"customer_id,omitempty"`
+
+	// Channel is the way the customer got in touch.
+	Channel client.Channel `json:"channel,omitempty"`
+
+	// Status is the last known status of the conversation.
+	Status client.Status `json:"status,omitempty"`
+
+	// AssigneeID identifies who the conversation is assigned to, if known.
+	AssigneeID string `json:"assignee_id,omitempty"`
+
+	// AssigneeType identifies the type of participant the conversation is
+	// assigned to.
+	AssigneeType client.ParticipantType `json:"assignee_type,omitempty"`
+
+	// Intent is the latest intent the agent classified for the conversation.
+	Intent string `json:"intent,omitempty"`
+
+	// HandOffTarget is where the agent last wanted to hand the conversation.
+	HandOffTarget string `json:"hand_off_target,omitempty"`
+
+	// HandOffReason is the code describing why the agent last handed off.
+	HandOffReason string `json:"hand_off_reason,omitempty"`
+
+	// HandOffNote is the agent's summary of the conversation at hand-off.
+	HandOffNote string `json:"hand_off_note,omitempty"`
+
+	// FinishReason is the reason the conversation was finished or cancelled.
+	FinishReason string `json:"finish_reason,omitempty"`
+
+	// Created is the time at which the conversation was created.
+	Created time.Time `json:"created"`
+
+	// Updated is the time at which the mirror was last updated.
+	Updated time.Time `json:"updated"`
+}
+
+// Message is a locally mirrored message, sent by any participant including
+// the AI agent.
+type Message struct {
+	// ConversationID identifies the conversation the message belongs to.
+	ConversationID string `json:"conversation_id"`
+
+	// ID uniquely identifies the message within the conversation. For AI agent
+	// messages, it is the ID of the `agent.message` webhook.
+	ID string `json:"id"`
+
+	// Body contains the message text.
+	Body string `json:"body"`
+
+	// Subject is the email subject line, if any.
+	Subject string `json:"subject,omitempty"`
+
+	// ParticipantID identifies the message sender.
+	ParticipantID string `json:"participant_id,omitempty"`
+
+	// ParticipantType identifies the type of participant who sent the message.
+	ParticipantType client.ParticipantType `json:"participant_type"`
+
+	// Created is the time at which the message was sent.
+	Created time.Time `json:"created"`
+}
+
+// Query filters the conversations returned by Store.ListConversations. Empty
+// fields match everything.
+type Query struct {
+	// Status matches conversations with any of the given statuses.
+	Status []client.Status
+
+	// AssigneeID matches conversations assigned to the given participant.
+	AssigneeID string
+
+	// CustomerID matches the given customer's conversations.
+	CustomerID string
+
+	// UpdatedBefore matches conversations that have not been updated since the
+	// given time (e.g. to find stuck conversations).
+	UpdatedBefore time.Time
+
+	// Limit is the maximum number of conversations to return.
+	Limit int
+}
+
+// Store persists the mirrored conversations.
+type Store interface {
+	// GetConversation returns the conversation with the given ID, or
+	// ErrNotFound.
+	GetConversation(ctx context.Context, id string) (*Conversation, error)
+
+	// ListConversations returns conversations matching the query, most
+	// recently updated first.
+	ListConversations(ctx context.Context, q Query) ([]*Conversation, error)
+
+	// UpdateConversation atomically applies fn to the conversation with the
+	// given ID, creating it if it does not exist yet. Updated is set
+	// automatically.
+	UpdateConversation(ctx context.Context, id string, fn func(*Conversation)) error
+
+	// AddMessage adds (or replaces) a message.
+	AddMessage(ctx context.Context, m *Message) error
+
+	// Messages returns the conversation's messages in the order they were
+	// sent.
+	Messages(ctx context.Context, conversationID string) ([]*Message, error)
+}
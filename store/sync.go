@@ -0,0 +1,241 @@
+package store
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	client "github.com/gradientlabs-ai/gradientlabs-go"
+)
+
+// Syncer keeps a Store up to date with client calls and webhooks.
+type Syncer struct {
+	// Store is where conversations are mirrored.
+	Store Store
+
+	// OnError is called when the store could not be updated. Store errors
+	// never cause the client call to fail. If nil, they are ignored.
+	OnError func(err error)
+}
+
+// Middleware returns client middleware that mirrors successful calls into the
+// store. It is shorthand for (&Syncer{Store: s}).Middleware.
+func Middleware(s Store) client.Middleware {
+	return (&Syncer{Store: s}).Middleware
+}
+
+// ApplyWebhook mirrors a received webhook into the store. It is shorthand for
+// (&Syncer{Store: s}).ApplyWebhook.
+func ApplyWebhook(ctx context.Context, s Store, w *client.Webhook) error {
+	return (&Syncer{Store: s}).ApplyWebhook(ctx, w)
+}
+
+// Middleware satisfies the client.Middleware type.
+func (s *Syncer) Middleware(next client.Handler) client.Handler {
+	return func(ctx context.Context, req *client.Request, out any) error {
+		if err := next(ctx, req, out); err != nil {
+			return err
+		}
+		if err := s.applyCall(ctx, req, out); err != nil && s.OnError != nil {
+			s.OnError(err)
+		}
+		return nil
+	}
+}
+
+func (s *Syncer) applyCall(ctx context.Context, req *client.Request, out any) error {
+	id := conversationIDFromPath(req.Path)
+
+	switch req.Operation {
+	case "StartConversation":
+		conv, ok := out.(*client.Conversation)
+		if !ok {
+			return nil
+		}
+		p, _ := req.Params.(client.StartConversationParams)
+		return s.Store.UpdateConversation(ctx, conv.ID, func(c *Conversation) {
+			mergeConversation(c, conv)
+			c.AssigneeID = p.AssigneeID
+			c.AssigneeType = p.AssigneeType
+		})
+
+	case "ReadConversation":
+		if conv, ok := out.(*client.Conversation); ok {
+			return s.Store.UpdateConversation(ctx, conv.ID, func(c *Conversation) { mergeConversation(c, conv) })
+		}
+
+	case "ListConversations":
+		if rsp, ok := out.(*client.ListConversationsResponse); ok {
+			for _, conv := range rsp.Conversations {
+				conv := conv
+				if err := s.Store.UpdateConversation(ctx, conv.ID, func(c *Conversation) { mergeConversation(c, conv) }); err != nil {
+					return err
+				}
+			}
+		}
+
+	case "StartOutboundConversation":
+		rsp, ok := out.(*client.StartOutboundConversationResponse)
+		if !ok {
+			return nil
+		}
+		p, _ := req.Params.(client.StartOutboundConversationParams)
+		channel := p.Channel
+		if channel == "" {
+			// The API defaults to email.
+			channel = client.ChannelEmail
+		}
+		return s.Store.UpdateConversation(ctx, rsp.ConversationID, func(c *Conversation) {
+			c.CustomerID = p.CustomerID
+			c.Channel = channel
+			c.Status = client.StatusActive
+			c.AssigneeType = client.ParticipantTypeAIAgent
+		})
+
+	case "AddMessage":
+		p, ok := req.Params.(client.AddMessageParams)
+		if !ok {
+			return nil
+		}
+		created := p.Created
+		if created.IsZero() {
+			created = time.Now()
+		}
+		if err := s.Store.AddMessage(ctx, &Message{
+			ConversationID:  id,
+			ID:              p.ID,
+			Body:            p.Body,
+			Subject:         p.Subject,
+			ParticipantID:   p.ParticipantID,
+			ParticipantType: p.ParticipantType,
+			Created:         created,
+		}); err != nil {
+			return err
+		}
+		return s.Store.UpdateConversation(ctx, id, func(*Conversation) {})
+
+	case "AssignConversation":
+		if p, ok := req.Params.(*client.AssignmentParams); ok && p != nil {
+			return s.Store.UpdateConversation(ctx, id, func(c *Conversation) {
+				c.AssigneeID = p.AssigneeID
+				c.AssigneeType = p.AssigneeType
+				c.Status = statusForAssignee(p.AssigneeType)
+			})
+		}
+
+	case "ResumeConversation":
+		if p, ok := req.Params.(*client.ConversationResumeParams); ok && p != nil {
+			return s.Store.UpdateConversation(ctx, id, func(c *Conversation) {
+				c.AssigneeID = p.AssigneeID
+				c.AssigneeType = p.AssigneeType
+				c.Status = statusForAssignee(p.AssigneeType)
+				c.FinishReason = ""
+			})
+		}
+
+	case "FinishConversation":
+		p, _ := req.Params.(client.FinishParams)
+		return s.Store.UpdateConversation(ctx, id, func(c *Conversation) {
+			c.Status = client.StatusFinished
+			c.FinishReason = p.Reason
+		})
+
+	case "CancelConversation":
+		p, _ := req.Params.(client.CancelParams)
+		return s.Store.UpdateConversation(ctx, id, func(c *Conversation) {
+			c.Status = client.StatusCancelled
+			c.FinishReason = p.Reason
+		})
+	}
+	return nil
+}
+
+// ApplyWebhook mirrors a received webhook into the store.
+func (s *Syncer) ApplyWebhook(ctx context.Context, w *client.Webhook) error {
+	switch w.Type {
+	case client.WebhookTypeAgentMessage:
+		ev, ok := w.AgentMessage()
+		if !ok {
+			return nil
+		}
+		if err := s.Store.AddMessage(ctx, &Message{
+			ConversationID:  ev.Conversation.ID,
+			ID:              w.ID,
+			Body:            ev.Body,
+			ParticipantType: client.ParticipantTypeAIAgent,
+			Created:         w.Timestamp,
+		}); err != nil {
+			return err
+		}
+		return s.Store.UpdateConversation(ctx, ev.Conversation.ID, func(c *Conversation) {
+			c.CustomerID = ev.Conversation.CustomerID
+			if ev.Intent != "" {
+				c.Intent = ev.Intent
+			}
+		})
+
+	case client.WebhookTypeConversationHandOff:
+		ev, ok := w.ConversationHandOff()
+		if !ok {
+			return nil
+		}
+		return s.Store.UpdateConversation(ctx, ev.Conversation.ID, func(c *Conversation) {
+			c.CustomerID = ev.Conversation.CustomerID
+			c.Status = client.StatusObserving
+			c.HandOffTarget = ev.Target
+			c.HandOffReason = ev.Reason
+			c.HandOffNote = ev.Note
+			if ev.Intent != "" {
+				c.Intent = ev.Intent
+			}
+		})
+
+	case client.WebhookTypeConversationFinished:
+		ev, ok := w.ConversationFinished()
+		if !ok {
+			return nil
+		}
+		return s.Store.UpdateConversation(ctx, ev.Conversation.ID, func(c *Conversation) {
+			c.CustomerID = ev.Conversation.CustomerID
+			c.Status = client.StatusFinished
+			c.FinishReason = ev.Reason
+			if ev.Intent != "" {
+				c.Intent = ev.Intent
+			}
+		})
+	}
+	return nil
+}
+
+func mergeConversation(c *Conversation, conv *client.Conversation) {
+	c.CustomerID = conv.CustomerID
+	c.Channel = conv.Channel
+	c.Status = conv.Status
+	if !conv.Created.IsZero() {
+		c.Created = conv.Created
+	}
+	if md := conv.AgentMetadata; md != nil {
+		c.Intent = md.Intent
+		c.HandOffTarget = md.IntentHandOffTarget
+		c.HandOffReason = md.HandOffReason
+		c.HandOffNote = md.HandOffNote
+	}
+}
+
+func statusForAssignee(t client.ParticipantType) client.Status {
+	if t == client.ParticipantTypeAIAgent {
+		return client.StatusActive
+	}
+	return client.StatusObserving
+}
+
+// conversationIDFromPath extracts the ID from paths like
+// "conversations/{id}/messages".
+func conversationIDFromPath(path string) string {
+	path, _, _ = strings.Cut(path, "?")
+	parts := strings.Split(path, "/")
+	if len(parts) < 2 || parts[0] != "conversations" {
+		return ""
+	}
+	return parts[1]
+}
@@ -0,0 +1,190 @@
+package store_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	client "github.com/gradientlabs-ai/gradientlabs-go"
+	"github.com/gradientlabs-ai/gradientlabs-go/store"
+)
+
+func newSyncedClient(t *testing.T, s store.Store, handler http.HandlerFunc) *client.Client {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	c, err := client.NewClient(
+		client.WithURL(srv.URL),
+		client.WithAPIKey("key"),
+		client.WithMiddleware(store.Middleware(s)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestMiddlewareMirrorsCalls(t *testing.T) {
+	s := store.NewMemory()
+	c := newSyncedClient(t, s, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/conversations":
+			_, _ = w.Write([]byte(`{"id":"conv-1","customer_id":"cust-1","channel":"web","status":"active"}`))
+		case r.URL.Path == "/outbound/conversations":
+			_, _ = w.Write([]byte(`{"conversation_id":"out-1"}`))
+		case strings.HasSuffix(r.URL.Path, "/messages"):
+			_, _ = w.Write([]byte(`{"id":"m1"}`))
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	ctx := context.Background()
+
+	if _, err := c.StartConversation(ctx, client.StartConversationParams{
+		ID: "conv-1", CustomerID: "cust-1", Channel: client.ChannelChat, AssigneeType: client.ParticipantTypeAIAgent,
+	}); err != nil {
+		t.Fatalf("StartConversation: %v", err)
+	}
+	if _, err := c.AddMessage(ctx, "conv-1", client.AddMessageParams{
+		ID: "m1", Body: "Hi", ParticipantID: "cust-1", ParticipantType: client.ParticipantTypeCustomer,
+	}); err != nil {
+		t.Fatalf("AddMessage: %v", err)
+	}
+	if err := c.AssignConversation(ctx, "conv-1", &client.AssignmentParams{
+		AssigneeID: "agent-1", AssigneeType: client.ParticipantTypeHumanAgent,
+	}); err != nil {
+		t.Fatalf("AssignConversation: %v", err)
+	}
+
+	conv, err := s.GetConversation(ctx, "conv-1")
+	if err != nil {
+		t.Fatalf("GetConversation: %v", err)
+	}
+	if conv.CustomerID != "cust-1" || conv.AssigneeID != "agent-1" || conv.Status != client.StatusObserving {
+		t.Errorf("conversation = %+v", conv)
+	}
+	msgs, _ := s.Messages(ctx, "conv-1")
+	if len(msgs) != 1 || msgs[0].Body != "Hi" {
+		t.Errorf("messages = %+v", msgs)
+	}
+
+	if err := c.FinishConversation(ctx, "conv-1", client.FinishParams{Reason: "resolved"}); err != nil {
+		t.Fatalf("FinishConversation: %v", err)
+	}
+	conv, _ = s.GetConversation(ctx, "conv-1")
+	if conv.Status != client.StatusFinished || conv.FinishReason != "resolved" {
+		t.Errorf("after finish, conversation = %+v", conv)
+	}
+
+	if _, err := c.StartOutboundConversation(ctx, client.StartOutboundConversationParams{
+		CustomerID: "cust-2", CustomerSource: client.CustomerSourceIntercom, ProcedureID: "proc-1",
+	}); err != nil {
+		t.Fatalf("StartOutboundConversation: %v", err)
+	}
+	out, err := s.GetConversation(ctx, "out-1")
+	if err != nil {
+		t.Fatalf("GetConversation(out-1): %v", err)
+	}
+	if out.Channel != client.ChannelEmail {
+		t.Errorf("outbound Channel = %q, want the API's default of email", out.Channel)
+	}
+}
+
+func TestMiddlewareIgnoresFailedCalls(t *testing.T) {
+	s := store.NewMemory()
+	c := newSyncedClient(t, s, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	if err := c.FinishConversation(context.Background(), "conv-1", client.FinishParams{}); err == nil {
+		t.Fatal("expected an error")
+	}
+	if _, err := s.GetConversation(context.Background(), "conv-1"); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestApplyWebhook(t *testing.T) {
+	s := store.NewMemory()
+	ctx := context.Background()
+	conv := client.WebhookConversation{ID: "conv-1", CustomerID: "cust-1"}
+	ts := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	webhooks := []*client.Webhook{
+		{ID: "wh-1", Type: client.WebhookTypeAgentMessage, Timestamp: ts, Data: &client.AgentMessageEvent{
+			Conversation: conv, Body: "Let me check.", Intent: "card-lost",
+		}},
+		{ID: "wh-2", Type: client.WebhookTypeConversationHandOff, Timestamp: ts, Data: &client.ConversationHandOffEvent{
+			Conversation: conv, Target: "cards-team", Reason: "needs-human", Note: "Customer lost their card.",
+		}},
+	}
+	for _, w := range webhooks {
+		if err := store.ApplyWebhook(ctx, s, w); err != nil {
+			t.Fatalf("ApplyWebhook(%s): %v", w.Type, err)
+		}
+	}
+
+	got, err := s.GetConversation(ctx, "conv-1")
+	if err != nil {
+		t.Fatalf("GetConversation: %v", err)
+	}
+	want := store.Conversation{
+		ID: "conv-1", CustomerID: "cust-1", Status: client.StatusObserving, Intent: "card-lost",
+		HandOffTarget: "cards-team", HandOffReason: "needs-human", HandOffNote: "Customer lost their card.",
+	}
+	got.Created, got.Updated = time.Time{}, time.Time{}
+	if *got != want {
+		t.Errorf("conversation = %+v, want %+v", *got, want)
+	}
+
+	msgs, _ := s.Messages(ctx, "conv-1")
+	if len(msgs) != 1 || msgs[0].ID != "wh-1" || msgs[0].ParticipantType != client.ParticipantTypeAIAgent {
+		t.Errorf("messages = %+v", msgs)
+	}
+
+	// Redelivered webhooks replace the message rather than duplicating it.
+	if err := store.ApplyWebhook(ctx, s, webhooks[0]); err != nil {
+		t.Fatal(err)
+	}
+	if msgs, _ := s.Messages(ctx, "conv-1"); len(msgs) != 1 {
+		t.Errorf("messages after redelivery = %d, want 1", len(msgs))
+	}
+}
+
+func TestMemoryListConversations(t *testing.T) {
+	s := store.NewMemory()
+	ctx := context.Background()
+
+	for _, c := range []struct{ id, customer string }{{"a", "cust-1"}, {"b", "cust-2"}, {"c", "cust-1"}} {
+		c := c
+		if err := s.UpdateConversation(ctx, c.id, func(conv *store.Conversation) {
+			conv.CustomerID = c.customer
+			conv.Status = client.StatusActive
+		}); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	got, _ := s.ListConversations(ctx, store.Query{CustomerID: "cust-1"})
+	if len(got) != 2 || got[0].ID != "c" || got[1].ID != "a" {
+		t.Errorf("ListConversations = %+v, want c then a", got)
+	}
+
+	got, _ = s.ListConversations(ctx, store.Query{Status: []client.Status{client.StatusFinished}})
+	if len(got) != 0 {
+		t.Errorf("ListConversations(finished) = %d, want 0", len(got))
+	}
+
+	got, _ = s.ListConversations(ctx, store.Query{Limit: 1})
+	if len(got) != 1 || got[0].ID != "c" {
+		t.Errorf("ListConversations(limit 1) = %+v", got)
+	}
+}
@@ -0,0 +1,209 @@
+package survey
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	client "github.com/gradientlabs-ai/gradientlabs-go"
+)
+
+const (
+	defaultDelay  = time.Minute
+	defaultWindow = 24 * time.Hour
+)
+
+// Rater is the subset of client.ConversationAPI used to submit ratings.
+type Rater interface {
+	RateConversation(ctx context.Context, conversationID string, p *client.RatingParams, opts ...client.CallOption) error
+}
+
+// SendFunc sends the survey question to the customer over the conversation's
+// channel.
+type SendFunc func(ctx context.Context, conversationID, question string) error
+
+// Config customises a Runner.
+type Config struct {
+	// Delay is how long to wait after the conversation finishes before
+	// sending the survey. Defaults to 1 minute.
+	Delay time.Duration
+
+	// Window is how long after sending the survey replies are treated as
+	// ratings. Defaults to 24 hours.
+	Window time.Duration
+
+	// OnError is called when a scheduled survey fails to send.
+	OnError func(conversationID string, err error)
+}
+
+// Runner sends a survey when conversations finish, and turns the customers'
+// replies into ratings. Use NewRunner to create one.
+type Runner struct {
+	api    Rater
+	survey Survey
+	send   SendFunc
+	cfg    Config
+
+	mu      sync.Mutex
+	pending map[string]*pending
+}
+
+// pending is a survey that has been scheduled or sent. Its timer sends the
+// survey, and once sent, forgets it when the window for replying has passed.
+type pending struct {
+	timer  *time.Timer
+	sentAt time.Time
+}
+
+// NewRunner creates a Runner that sends the survey with send.
+func NewRunner(api Rater, survey Survey, send SendFunc, cfg Config) (*Runner, error) {
+	if err := survey.Validate(); err != nil {
+		return nil, err
+	}
+	if survey.Question == "" {
+		return nil, errors.New("survey: question is required")
+	}
+	if cfg.Delay <= 0 {
+		cfg.Delay = defaultDelay
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = defaultWindow
+	}
+	return &Runner{
+		api:     api,
+		survey:  survey,
+		send:    send,
+		cfg:     cfg,
+		pending: make(map[string]*pending),
+	}, nil
+}
+
+// HandleWebhook schedules a survey when a `conversation.finished` webhook is
+// received. Other webhook types are ignored.
+func (r *Runner) HandleWebhook(w *client.Webhook) {
+	if ev, ok := w.ConversationFinished(); ok {
+		r.Schedule(ev.Conversation.ID)
+	}
+}
+
+// Schedule sends the survey for the conversation once the configured delay
+// has passed. Scheduling a conversation again restarts the delay.
+func (r *Runner) Schedule(conversationID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if p, ok := r.pending[conversationID]; ok {
+		p.timer.Stop()
+	}
+
+	p := &pending{}
+	p.timer = time.AfterFunc(r.cfg.Delay, func() { r.sendSurvey(conversationID, p) })
+	r.pending[conversationID] = p
+}
+
+// Cancel stops a scheduled survey from being sent (e.g. because the customer
+// wrote back and the conversation was resumed), or stops waiting for a reply.
+func (r *Runner) Cancel(conversationID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if p, ok := r.pending[conversationID]; ok {
+		p.timer.Stop()
+		delete(r.pending, conversationID)
+	}
+}
+
+// Stop cancels all scheduled surveys.
+func (r *Runner) Stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, p := range r.pending {
+		p.timer.Stop()
+		delete(r.pending, id)
+	}
+}
+
+func (r *Runner) sendSurvey(conversationID string, p *pending) {
+	err := r.send(context.Background(), conversationID, r.survey.Question)
+
+	r.mu.Lock()
+	if r.pending[conversationID] == p {
+		if err != nil {
+			delete(r.pending, conversationID)
+		} else {
+			p.sentAt = time.Now()
+			p.timer = time.AfterFunc(r.cfg.Window, func() { r.expire(conversationID, p) })
+		}
+	}
+	r.mu.Unlock()
+
+	if err != nil && r.cfg.OnError != nil {
+		r.cfg.OnError(conversationID, err)
+	}
+}
+
+// expire forgets a survey the customer didn't reply to in time.
+func (r *Runner) expire(conversationID string, p *pending) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.pending[conversationID] == p {
+		delete(r.pending, conversationID)
+	}
+}
+
+// Awaiting reports whether the conversation has been sent a survey that the
+// customer hasn't replied to yet.
+func (r *Runner) Awaiting(conversationID string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p, ok := r.pending[conversationID]
+	return ok && r.awaiting(p)
+}
+
+func (r *Runner) awaiting(p *pending) bool {
+	return !p.sentAt.IsZero() && time.Since(p.sentAt) < r.cfg.Window
+}
+
+// HandleReply submits the customer's reply as a rating if the conversation is
+// awaiting one. It returns false if the conversation isn't awaiting a rating,
+// or the reply doesn't contain one, in which case the reply should be handled
+// as a normal message. Only one reply is submitted as the rating, even if
+// several arrive at once.
+func (r *Runner) HandleReply(ctx context.Context, conversationID, reply string) (bool, error) {
+	if !r.Awaiting(conversationID) {
+		return false, nil
+	}
+
+	params, err := r.survey.ParseRating(reply)
+	if errors.Is(err, ErrNoRating) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	// Claim the survey, so a concurrent reply isn't also submitted.
+	r.mu.Lock()
+	p, ok := r.pending[conversationID]
+	if !ok || !r.awaiting(p) {
+		r.mu.Unlock()
+		return false, nil
+	}
+	delete(r.pending, conversationID)
+	r.mu.Unlock()
+
+	if err := r.api.RateConversation(ctx, conversationID, params); err != nil {
+		// Put the survey back so the customer's next reply can be submitted.
+		r.mu.Lock()
+		if _, ok := r.pending[conversationID]; !ok && r.awaiting(p) {
+			r.pending[conversationID] = p
+		}
+		r.mu.Unlock()
+		return false, err
+	}
+	return true, nil
+}
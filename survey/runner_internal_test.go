@@ -0,0 +1,33 @@
+package survey
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRunnerForgetsUnansweredSurveys(t *testing.T) {
+	r, err := NewRunner(nil, CSAT, func(context.Context, string, string) error { return nil }, Config{
+		Delay:  time.Millisecond,
+		Window: 10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Schedule("conv-1")
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		r.mu.Lock()
+		n := len(r.pending)
+		r.mu.Unlock()
+
+		if n == 0 {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("unanswered survey was never forgotten")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
@@ -0,0 +1,264 @@
+package survey_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	client "github.com/gradientlabs-ai/gradientlabs-go"
+	"github.com/gradientlabs-ai/gradientlabs-go/survey"
+)
+
+// fakeRater records the ratings it is sent.
+type fakeRater struct {
+	mu      sync.Mutex
+	err     error
+	delay   time.Duration
+	calls   int
+	ratings map[string]*client.RatingParams
+}
+
+func (f *fakeRater) RateConversation(_ context.Context, conversationID string, p *client.RatingParams, _ ...client.CallOption) error {
+	time.Sleep(f.delay)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	if f.err != nil {
+		return f.err
+	}
+	if f.ratings == nil {
+		f.ratings = make(map[string]*client.RatingParams)
+	}
+	f.ratings[conversationID] = p
+	return nil
+}
+
+// fakeSender records the surveys sent, signalling each one on sent.
+type fakeSender struct {
+	err  error
+	sent chan string
+}
+
+func newFakeSender(err error) *fakeSender {
+	return &fakeSender{err: err, sent: make(chan string, 10)}
+}
+
+func (f *fakeSender) send(_ context.Context, conversationID, _ string) error {
+	f.sent <- conversationID
+	return f.err
+}
+
+func (f *fakeSender) wait(t *testing.T) string {
+	t.Helper()
+	select {
+	case id := <-f.sent:
+		// Give the runner a moment to record that the survey was sent.
+		time.Sleep(5 * time.Millisecond)
+		return id
+	case <-time.After(time.Second):
+		t.Fatal("survey was not sent")
+		return ""
+	}
+}
+
+func TestRunner(t *testing.T) {
+	rater := &fakeRater{}
+	sender := newFakeSender(nil)
+	r, err := survey.NewRunner(rater, survey.CSAT, sender.send, survey.Config{Delay: time.Millisecond})
+	if err != nil {
+		t.Fatalf("NewRunner: %v", err)
+	}
+	ctx := context.Background()
+
+	if handled, err := r.HandleReply(ctx, "conv-1", "5"); handled || err != nil {
+		t.Errorf("HandleReply before scheduling = %v, %v", handled, err)
+	}
+
+	r.HandleWebhook(&client.Webhook{
+		Type: client.WebhookTypeConversationFinished,
+		Data: &client.ConversationFinishedEvent{Conversation: client.WebhookConversation{ID: "conv-1"}},
+	})
+	if got := sender.wait(t); got != "conv-1" {
+		t.Fatalf("sent survey for %q", got)
+	}
+	for deadline := time.Now().Add(time.Second); !r.Awaiting("conv-1"); time.Sleep(time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("conversation is not awaiting a rating")
+		}
+	}
+
+	if handled, err := r.HandleReply(ctx, "conv-1", "Where is my refund?"); handled || err != nil {
+		t.Errorf("HandleReply without a rating = %v, %v", handled, err)
+	}
+	if !r.Awaiting("conv-1") {
+		t.Error("a reply without a rating stopped the survey")
+	}
+
+	if handled, err := r.HandleReply(ctx, "conv-1", "4 - thanks"); !handled || err != nil {
+		t.Fatalf("HandleReply = %v, %v", handled, err)
+	}
+	if p := rater.ratings["conv-1"]; p == nil || p.Value != 4 || p.Comments != "thanks" {
+		t.Errorf("rating = %+v", p)
+	}
+	if r.Awaiting("conv-1") {
+		t.Error("still awaiting a rating after one was submitted")
+	}
+	if handled, _ := r.HandleReply(ctx, "conv-1", "5"); handled {
+		t.Error("a second reply was treated as a rating")
+	}
+}
+
+// sendSurvey schedules a survey and waits until it is awaiting a reply.
+func sendSurvey(t *testing.T, r *survey.Runner, sender *fakeSender, conversationID string) {
+	t.Helper()
+	r.Schedule(conversationID)
+	sender.wait(t)
+	for deadline := time.Now().Add(time.Second); !r.Awaiting(conversationID); time.Sleep(time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("conversation is not awaiting a rating")
+		}
+	}
+}
+
+func TestRunnerConcurrentReplies(t *testing.T) {
+	rater := &fakeRater{delay: 10 * time.Millisecond}
+	sender := newFakeSender(nil)
+	r, err := survey.NewRunner(rater, survey.CSAT, sender.send, survey.Config{Delay: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sendSurvey(t, r, sender, "conv-1")
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		handled int
+	)
+	for _, reply := range []string{"5", "4", "3"} {
+		wg.Add(1)
+		go func(reply string) {
+			defer wg.Done()
+			ok, err := r.HandleReply(context.Background(), "conv-1", reply)
+			if err != nil {
+				t.Error(err)
+			}
+			if ok {
+				mu.Lock()
+				handled++
+				mu.Unlock()
+			}
+		}(reply)
+	}
+	wg.Wait()
+
+	if handled != 1 || rater.calls != 1 {
+		t.Errorf("handled %d replies with %d ratings, want 1", handled, rater.calls)
+	}
+}
+
+func TestRunnerRatingError(t *testing.T) {
+	rater := &fakeRater{err: errors.New("boom")}
+	sender := newFakeSender(nil)
+	r, err := survey.NewRunner(rater, survey.CSAT, sender.send, survey.Config{Delay: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sendSurvey(t, r, sender, "conv-1")
+
+	if handled, err := r.HandleReply(context.Background(), "conv-1", "5"); handled || err == nil {
+		t.Fatalf("HandleReply = %v, %v, want an error", handled, err)
+	}
+	if !r.Awaiting("conv-1") {
+		t.Fatal("a failed rating stopped the survey")
+	}
+
+	rater.mu.Lock()
+	rater.err = nil
+	rater.mu.Unlock()
+	if handled, err := r.HandleReply(context.Background(), "conv-1", "5"); !handled || err != nil {
+		t.Errorf("HandleReply = %v, %v", handled, err)
+	}
+}
+
+func TestRunnerWindow(t *testing.T) {
+	sender := newFakeSender(nil)
+	r, err := survey.NewRunner(&fakeRater{}, survey.Thumbs, sender.send, survey.Config{
+		Delay:  time.Millisecond,
+		Window: 20 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r.Schedule("conv-1")
+	sender.wait(t)
+	time.Sleep(30 * time.Millisecond)
+
+	if r.Awaiting("conv-1") {
+		t.Error("still awaiting a rating after the window")
+	}
+	if handled, _ := r.HandleReply(context.Background(), "conv-1", "yes"); handled {
+		t.Error("a reply after the window was treated as a rating")
+	}
+}
+
+func TestRunnerCancel(t *testing.T) {
+	sender := newFakeSender(nil)
+	r, err := survey.NewRunner(&fakeRater{}, survey.CSAT, sender.send, survey.Config{Delay: 20 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r.Schedule("conv-1")
+	r.Schedule("conv-2")
+	r.Cancel("conv-1")
+	if got := sender.wait(t); got != "conv-2" {
+		t.Errorf("sent survey for %q", got)
+	}
+
+	r.Schedule("conv-3")
+	r.Stop()
+	select {
+	case id := <-sender.sent:
+		t.Errorf("sent survey for %q after Stop", id)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestRunnerSendError(t *testing.T) {
+	errs := make(chan error, 1)
+	sender := newFakeSender(errors.New("boom"))
+	r, err := survey.NewRunner(&fakeRater{}, survey.CSAT, sender.send, survey.Config{
+		Delay:   time.Millisecond,
+		OnError: func(_ string, err error) { errs <- err },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r.Schedule("conv-1")
+	select {
+	case err := <-errs:
+		if err.Error() != "boom" {
+			t.Errorf("OnError = %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("OnError was not called")
+	}
+	if r.Awaiting("conv-1") {
+		t.Error("awaiting a rating for a survey that failed to send")
+	}
+}
+
+func TestNewRunnerValidation(t *testing.T) {
+	send := func(context.Context, string, string) error { return nil }
+	if _, err := survey.NewRunner(&fakeRater{}, survey.Survey{Type: "x", MaxValue: 1}, send, survey.Config{}); err == nil {
+		t.Error("expected an error without a question")
+	}
+	if _, err := survey.NewRunner(&fakeRater{}, survey.Survey{Question: "?"}, send, survey.Config{}); err == nil {
+		t.Error("expected an error for an invalid survey")
+	}
+}
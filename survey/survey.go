@@ -0,0 +1,229 @@
+// Package survey runs customer satisfaction surveys (CSAT, NPS and thumbs
+// up/down) and submits the results with Client.RateConversation.
+package survey
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	client "github.com/gradientlabs-ai/gradientlabs-go"
+)
+
+// ErrNoRating is returned when a reply doesn't contain a rating.
+var ErrNoRating = errors.New("survey: no rating in reply")
+
+// Survey describes a rating scale and the question used to ask for a rating.
+type Survey struct {
+	// Type identifies the type of survey (see: RatingParams.SurveyType).
+	Type string
+
+	// MinValue is the minimum value of the rating scale.
+	MinValue int
+
+	// MaxValue is the maximum value of the rating scale.
+	MaxValue int
+
+	// Question is the message sent to the customer to ask for a rating.
+	Question string
+}
+
+var (
+	// CSAT is a customer satisfaction survey, rated from 1 to 5.
+	CSAT = Survey{
+		Type:     "csat",
+		MinValue: 1,
+		MaxValue: 5,
+		Question: "How would you rate the support you received? Please reply with a number from 1 (poor) to 5 (excellent), and let us know if there's anything we could do better.",
+	}
+
+	// NPS is a Net Promoter Score survey, rated from 0 to 10.
+	NPS = Survey{
+		Type:     "nps",
+		MinValue: 0,
+		MaxValue: 10,
+		Question: "How likely are you to recommend us to a friend or colleague? Please reply with a number from 0 (not at all likely) to 10 (extremely likely).",
+	}
+
+	// Thumbs is a thumbs up/down survey, where 0 is down and 1 is up.
+	Thumbs = Survey{
+		Type:     "thumbs",
+		MinValue: 0,
+		MaxValue: 1,
+		Question: "Did we solve your problem? Please reply yes or no.",
+	}
+)
+
+// Validate checks that the survey's scale is well-formed.
+func (s Survey) Validate() error {
+	if s.Type == "" {
+		return errors.New("survey: type is required")
+	}
+	if s.MinValue >= s.MaxValue {
+		return fmt.Errorf("survey: %s minimum value (%d) must be less than maximum value (%d)", s.Type, s.MinValue, s.MaxValue)
+	}
+	return nil
+}
+
+// Rating validates the value and returns the parameters to submit it with
+// Client.RateConversation.
+func (s Survey) Rating(value int, comments string) (*client.RatingParams, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	if value < s.MinValue || value > s.MaxValue {
+		return nil, fmt.Errorf("survey: %s rating must be between %d and %d, got %d", s.Type, s.MinValue, s.MaxValue, value)
+	}
+	now := time.Now()
+	return &client.RatingParams{
+		SurveyType: s.Type,
+		Value:      value,
+		MinValue:   s.MinValue,
+		MaxValue:   s.MaxValue,
+		Comments:   strings.TrimSpace(comments),
+		Timestamp:  &now,
+	}, nil
+}
+
+// CSATRating returns the parameters for a CSAT rating from 1 to 5.
+func CSATRating(value int, comments string) (*client.RatingParams, error) {
+	return CSAT.Rating(value, comments)
+}
+
+// NPSRating returns the parameters for an NPS rating from 0 to 10.
+func NPSRating(value int, comments string) (*client.RatingParams, error) {
+	return NPS.Rating(value, comments)
+}
+
+// ThumbsRating returns the parameters for a thumbs up or down rating.
+func ThumbsRating(up bool, comments string) *client.RatingParams {
+	value := 0
+	if up {
+		value = 1
+	}
+	p, _ := Thumbs.Rating(value, comments)
+	return p
+}
+
+var (
+	// A leading number, optionally out of a maximum (e.g. "8/10" or "4 out
+	// of 5"), that isn't the start of a longer word (e.g. "2nd").
+	numberPattern = regexp.MustCompile(`^(\d{1,2})(?:\s*(?:/|out of)\s*(\d{1,2}))?(?:$|[^\p{L}\p{N}])`)
+	starPattern   = regexp.MustCompile(`^(?:[⭐★]\x{FE0F}?)+`)
+	wordPattern   = regexp.MustCompile(`^(?:[\p{L}']+|👍|👎)`)
+)
+
+var numberWords = map[string]int{
+	"zero": 0, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5,
+	"six": 6, "seven": 7, "eight": 8, "nine": 9, "ten": 10,
+}
+
+var thumbsWords = map[string]int{
+	"👍": 1, "yes": 1, "yep": 1, "yeah": 1, "up": 1, "good": 1, "great": 1, "solved": 1,
+	"👎": 0, "no": 0, "nope": 0, "down": 0, "bad": 0, "unsolved": 0,
+}
+
+// negations invert the thumbs word that follows them (e.g. "not good").
+var negations = map[string]bool{"not": true, "never": true}
+
+// idioms start with a thumbs word but don't answer the question (e.g. "no
+// problem" is a response to thanks, not "no, it wasn't solved").
+var idioms = map[string]map[string]bool{
+	"no": {"problem": true, "problems": true, "worries": true, "issue": true, "issues": true, "idea": true, "complaints": true},
+}
+
+// Parse extracts a rating from a free-text reply such as "4 - quick and
+// helpful", "8/10" or "⭐⭐⭐⭐". The rating must be the reply's leading token
+// (or the whole reply), so numbers later in the reply (e.g. "I waited 3 days")
+// are not mistaken for ratings. Whatever text follows the rating is returned
+// as the comments. It returns ErrNoRating if the reply doesn't start with a
+// rating on the survey's scale.
+func (s Survey) Parse(reply string) (value int, comments string, err error) {
+	reply = strings.TrimSpace(reply)
+
+	if m := numberPattern.FindStringSubmatchIndex(reply); m != nil {
+		v, _ := strconv.Atoi(reply[m[2]:m[3]])
+		if m[4] >= 0 {
+			if max, _ := strconv.Atoi(reply[m[4]:m[5]]); max != s.MaxValue {
+				return 0, "", ErrNoRating
+			}
+		}
+		return s.result(v, reply[m[1]:])
+	}
+
+	if m := starPattern.FindStringIndex(reply); m != nil {
+		stars := reply[:m[1]]
+		return s.result(strings.Count(stars, "⭐")+strings.Count(stars, "★"), reply[m[1]:])
+	}
+
+	word, rest := leadingWord(reply)
+	if word == "" {
+		return 0, "", ErrNoRating
+	}
+	if !s.isThumbs() {
+		v, ok := numberWords[word]
+		if !ok {
+			return 0, "", ErrNoRating
+		}
+		return s.result(v, rest)
+	}
+
+	if negations[word] {
+		next, after := leadingWord(rest)
+		v, ok := thumbsWords[next]
+		if !ok {
+			return 0, "", ErrNoRating
+		}
+		return s.result(1-v, after)
+	}
+	if next, _ := leadingWord(rest); idioms[word][next] {
+		return 0, "", ErrNoRating
+	}
+	v, ok := thumbsWords[word]
+	if !ok {
+		return 0, "", ErrNoRating
+	}
+	return s.result(v, rest)
+}
+
+func (s Survey) isThumbs() bool {
+	return s.MinValue == 0 && s.MaxValue == 1
+}
+
+// result returns the rating if it's on the survey's scale.
+func (s Survey) result(value int, rest string) (int, string, error) {
+	if value < s.MinValue || value > s.MaxValue {
+		return 0, "", ErrNoRating
+	}
+	return value, cleanComments(rest), nil
+}
+
+// leadingWord returns the first word of s in lower case, and the text after
+// it.
+func leadingWord(s string) (word, rest string) {
+	s = strings.TrimSpace(s)
+	m := wordPattern.FindStringIndex(s)
+	if m == nil {
+		return "", s
+	}
+	return strings.ToLower(s[:m[1]]), s[m[1]:]
+}
+
+// ParseRating parses a free-text reply and returns the parameters to submit it
+// with Client.RateConversation.
+func (s Survey) ParseRating(reply string) (*client.RatingParams, error) {
+	value, comments, err := s.Parse(reply)
+	if err != nil {
+		return nil, err
+	}
+	return s.Rating(value, comments)
+}
+
+// cleanComments removes the punctuation left behind at the start of a reply
+// once its rating has been removed (e.g. "4 - great" becomes "great").
+func cleanComments(s string) string {
+	return strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(s), "-–—:,.!/ "))
+}
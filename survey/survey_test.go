@@ -0,0 +1,104 @@
+package survey_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gradientlabs-ai/gradientlabs-go/survey"
+)
+
+func TestParse(t *testing.T) {
+	testCases := map[string]struct {
+		survey   survey.Survey
+		reply    string
+		value    int
+		comments string
+		err      error
+	}{
+		"number":                  {survey: survey.CSAT, reply: "4", value: 4},
+		"number with comments":    {survey: survey.CSAT, reply: "4 - quick and helpful", value: 4, comments: "quick and helpful"},
+		"number with punctuation": {survey: survey.CSAT, reply: " 5! Thanks", value: 5, comments: "Thanks"},
+		"out of slash":            {survey: survey.NPS, reply: "8/10 would recommend", value: 8, comments: "would recommend"},
+		"out of words":            {survey: survey.CSAT, reply: "3 out of 5, slow", value: 3, comments: "slow"},
+		"wrong scale":             {survey: survey.CSAT, reply: "8/10", err: survey.ErrNoRating},
+		"out of range":            {survey: survey.CSAT, reply: "7", err: survey.ErrNoRating},
+		"zero on nps":             {survey: survey.NPS, reply: "0", value: 0},
+		"number later in reply":   {survey: survey.CSAT, reply: "I waited 3 days for a reply", err: survey.ErrNoRating},
+		"ordinal":                 {survey: survey.CSAT, reply: "2nd time asking", err: survey.ErrNoRating},
+		"stars":                   {survey: survey.CSAT, reply: "⭐⭐⭐⭐ good", value: 4, comments: "good"},
+		"stars with selectors":    {survey: survey.CSAT, reply: "⭐️⭐️⭐️", value: 3},
+		"black stars":             {survey: survey.CSAT, reply: "★★", value: 2},
+		"too many stars":          {survey: survey.CSAT, reply: "⭐⭐⭐⭐⭐⭐", err: survey.ErrNoRating},
+		"number word":             {survey: survey.CSAT, reply: "Five, brilliant", value: 5, comments: "brilliant"},
+		"number word later":       {survey: survey.CSAT, reply: "It took two weeks", err: survey.ErrNoRating},
+		"thumbs yes":              {survey: survey.Thumbs, reply: "Yes, thanks!", value: 1, comments: "thanks!"},
+		"thumbs no":               {survey: survey.Thumbs, reply: "no - still broken", value: 0, comments: "still broken"},
+		"thumbs emoji":            {survey: survey.Thumbs, reply: "👍", value: 1},
+		"thumbs number":           {survey: survey.Thumbs, reply: "1", value: 1},
+		"not good":                {survey: survey.Thumbs, reply: "not good at all", value: 0, comments: "at all"},
+		"not bad":                 {survey: survey.Thumbs, reply: "Not bad", value: 1},
+		"not solved":              {survey: survey.Thumbs, reply: "never solved.", value: 0},
+		"negated other word":      {survey: survey.Thumbs, reply: "not sure", err: survey.ErrNoRating},
+		"no problem":              {survey: survey.Thumbs, reply: "No problem, thanks", err: survey.ErrNoRating},
+		"no worries":              {survey: survey.Thumbs, reply: "no worries", err: survey.ErrNoRating},
+		"no with comma":           {survey: survey.Thumbs, reply: "No, problem is still there", value: 0, comments: "problem is still there"},
+		"thumbs word later":       {survey: survey.Thumbs, reply: "it was good", err: survey.ErrNoRating},
+		"thumbs word on csat":     {survey: survey.CSAT, reply: "good", err: survey.ErrNoRating},
+		"empty":                   {survey: survey.CSAT, reply: "  ", err: survey.ErrNoRating},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			value, comments, err := tc.survey.Parse(tc.reply)
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("Parse(%q) error = %v, want %v", tc.reply, err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tc.reply, err)
+			}
+			if value != tc.value || comments != tc.comments {
+				t.Errorf("Parse(%q) = %d, %q, want %d, %q", tc.reply, value, comments, tc.value, tc.comments)
+			}
+		})
+	}
+}
+
+func TestParseRating(t *testing.T) {
+	p, err := survey.NPS.ParseRating("9 out of 10 - great")
+	if err != nil {
+		t.Fatalf("ParseRating: %v", err)
+	}
+	if p.SurveyType != "nps" || p.Value != 9 || p.MinValue != 0 || p.MaxValue != 10 || p.Comments != "great" || p.Timestamp == nil {
+		t.Errorf("params = %+v", p)
+	}
+
+	if _, err := survey.NPS.ParseRating("no idea"); !errors.Is(err, survey.ErrNoRating) {
+		t.Errorf("ParseRating error = %v", err)
+	}
+}
+
+func TestRating(t *testing.T) {
+	if _, err := survey.CSATRating(0, ""); err == nil {
+		t.Error("expected an error for a CSAT rating of 0")
+	}
+	if p, err := survey.NPSRating(10, "  great  "); err != nil || p.Comments != "great" {
+		t.Errorf("NPSRating = %+v, %v", p, err)
+	}
+	if p := survey.ThumbsRating(true, ""); p.Value != 1 || p.SurveyType != "thumbs" {
+		t.Errorf("ThumbsRating = %+v", p)
+	}
+
+	invalid := map[string]survey.Survey{
+		"no type":     {MinValue: 1, MaxValue: 5},
+		"empty scale": {Type: "x", MinValue: 3, MaxValue: 3},
+		"backwards":   {Type: "x", MinValue: 5, MaxValue: 1},
+	}
+	for name, s := range invalid {
+		if err := s.Validate(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
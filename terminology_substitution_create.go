@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 )
 
@@ -19,19 +18,9 @@ type TerminologySubstitutionCreateParams struct {
 // CreateTerminologySubstitution creates a new terminology substitution.
 //
 // Note: requires a Management API key.
-func (c *Client) CreateTerminologySubstitution(ctx context.Context, p *TerminologySubstitutionCreateParams) (*TerminologySubstitution, error) {
-	rsp, err := c.makeRequest(ctx, http.MethodPost, "terminology-substitutions", p)
-	if err != nil {
-		return nil, err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return nil, err
-	}
-
+func (c *Client) CreateTerminologySubstitution(ctx context.Context, p *TerminologySubstitutionCreateParams, opts ...CallOption) (*TerminologySubstitution, error) {
 	var sub TerminologySubstitution
-	if err := json.NewDecoder(rsp.Body).Decode(&sub); err != nil {
+	if err := c.do(ctx, "CreateTerminologySubstitution", http.MethodPost, "terminology-substitutions", p, &sub, opts...); err != nil {
 		return nil, err
 	}
 	return &sub, nil
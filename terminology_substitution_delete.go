@@ -9,15 +9,6 @@ import (
 // DeleteTerminologySubstitution removes a terminology substitution.
 //
 // Note: requires a Management API key.
-func (c *Client) DeleteTerminologySubstitution(ctx context.Context, id string) error {
-	rsp, err := c.makeRequest(ctx, http.MethodDelete, fmt.Sprintf("terminology-substitutions/%s", id), nil)
-	if err != nil {
-		return err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return err
-	}
-	return nil
+func (c *Client) DeleteTerminologySubstitution(ctx context.Context, id string, opts ...CallOption) error {
+	return c.do(ctx, "DeleteTerminologySubstitution", http.MethodDelete, fmt.Sprintf("terminology-substitutions/%s", id), nil, nil, opts...)
 }
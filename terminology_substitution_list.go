@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 )
 
@@ -15,19 +14,9 @@ type TerminologySubstitutionListResponse struct {
 // for your company.
 //
 // Note: requires a Management API key.
-func (c *Client) ListTerminologySubstitutions(ctx context.Context) (*TerminologySubstitutionListResponse, error) {
-	rsp, err := c.makeRequest(ctx, http.MethodGet, "terminology-substitutions", nil)
-	if err != nil {
-		return nil, err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return nil, err
-	}
-
+func (c *Client) ListTerminologySubstitutions(ctx context.Context, opts ...CallOption) (*TerminologySubstitutionListResponse, error) {
 	var result TerminologySubstitutionListResponse
-	if err := json.NewDecoder(rsp.Body).Decode(&result); err != nil {
+	if err := c.do(ctx, "ListTerminologySubstitutions", http.MethodGet, "terminology-substitutions", nil, &result, opts...); err != nil {
 		return nil, err
 	}
 	return &result, nil
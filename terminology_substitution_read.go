@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 )
@@ -10,19 +9,9 @@ import (
 // ReadTerminologySubstitution retrieves a single terminology substitution by ID.
 //
 // Note: requires a Management API key.
-func (c *Client) ReadTerminologySubstitution(ctx context.Context, id string) (*TerminologySubstitution, error) {
-	rsp, err := c.makeRequest(ctx, http.MethodGet, fmt.Sprintf("terminology-substitutions/%s", id), nil)
-	if err != nil {
-		return nil, err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return nil, err
-	}
-
+func (c *Client) ReadTerminologySubstitution(ctx context.Context, id string, opts ...CallOption) (*TerminologySubstitution, error) {
 	var sub TerminologySubstitution
-	if err := json.NewDecoder(rsp.Body).Decode(&sub); err != nil {
+	if err := c.do(ctx, "ReadTerminologySubstitution", http.MethodGet, fmt.Sprintf("terminology-substitutions/%s", id), nil, &sub, opts...); err != nil {
 		return nil, err
 	}
 	return &sub, nil
@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 )
@@ -20,19 +19,9 @@ type TerminologySubstitutionUpdateParams struct {
 // UpdateTerminologySubstitution replaces an existing terminology substitution.
 //
 // Note: requires a Management API key.
-func (c *Client) UpdateTerminologySubstitution(ctx context.Context, id string, p *TerminologySubstitutionUpdateParams) (*TerminologySubstitution, error) {
-	rsp, err := c.makeRequest(ctx, http.MethodPut, fmt.Sprintf("terminology-substitutions/%s", id), p)
-	if err != nil {
-		return nil, err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return nil, err
-	}
-
+func (c *Client) UpdateTerminologySubstitution(ctx context.Context, id string, p *TerminologySubstitutionUpdateParams, opts ...CallOption) (*TerminologySubstitution, error) {
 	var sub TerminologySubstitution
-	if err := json.NewDecoder(rsp.Body).Decode(&sub); err != nil {
+	if err := c.do(ctx, "UpdateTerminologySubstitution", http.MethodPut, fmt.Sprintf("terminology-substitutions/%s", id), p, &sub, opts...); err != nil {
 		return nil, err
 	}
 	return &sub, nil
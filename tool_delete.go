@@ -10,15 +10,6 @@ import (
 // Note: You can't delete a tool that is currently in use in a live procedure.
 //
 // Note: requires a `Management` API key.
-func (c *Client) DeleteTool(ctx context.Context, toolID string) error {
-	rsp, err := c.makeRequest(ctx, http.MethodDelete, fmt.Sprintf("tools/%s", toolID), nil)
-	if err != nil {
-		return err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return err
-	}
-	return nil
+func (c *Client) DeleteTool(ctx context.Context, toolID string, opts ...CallOption) error {
+	return c.do(ctx, "DeleteTool", http.MethodDelete, fmt.Sprintf("tools/%s", toolID), nil, nil, opts...)
 }
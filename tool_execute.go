@@ -39,19 +39,9 @@ type ExecuteResult struct {
 // test a tool end-to-end.
 //
 // Note: requires a `Management` API key.
-func (c *Client) ExecuteTool(ctx context.Context, p *ExecutionParams) (*ExecuteResult, error) {
-	rsp, err := c.makeRequest(ctx, http.MethodPost, fmt.Sprintf("tools/%s/execute", p.ID), p)
-	if err != nil {
-		return nil, err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return nil, err
-	}
-
+func (c *Client) ExecuteTool(ctx context.Context, p *ExecutionParams, opts ...CallOption) (*ExecuteResult, error) {
 	var toolResponse ExecuteResult
-	if err := json.NewDecoder(rsp.Body).Decode(&toolResponse); err != nil {
+	if err := c.do(ctx, "ExecuteTool", http.MethodPost, fmt.Sprintf("tools/%s/execute", p.ID), p, &toolResponse, opts...); err != nil {
 		return nil, err
 	}
 	return &toolResponse, nil
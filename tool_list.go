@@ -2,26 +2,15 @@ package client
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 )
 
 // ListTools retrieves all tools.
 //
 // Note: requires a `Management` API key.
-func (c *Client) ListTools(ctx context.Context) ([]*Tool, error) {
-	rsp, err := c.makeRequest(ctx, http.MethodGet, "tools", nil)
-	if err != nil {
-		return nil, err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return nil, err
-	}
-
+func (c *Client) ListTools(ctx context.Context, opts ...CallOption) ([]*Tool, error) {
 	var toolList ToolList
-	if err := json.NewDecoder(rsp.Body).Decode(&toolList); err != nil {
+	if err := c.do(ctx, "ListTools", http.MethodGet, "tools", nil, &toolList, opts...); err != nil {
 		return nil, err
 	}
 
@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 )
@@ -20,24 +19,14 @@ type ReadToolParams struct {
 // Pass nil (or a zero-value ReadToolParams) to retrieve the latest version.
 //
 // Note: requires a `Management` API key.
-func (c *Client) ReadTool(ctx context.Context, toolID string, p *ReadToolParams) (*Tool, error) {
+func (c *Client) ReadTool(ctx context.Context, toolID string, p *ReadToolParams, opts ...CallOption) (*Tool, error) {
 	path := fmt.Sprintf("tools/%s", toolID)
 	if p != nil && p.Version > 0 {
 		path = fmt.Sprintf("%s?version=%d", path, p.Version)
 	}
 
-	rsp, err := c.makeRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return nil, err
-	}
-
 	var tool Tool
-	if err := json.NewDecoder(rsp.Body).Decode(&tool); err != nil {
+	if err := c.do(ctx, "ReadTool", http.MethodGet, path, nil, &tool, opts...); err != nil {
 		return nil, err
 	}
 	return &tool, nil
@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 )
@@ -20,19 +19,9 @@ type UpdateToolParams struct {
 // into real tools, but not the other way around.
 //
 // Note: requires a `Management` API key.
-func (c *Client) UpdateTool(ctx context.Context, p *UpdateToolParams) (*Tool, error) {
-	rsp, err := c.makeRequest(ctx, http.MethodPut, fmt.Sprintf("tools/%s", p.ID), p)
-	if err != nil {
-		return nil, err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return nil, err
-	}
-
+func (c *Client) UpdateTool(ctx context.Context, p *UpdateToolParams, opts ...CallOption) (*Tool, error) {
 	var tool Tool
-	if err := json.NewDecoder(rsp.Body).Decode(&tool); err != nil {
+	if err := c.do(ctx, "UpdateTool", http.MethodPut, fmt.Sprintf("tools/%s", p.ID), p, &tool, opts...); err != nil {
 		return nil, err
 	}
 	return &tool, nil
@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 )
 
@@ -15,19 +14,9 @@ type CreateTrafficGroupParams struct {
 // CreateTrafficGroup creates a new traffic group.
 //
 // Note: requires a `Management` API key.
-func (c *Client) CreateTrafficGroup(ctx context.Context, p *CreateTrafficGroupParams) (*TrafficGroup, error) {
-	rsp, err := c.makeRequest(ctx, http.MethodPost, "traffic-groups", p)
-	if err != nil {
-		return nil, err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return nil, err
-	}
-
+func (c *Client) CreateTrafficGroup(ctx context.Context, p *CreateTrafficGroupParams, opts ...CallOption) (*TrafficGroup, error) {
 	var group TrafficGroup
-	if err := json.NewDecoder(rsp.Body).Decode(&group); err != nil {
+	if err := c.do(ctx, "CreateTrafficGroup", http.MethodPost, "traffic-groups", p, &group, opts...); err != nil {
 		return nil, err
 	}
 	return &group, nil
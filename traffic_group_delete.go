@@ -9,15 +9,6 @@ import (
 // DeleteTrafficGroup deletes a traffic group and all associated targets.
 //
 // Note: requires a `Management` API key.
-func (c *Client) DeleteTrafficGroup(ctx context.Context, trafficGroupID string) error {
-	rsp, err := c.makeRequest(ctx, http.MethodDelete, fmt.Sprintf("traffic-groups/%s", trafficGroupID), nil)
-	if err != nil {
-		return err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return err
-	}
-	return nil
+func (c *Client) DeleteTrafficGroup(ctx context.Context, trafficGroupID string, opts ...CallOption) error {
+	return c.do(ctx, "DeleteTrafficGroup", http.MethodDelete, fmt.Sprintf("traffic-groups/%s", trafficGroupID), nil, nil, opts...)
 }
@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 )
@@ -22,19 +21,9 @@ type CreateTrafficGroupExclusionParams struct {
 // CreateTrafficGroupExclusion excludes a target from a traffic group.
 //
 // Note: requires a Management API key.
-func (c *Client) CreateTrafficGroupExclusion(ctx context.Context, p *CreateTrafficGroupExclusionParams) (*TrafficGroupTarget, error) {
-	rsp, err := c.makeRequest(ctx, http.MethodPost, fmt.Sprintf("traffic-groups/%s/exclusions", p.GroupID), p)
-	if err != nil {
-		return nil, err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return nil, err
-	}
-
+func (c *Client) CreateTrafficGroupExclusion(ctx context.Context, p *CreateTrafficGroupExclusionParams, opts ...CallOption) (*TrafficGroupTarget, error) {
 	var target TrafficGroupTarget
-	if err := json.NewDecoder(rsp.Body).Decode(&target); err != nil {
+	if err := c.do(ctx, "CreateTrafficGroupExclusion", http.MethodPost, fmt.Sprintf("traffic-groups/%s/exclusions", p.GroupID), p, &target, opts...); err != nil {
 		return nil, err
 	}
 	return &target, nil
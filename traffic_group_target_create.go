@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 )
@@ -22,19 +21,9 @@ type CreateTrafficGroupTargetParams struct {
 // CreateTrafficGroupTarget adds a target to a traffic group.
 //
 // Note: requires a `Management` API key.
-func (c *Client) CreateTrafficGroupTarget(ctx context.Context, p *CreateTrafficGroupTargetParams) (*TrafficGroupTarget, error) {
-	rsp, err := c.makeRequest(ctx, http.MethodPost, fmt.Sprintf("traffic-groups/%s/targets", p.GroupID), p)
-	if err != nil {
-		return nil, err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return nil, err
-	}
-
+func (c *Client) CreateTrafficGroupTarget(ctx context.Context, p *CreateTrafficGroupTargetParams, opts ...CallOption) (*TrafficGroupTarget, error) {
 	var target TrafficGroupTarget
-	if err := json.NewDecoder(rsp.Body).Decode(&target); err != nil {
+	if err := c.do(ctx, "CreateTrafficGroupTarget", http.MethodPost, fmt.Sprintf("traffic-groups/%s/targets", p.GroupID), p, &target, opts...); err != nil {
 		return nil, err
 	}
 	return &target, nil
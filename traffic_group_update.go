@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 )
@@ -19,19 +18,9 @@ type UpdateTrafficGroupParams struct {
 // UpdateTrafficGroup updates an existing traffic group.
 //
 // Note: requires a `Management` API key.
-func (c *Client) UpdateTrafficGroup(ctx context.Context, p *UpdateTrafficGroupParams) (*TrafficGroup, error) {
-	rsp, err := c.makeRequest(ctx, http.MethodPut, fmt.Sprintf("traffic-groups/%s", p.ID), p)
-	if err != nil {
-		return nil, err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return nil, err
-	}
-
+func (c *Client) UpdateTrafficGroup(ctx context.Context, p *UpdateTrafficGroupParams, opts ...CallOption) (*TrafficGroup, error) {
 	var group TrafficGroup
-	if err := json.NewDecoder(rsp.Body).Decode(&group); err != nil {
+	if err := c.do(ctx, "UpdateTrafficGroup", http.MethodPut, fmt.Sprintf("traffic-groups/%s", p.ID), p, &group, opts...); err != nil {
 		return nil, err
 	}
 	return &group, nil
@@ -0,0 +1,134 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const transcriptTimeFormat = "2006-01-02 15:04:05 MST"
+
+// WriteJSON writes the transcript as indented JSON.
+func (t *Transcript) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(t)
+}
+
+// WriteMarkdown writes the transcript as a Markdown document, suitable for
+// audits and hand-off notes.
+func (t *Transcript) WriteMarkdown(w io.Writer) error {
+	b := new(strings.Builder)
+
+	_, _ = fmt.Fprintf(b, "# Conversation %s\n\n", t.Conversation.ID)
+	_, _ = fmt.Fprintf(b, "- **Customer:** %s\n", t.Conversation.CustomerID)
+	_, _ = fmt.Fprintf(b, "- **Channel:** %s\n", t.Conversation.Channel)
+	_, _ = fmt.Fprintf(b, "- **Status:** %s\n", t.Conversation.Status)
+	if !t.Conversation.Created.IsZero() {
+		_, _ = fmt.Fprintf(b, "- **Created:** %s\n", formatTranscriptTime(t.Conversation.Created))
+	}
+	if md := t.Conversation.AgentMetadata; md != nil {
+		if md.Intent != "" {
+			_, _ = fmt.Fprintf(b, "- **Intent:** %s\n", md.Intent)
+		}
+		if md.HandOffReason != "" {
+			_, _ = fmt.Fprintf(b, "- **Hand-off reason:** %s\n", md.HandOffReason)
+		}
+	}
+	_, _ = b.WriteString("\n## Transcript\n")
+
+	for _, e := range t.Entries() {
+		switch {
+		case e.Message != nil:
+			m := e.Message
+			_, _ = fmt.Fprintf(b, "\n**%s** (%s)", participantLabel(m.ParticipantType, m.ParticipantID), formatTranscriptTime(e.Timestamp))
+			if m.Subject != "" {
+				_, _ = fmt.Fprintf(b, " — _%s_", m.Subject)
+			}
+			_, _ = b.WriteString("\n\n")
+			for _, line := range strings.Split(m.Body, "\n") {
+				_, _ = fmt.Fprintf(b, "> %s\n", line)
+			}
+			for _, a := range m.Attachment {
+				_, _ = fmt.Fprintf(b, ">\n> _Attachment: %s_\n", a.FileName)
+			}
+		case e.Event != nil:
+			_, _ = fmt.Fprintf(b, "\n_%s — %s_\n", formatTranscriptTime(e.Timestamp), describeTranscriptEvent(e.Event))
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteText writes the transcript as plain text, with one line per message or
+// event.
+func (t *Transcript) WriteText(w io.Writer) error {
+	b := new(strings.Builder)
+
+	_, _ = fmt.Fprintf(b, "Conversation %s (customer: %s, channel: %s, status: %s)\n\n",
+		t.Conversation.ID, t.Conversation.CustomerID, t.Conversation.Channel, t.Conversation.Status)
+
+	for _, e := range t.Entries() {
+		switch {
+		case e.Message != nil:
+			m := e.Message
+			body := strings.ReplaceAll(m.Body, "\n", "\n    ")
+			_, _ = fmt.Fprintf(b, "[%s] %s: %s\n", formatTranscriptTime(e.Timestamp), participantLabel(m.ParticipantType, m.ParticipantID), body)
+		case e.Event != nil:
+			_, _ = fmt.Fprintf(b, "[%s] * %s\n", formatTranscriptTime(e.Timestamp), describeTranscriptEvent(e.Event))
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func describeTranscriptEvent(e *TranscriptEvent) string {
+	who := participantLabel(e.ParticipantType, e.ParticipantID)
+
+	var desc string
+	switch e.Type {
+	case TranscriptEventType(ConversationEventInternalNote):
+		desc = fmt.Sprintf("internal note from %s", who)
+	case TranscriptEventAssigned:
+		desc = fmt.Sprintf("assigned to %s", who)
+	case TranscriptEventHandOff:
+		desc = "handed off"
+	default:
+		desc = string(e.Type)
+		if e.ParticipantType != "" || e.ParticipantID != "" {
+			desc += " by " + who
+		}
+	}
+
+	if e.Reason != "" {
+		desc += fmt.Sprintf(" (%s)", e.Reason)
+	}
+	if e.Body != "" {
+		desc += ": " + strings.ReplaceAll(e.Body, "\n", " ")
+	}
+	return desc
+}
+
+func participantLabel(typ ParticipantType, id string) string {
+	switch {
+	case typ == "" && id == "":
+		return "Unknown"
+	case id == "" || typ == ParticipantTypeAIAgent:
+		return string(typ)
+	case typ == "":
+		return id
+	default:
+		return fmt.Sprintf("%s %s", typ, id)
+	}
+}
+
+func formatTranscriptTime(t time.Time) string {
+	if t.IsZero() {
+		return "unknown time"
+	}
+	return t.UTC().Format(transcriptTimeFormat)
+}
@@ -0,0 +1,122 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testTranscript() *Transcript {
+	at := func(min int) time.Time {
+		return time.Date(2024, 1, 1, 12, min, 0, 0, time.UTC)
+	}
+	msg := func(id string, typ ParticipantType, participant, body string, min int) *Message {
+		created := at(min)
+		return &Message{ID: id, Body: body, ParticipantID: participant, ParticipantType: typ, Created: &created}
+	}
+
+	return &Transcript{
+		Conversation: Conversation{ID: "conv-1", CustomerID: "cust-1", Channel: ChannelChat, Status: StatusObserving},
+		Messages: []*Message{
+			msg("m1", ParticipantTypeCustomer, "cust-1", "Where is my card?\nIt's been a week.", 0),
+			msg("m2", ParticipantTypeAIAgent, "", "Let me check.", 1),
+			msg("m3", ParticipantTypeHumanAgent, "agent-7", "I've reissued it.", 5),
+		},
+		Events: []*TranscriptEvent{
+			{Type: TranscriptEventHandOff, Reason: "card-reissue", Timestamp: at(2)},
+			{Type: TranscriptEventAssigned, ParticipantType: ParticipantTypeHumanAgent, ParticipantID: "agent-7", Timestamp: at(3)},
+		},
+	}
+}
+
+func TestTranscriptEntries(t *testing.T) {
+	entries := testTranscript().Entries()
+
+	var order []string
+	for _, e := range entries {
+		if e.Message != nil {
+			order = append(order, e.Message.ID)
+		} else {
+			order = append(order, string(e.Event.Type))
+		}
+	}
+	if got := strings.Join(order, ","); got != "m1,m2,hand-off,assigned,m3" {
+		t.Errorf("order = %s", got)
+	}
+}
+
+func TestTranscriptWriteText(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testTranscript().WriteText(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `Conversation conv-1 (customer: cust-1, channel: web, status: observing)
+
+[2024-01-01 12:00:00 UTC] Customer cust-1: Where is my card?
+    It's been a week.
+[2024-01-01 12:01:00 UTC] AI Agent: Let me check.
+[2024-01-01 12:02:00 UTC] * handed off (card-reissue)
+[2024-01-01 12:03:00 UTC] * assigned to Agent agent-7
+[2024-01-01 12:05:00 UTC] Agent agent-7: I've reissued it.
+`
+	if got := buf.String(); got != want {
+		t.Errorf("WriteText =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestTranscriptWriteMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testTranscript().WriteMarkdown(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"# Conversation conv-1\n",
+		"- **Customer:** cust-1\n",
+		"**Customer cust-1** (2024-01-01 12:00:00 UTC)\n\n> Where is my card?\n> It's been a week.\n",
+		"_2024-01-01 12:02:00 UTC — handed off (card-reissue)_",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("WriteMarkdown output is missing %q:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestTranscriptWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testTranscript().WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	var got Transcript
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Messages) != 3 || len(got.Events) != 2 || got.Conversation.ID != "conv-1" {
+		t.Errorf("decoded transcript = %+v", got)
+	}
+}
+
+func TestReadConversationTranscript(t *testing.T) {
+	var gotURL string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		gotURL = r.URL.String()
+		writeJSON(w, http.StatusOK, `{"conversation":{"id":"conv-1"},"messages":[],"events":[]}`)
+	})
+
+	tr, err := c.ReadConversationTranscript(context.Background(), "conv-1", &ReadTranscriptParams{SupportPlatform: "intercom"})
+	if err != nil {
+		t.Fatalf("ReadConversationTranscript: %v", err)
+	}
+	if tr.Conversation.ID != "conv-1" {
+		t.Errorf("ID = %q", tr.Conversation.ID)
+	}
+	if gotURL != "/conversations/conv-1/transcript?support_platform=intercom" {
+		t.Errorf("URL = %s", gotURL)
+	}
+}
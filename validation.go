@@ -0,0 +1,158 @@
+package client
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ValidationProblem describes a single problem with a request's parameters.
+type ValidationProblem struct {
+	// Field is the JSON name of the offending field (e.g. "customer_source").
+	Field string
+
+	// Message describes the problem.
+	Message string
+}
+
+// ValidationError is returned when a request's parameters are invalid, before
+// the request is sent. It lists every problem found, rather than just the first.
+type ValidationError struct {
+	// Operation is the name of the Client method being called (e.g.
+	// "StartOutboundConversation").
+	Operation string
+
+	// Problems contains each of the problems found.
+	Problems []ValidationProblem
+}
+
+// Error satisfies the error interface.
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Problems))
+	for i, p := range e.Problems {
+		msgs[i] = fmt.Sprintf("%s: %s", p.Field, p.Message)
+	}
+	return fmt.Sprintf("invalid %s parameters: %s", e.Operation, strings.Join(msgs, "; "))
+}
+
+// validator collects the problems found with a request's parameters.
+type validator struct {
+	problems []ValidationProblem
+}
+
+func (v *validator) add(field, format string, args ...any) {
+	v.problems = append(v.problems, ValidationProblem{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) required(field, value string) {
+	if value == "" {
+		v.add(field, "is required")
+	}
+}
+
+func (v *validator) err(op string) error {
+	if len(v.problems) == 0 {
+		return nil
+	}
+	return &ValidationError{Operation: op, Problems: v.problems}
+}
+
+var idPattern = regexp.MustCompile(`^[A-Za-z0-9_\-+=]+$`)
+
+// knownChannels are the channels this version of the client knows about.
+var knownChannels = map[Channel]bool{
+	ChannelChat:  true,
+	ChannelEmail: true,
+	ChannelVoice: true,
+}
+
+// voiceCustomerSources are the customer sources for voice calls.
+var voiceCustomerSources = map[CustomerSource]bool{
+	CustomerSourceVoice:         true,
+	CustomerSourceVoiceTwilio:   true,
+	CustomerSourceVoiceTalkdesk: true,
+	CustomerSourceVoiceIntercom: true,
+}
+
+// sourcePlatforms maps customer sources that belong to a support platform to
+// that platform. Other sources (e.g. the public API) can be used with any.
+var sourcePlatforms = map[CustomerSource]SupportPlatform{
+	CustomerSourceIntercom:      SupportPlatformIntercom,
+	CustomerSourceVoiceIntercom: SupportPlatformIntercom,
+	CustomerSourceFreshchat:     SupportPlatformFreshchat,
+	CustomerSourceFreshdesk:     SupportPlatformFreshdesk,
+	CustomerSourceSalesforce:    SupportPlatformSalesforce,
+	CustomerSourceZendesk:       SupportPlatformZendesk,
+}
+
+// platformChannels lists the channels supported by support platforms that
+// don't support every channel.
+var platformChannels = map[SupportPlatform][]Channel{
+	SupportPlatformFreshchat: {ChannelChat},
+	SupportPlatformFreshdesk: {ChannelEmail},
+}
+
+// checkCompatibility checks that a customer source, support platform and
+// channel can be used together. An empty channel is checked as email, which
+// the API defaults to. Other empty values are not checked, and nor are values
+// this version of the client doesn't know about.
+func (v *validator) checkCompatibility(source CustomerSource, platform SupportPlatform, channel Channel) {
+	effective, got := channel, fmt.Sprintf("%q", channel)
+	if channel == "" {
+		effective, got = ChannelEmail, fmt.Sprintf("none (which defaults to %q)", ChannelEmail)
+	}
+	known := knownChannels[effective]
+
+	if voiceCustomerSources[source] && known && effective != ChannelVoice {
+		v.add("channel", "must be %q for customer source %q, got %s", ChannelVoice, source, got)
+	}
+
+	if want, ok := sourcePlatforms[source]; ok && platform != "" && platform != want {
+		v.add("support_platform", "must be %q for customer source %q, got %q", want, source, platform)
+	}
+
+	if supported, ok := platformChannels[platform]; ok && known {
+		found := false
+		for _, c := range supported {
+			found = found || c == effective
+		}
+		if !found {
+			v.add("support_platform", "%q does not support channel %s", platform, got)
+		}
+	}
+}
+
+// Validate checks the parameters before the conversation is started. It is
+// called by Client.StartConversation, and returns a *ValidationError listing
+// every problem found.
+func (p StartConversationParams) Validate() error {
+	var v validator
+
+	v.required("id", p.ID)
+	if p.ID != "" && !idPattern.MatchString(p.ID) {
+		v.add("id", "may only contain letters, numbers, and any of: _ - + =")
+	}
+	v.required("customer_id", p.CustomerID)
+	v.required("channel", string(p.Channel))
+	if p.AssigneeType == ParticipantTypeCustomer {
+		v.add("assignee_type", "cannot be %q", ParticipantTypeCustomer)
+	}
+
+	return v.err("StartConversation")
+}
+
+// Validate checks the parameters before the outbound conversation is started,
+// including that the customer source, support platform and channel can be used
+// together. It is called by Client.StartOutboundConversation, and returns a
+// *ValidationError listing every problem found.
+func (p StartOutboundConversationParams) Validate() error {
+	var v validator
+
+	v.required("customer_id", p.CustomerID)
+	v.required("customer_source", string(p.CustomerSource))
+	v.required("procedure_id", p.ProcedureID)
+
+	v.checkCompatibility(p.CustomerSource, p.SupportPlatform, p.Channel)
+
+	return v.err("StartOutboundConversation")
+}
@@ -0,0 +1,197 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestStartOutboundConversationParamsValidate(t *testing.T) {
+	valid := func() StartOutboundConversationParams {
+		return StartOutboundConversationParams{
+			CustomerID:     "cust-1",
+			CustomerSource: CustomerSourcePublicAPI,
+			ProcedureID:    "proc-1",
+		}
+	}
+
+	testCases := map[string]struct {
+		modify   func(*StartOutboundConversationParams)
+		problems []ValidationProblem
+	}{
+		"valid": {
+			modify: func(*StartOutboundConversationParams) {},
+		},
+		"subject without body": {
+			modify: func(p *StartOutboundConversationParams) { p.Subject = "Your order" },
+		},
+		"missing required fields": {
+			modify: func(p *StartOutboundConversationParams) { *p = StartOutboundConversationParams{} },
+			problems: []ValidationProblem{
+				{Field: "customer_id", Message: "is required"},
+				{Field: "customer_source", Message: "is required"},
+				{Field: "procedure_id", Message: "is required"},
+			},
+		},
+		"unknown channel": {
+			modify: func(p *StartOutboundConversationParams) { p.Channel = "fax" },
+		},
+		"unknown channel with voice source and restricted platform": {
+			modify: func(p *StartOutboundConversationParams) {
+				p.CustomerSource = CustomerSourceVoiceTwilio
+				p.SupportPlatform = SupportPlatformFreshdesk
+				p.Channel = "fax"
+			},
+		},
+		"voice source with voice channel": {
+			modify: func(p *StartOutboundConversationParams) {
+				p.CustomerSource = CustomerSourceVoiceTwilio
+				p.Channel = ChannelVoice
+			},
+		},
+		"voice source with chat channel": {
+			modify: func(p *StartOutboundConversationParams) {
+				p.CustomerSource = CustomerSourceVoiceTwilio
+				p.Channel = ChannelChat
+			},
+			problems: []ValidationProblem{
+				{Field: "channel", Message: `must be "voice" for customer source "twilio", got "web"`},
+			},
+		},
+		"voice source without channel": {
+			modify: func(p *StartOutboundConversationParams) { p.CustomerSource = CustomerSourceVoice },
+			problems: []ValidationProblem{
+				{Field: "channel", Message: `must be "voice" for customer source "livekit", got none (which defaults to "email")`},
+			},
+		},
+		"source matches platform": {
+			modify: func(p *StartOutboundConversationParams) {
+				p.CustomerSource = CustomerSourceFreshchat
+				p.SupportPlatform = SupportPlatformFreshchat
+				p.Channel = ChannelChat
+			},
+		},
+		"source from another platform": {
+			modify: func(p *StartOutboundConversationParams) {
+				p.CustomerSource = CustomerSourceFreshchat
+				p.SupportPlatform = SupportPlatformZendesk
+			},
+			problems: []ValidationProblem{
+				{Field: "support_platform", Message: `must be "freshchat" for customer source "freshchat", got "zendesk"`},
+			},
+		},
+		"intercom voice on intercom": {
+			modify: func(p *StartOutboundConversationParams) {
+				p.CustomerSource = CustomerSourceVoiceIntercom
+				p.SupportPlatform = SupportPlatformIntercom
+				p.Channel = ChannelVoice
+			},
+		},
+		"platform independent source": {
+			modify: func(p *StartOutboundConversationParams) { p.SupportPlatform = SupportPlatformZendesk },
+		},
+		"platform without channel support": {
+			modify: func(p *StartOutboundConversationParams) {
+				p.SupportPlatform = SupportPlatformFreshdesk
+				p.Channel = ChannelChat
+			},
+			problems: []ValidationProblem{
+				{Field: "support_platform", Message: `"freshdesk" does not support channel "web"`},
+			},
+		},
+		"platform without default channel support": {
+			modify: func(p *StartOutboundConversationParams) { p.SupportPlatform = SupportPlatformFreshchat },
+			problems: []ValidationProblem{
+				{Field: "support_platform", Message: `"freshchat" does not support channel none (which defaults to "email")`},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			p := valid()
+			tc.modify(&p)
+
+			err := p.Validate()
+			if len(tc.problems) == 0 {
+				if err != nil {
+					t.Fatalf("Validate = %v, want nil", err)
+				}
+				return
+			}
+
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("Validate = %v, want a *ValidationError", err)
+			}
+			if !reflect.DeepEqual(verr.Problems, tc.problems) {
+				t.Errorf("problems = %+v, want %+v", verr.Problems, tc.problems)
+			}
+		})
+	}
+}
+
+func TestStartConversationParamsValidate(t *testing.T) {
+	testCases := map[string]struct {
+		params   StartConversationParams
+		problems []ValidationProblem
+	}{
+		"valid": {
+			params: StartConversationParams{ID: "conv-1", CustomerID: "cust-1", Channel: ChannelChat},
+		},
+		"unknown channel": {
+			params: StartConversationParams{ID: "conv-1", CustomerID: "cust-1", Channel: "fax"},
+		},
+		"invalid": {
+			params: StartConversationParams{ID: "conv 1", AssigneeType: ParticipantTypeCustomer},
+			problems: []ValidationProblem{
+				{Field: "id", Message: "may only contain letters, numbers, and any of: _ - + ="},
+				{Field: "customer_id", Message: "is required"},
+				{Field: "channel", Message: "is required"},
+				{Field: "assignee_type", Message: `cannot be "Customer"`},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.params.Validate()
+			if len(tc.problems) == 0 {
+				if err != nil {
+					t.Fatalf("Validate = %v, want nil", err)
+				}
+				return
+			}
+
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("Validate = %v, want a *ValidationError", err)
+			}
+			if !reflect.DeepEqual(verr.Problems, tc.problems) {
+				t.Errorf("problems = %+v, want %+v", verr.Problems, tc.problems)
+			}
+		})
+	}
+}
+
+func TestStartOutboundConversationValidatesBeforeSending(t *testing.T) {
+	var called bool
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		writeJSON(w, http.StatusOK, `{"conversation_id":"conv-1"}`)
+	})
+
+	_, err := c.StartOutboundConversation(context.Background(), StartOutboundConversationParams{
+		CustomerID:      "cust-1",
+		CustomerSource:  CustomerSourceFreshchat,
+		SupportPlatform: SupportPlatformZendesk,
+		ProcedureID:     "proc-1",
+	})
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("StartOutboundConversation = %v, want a *ValidationError", err)
+	}
+	if called {
+		t.Error("the request was sent despite the parameters being invalid")
+	}
+}
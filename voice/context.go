@@ -0,0 +1,141 @@
+// Package voice helps IVR and telephony bridges hand calls handled by the AI
+// agent over to human agents, using the context and transcript of the
+// customer's most recent call.
+package voice
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	client "github.com/gradientlabs-ai/gradientlabs-go"
+)
+
+// ResourceName is the name of the resource that call context is attached to
+// conversations as.
+const ResourceName = "voice_call"
+
+const defaultPollInterval = 2 * time.Second
+
+// ContextReader is the subset of client.API used to read call context.
+type ContextReader interface {
+	ReadLatestVoiceCallContext(ctx context.Context, phoneNumber string, p *client.ReadVoiceCallContextParams, opts ...client.CallOption) (*client.VoiceCallContext, error)
+}
+
+// Resource returns the call context as a resource, including its summary and
+// transcript, for the agent (human or AI) picking up the conversation.
+func Resource(vc *client.VoiceCallContext) map[string]any {
+	r := map[string]any{
+		"started_at": vc.StartedAt.Format(time.RFC3339),
+	}
+	set := func(key, value string) {
+		if value != "" {
+			r[key] = value
+		}
+	}
+	set("summary", vc.Summary)
+	set("transcript", vc.Transcript)
+	set("handoff_reason", vc.HandoffReason)
+	set("last_executed_procedure", vc.LastExecutedProcedure)
+	set("last_executed_procedure_url", vc.LastExecutedProcedureURL)
+	set("gradient_labs_url", vc.GradientLabsURL)
+	return r
+}
+
+// ResumeParams returns the parameters to resume a conversation with the call's
+// context, assigned to the given human agent.
+func ResumeParams(vc *client.VoiceCallContext, assigneeID string) *client.ConversationResumeParams {
+	return &client.ConversationResumeParams{
+		AssigneeID:   assigneeID,
+		AssigneeType: client.ParticipantTypeHumanAgent,
+		Reason:       handOverReason(vc),
+		Resources:    client.Resources{ResourceName: Resource(vc)},
+	}
+}
+
+// StartParams returns the parameters to start a voice conversation with the
+// call's context, assigned to the given human agent.
+func StartParams(vc *client.VoiceCallContext, conversationID, customerID, assigneeID string) client.StartConversationParams {
+	return client.StartConversationParams{
+		ID:           conversationID,
+		CustomerID:   customerID,
+		AssigneeID:   assigneeID,
+		AssigneeType: client.ParticipantTypeHumanAgent,
+		Channel:      client.ChannelVoice,
+		Resources:    client.Resources{ResourceName: Resource(vc)},
+	}
+}
+
+func handOverReason(vc *client.VoiceCallContext) string {
+	if vc.HandoffReason != "" {
+		return "Voice call handed over: " + vc.HandoffReason
+	}
+	return "Voice call handed over"
+}
+
+// WaitOptions customise WaitForContext.
+type WaitOptions struct {
+	// Since is when the call started, or a time shortly before. Context for
+	// calls that started earlier is ignored, so a previous call isn't
+	// mistaken for this one.
+	Since time.Time
+
+	// Interval is how often to poll. Defaults to 2 seconds.
+	Interval time.Duration
+
+	// Params are passed to ReadLatestVoiceCallContext. Set IncludeLargeFields
+	// to wait for the transcript as well as the summary.
+	Params *client.ReadVoiceCallContextParams
+}
+
+// WaitForContext polls for the phone number's most recent call context until
+// the call has been processed (i.e. its summary, and transcript if requested,
+// are available) or the context is done. The phone number is normalised to
+// E.164 format first, with region used for national numbers.
+func WaitForContext(ctx context.Context, api ContextReader, phoneNumber, region string, opts WaitOptions) (*client.VoiceCallContext, error) {
+	number, err := NormalizeE164(phoneNumber, region)
+	if err != nil {
+		return nil, err
+	}
+
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	for {
+		vc, err := api.ReadLatestVoiceCallContext(ctx, number, opts.Params)
+		switch {
+		case err == nil:
+			if processed(vc, opts) {
+				return vc, nil
+			}
+		case !isNotFound(err):
+			return nil, err
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func processed(vc *client.VoiceCallContext, opts WaitOptions) bool {
+	if vc.StartedAt.Before(opts.Since) || vc.Summary == "" {
+		return false
+	}
+	if opts.Params != nil && opts.Params.IncludeLargeFields && vc.Transcript == "" {
+		return false
+	}
+	return true
+}
+
+func isNotFound(err error) bool {
+	var re *client.ResponseError
+	return errors.As(err, &re) && re.StatusCode == http.StatusNotFound
+}
@@ -0,0 +1,149 @@
+package voice_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	client "github.com/gradientlabs-ai/gradientlabs-go"
+	"github.com/gradientlabs-ai/gradientlabs-go/voice"
+)
+
+// fakeReader returns each of its responses in turn, repeating the last one.
+type fakeReader struct {
+	responses []response
+	numbers   []string
+}
+
+type response struct {
+	vc  *client.VoiceCallContext
+	err error
+}
+
+func (f *fakeReader) ReadLatestVoiceCallContext(_ context.Context, phoneNumber string, _ *client.ReadVoiceCallContextParams, _ ...client.CallOption) (*client.VoiceCallContext, error) {
+	f.numbers = append(f.numbers, phoneNumber)
+	r := f.responses[0]
+	if len(f.responses) > 1 {
+		f.responses = f.responses[1:]
+	}
+	return r.vc, r.err
+}
+
+var testCall = &client.VoiceCallContext{
+	StartedAt:             time.Date(2024, 6, 3, 10, 0, 0, 0, time.UTC),
+	Summary:               "Asked about a refund.",
+	HandoffReason:         "customer-request",
+	LastExecutedProcedure: "Refunds",
+	GradientLabsURL:       "https://app.example.com/c/1",
+}
+
+func TestResumeParams(t *testing.T) {
+	p := voice.ResumeParams(testCall, "agent-1")
+	if p.AssigneeID != "agent-1" || p.AssigneeType != client.ParticipantTypeHumanAgent {
+		t.Errorf("params = %+v", p)
+	}
+	if p.Reason != "Voice call handed over: customer-request" {
+		t.Errorf("Reason = %q", p.Reason)
+	}
+
+	r, ok := p.Resources[voice.ResourceName].(map[string]any)
+	if !ok {
+		t.Fatalf("Resources = %+v", p.Resources)
+	}
+	if r["started_at"] != "2024-06-03T10:00:00Z" || r["summary"] != "Asked about a refund." || r["last_executed_procedure"] != "Refunds" {
+		t.Errorf("resource = %+v", r)
+	}
+	if _, ok := r["transcript"]; ok {
+		t.Error("empty transcript was included")
+	}
+
+	if got := voice.ResumeParams(&client.VoiceCallContext{}, "agent-1").Reason; got != "Voice call handed over" {
+		t.Errorf("Reason without hand-off reason = %q", got)
+	}
+}
+
+func TestStartParams(t *testing.T) {
+	p := voice.StartParams(testCall, "conv-1", "cust-1", "agent-1")
+	if p.ID != "conv-1" || p.CustomerID != "cust-1" || p.AssigneeID != "agent-1" || p.Channel != client.ChannelVoice {
+		t.Errorf("params = %+v", p)
+	}
+	if _, ok := p.Resources[voice.ResourceName]; !ok {
+		t.Errorf("Resources = %+v", p.Resources)
+	}
+}
+
+func TestWaitForContext(t *testing.T) {
+	notFound := &client.ResponseError{StatusCode: http.StatusNotFound}
+	previous := &client.VoiceCallContext{StartedAt: testCall.StartedAt.Add(-time.Hour), Summary: "An earlier call."}
+	unprocessed := &client.VoiceCallContext{StartedAt: testCall.StartedAt}
+	noTranscript := &client.VoiceCallContext{StartedAt: testCall.StartedAt, Summary: "Done."}
+	withTranscript := &client.VoiceCallContext{StartedAt: testCall.StartedAt, Summary: "Done.", Transcript: "Customer: hi"}
+
+	testCases := map[string]struct {
+		responses []response
+		params    *client.ReadVoiceCallContextParams
+		want      *client.VoiceCallContext
+		polls     int
+	}{
+		"available": {
+			responses: []response{{vc: testCall}},
+			want:      testCall,
+			polls:     1,
+		},
+		"not found then processed": {
+			responses: []response{{err: notFound}, {vc: previous}, {vc: unprocessed}, {vc: testCall}},
+			want:      testCall,
+			polls:     4,
+		},
+		"waits for transcript": {
+			responses: []response{{vc: noTranscript}, {vc: withTranscript}},
+			params:    &client.ReadVoiceCallContextParams{IncludeLargeFields: true},
+			want:      withTranscript,
+			polls:     2,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			api := &fakeReader{responses: tc.responses}
+			got, err := voice.WaitForContext(context.Background(), api, "07700 900123", "GB", voice.WaitOptions{
+				Since:    testCall.StartedAt.Add(-time.Minute),
+				Interval: time.Millisecond,
+				Params:   tc.params,
+			})
+			if err != nil {
+				t.Fatalf("WaitForContext: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("WaitForContext = %+v, want %+v", got, tc.want)
+			}
+			if len(api.numbers) != tc.polls {
+				t.Errorf("polled %d times, want %d", len(api.numbers), tc.polls)
+			}
+			if api.numbers[0] != "+447700900123" {
+				t.Errorf("polled for %q", api.numbers[0])
+			}
+		})
+	}
+}
+
+func TestWaitForContextErrors(t *testing.T) {
+	boom := &client.ResponseError{StatusCode: http.StatusInternalServerError}
+	api := &fakeReader{responses: []response{{err: boom}}}
+	if _, err := voice.WaitForContext(context.Background(), api, "+447700900123", "", voice.WaitOptions{}); !errors.Is(err, boom) {
+		t.Errorf("WaitForContext error = %v", err)
+	}
+
+	if _, err := voice.WaitForContext(context.Background(), api, "07700 900123", "", voice.WaitOptions{}); !errors.Is(err, voice.ErrInvalidPhoneNumber) {
+		t.Errorf("WaitForContext error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	api = &fakeReader{responses: []response{{err: &client.ResponseError{StatusCode: http.StatusNotFound}}}}
+	if _, err := voice.WaitForContext(ctx, api, "+447700900123", "", voice.WaitOptions{Interval: time.Millisecond}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("WaitForContext error = %v", err)
+	}
+}
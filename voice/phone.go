@@ -0,0 +1,108 @@
+package voice
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidPhoneNumber is returned when a phone number can't be normalised to
+// E.164 format.
+var ErrInvalidPhoneNumber = errors.New("voice: invalid phone number")
+
+// callingCodes maps ISO 3166-1 region codes to their country calling codes,
+// for numbers given in national format.
+var callingCodes = map[string]string{
+	"AU": "61",
+	"BE": "32",
+	"CA": "1",
+	"CH": "41",
+	"DE": "49",
+	"DK": "45",
+	"ES": "34",
+	"FI": "358",
+	"FR": "33",
+	"GB": "44",
+	"IE": "353",
+	"IT": "39",
+	"NL": "31",
+	"NO": "47",
+	"NZ": "64",
+	"PL": "48",
+	"PT": "351",
+	"SE": "46",
+	"US": "1",
+}
+
+// keepsTrunkPrefix lists the regions where the leading zero of a national
+// number is part of the number itself, rather than a trunk prefix.
+var keepsTrunkPrefix = map[string]bool{
+	"IT": true,
+}
+
+// internationalPrefixes lists the regions that use an international call prefix
+// other than "00".
+var internationalPrefixes = map[string]string{
+	"CA": "011",
+	"US": "011",
+}
+
+// NormalizeE164 converts a phone number to E.164 format (e.g. "+447700900123"),
+// as expected by Client.ReadLatestVoiceCallContext. Formatting characters such
+// as spaces, dashes and brackets are removed, and an international "00" prefix
+// (or "011" in the US and Canada) is replaced with "+".
+//
+// Numbers without an international prefix are treated as national numbers in
+// the given region (e.g. "GB" or "US"). If region is empty, such numbers are
+// rejected.
+func NormalizeE164(number, region string) (string, error) {
+	// International numbers are sometimes written with the national trunk
+	// prefix in brackets (e.g. "+44 (0)20 7946 0000").
+	trimmed := strings.Replace(strings.TrimSpace(number), "(0)", "", 1)
+
+	var digits strings.Builder
+	international := false
+	for i, r := range trimmed {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == '+' && i == 0:
+			international = true
+		case strings.ContainsRune(" -.()/", r):
+		default:
+			return "", fmt.Errorf("%w: unexpected %q in %q", ErrInvalidPhoneNumber, r, number)
+		}
+	}
+
+	n := digits.String()
+	region = strings.ToUpper(region)
+	prefix := internationalPrefixes[region]
+	switch {
+	case international:
+	case strings.HasPrefix(n, "00"):
+		n = n[2:]
+	case prefix != "" && strings.HasPrefix(n, prefix):
+		n = n[len(prefix):]
+	case region == "":
+		return "", fmt.Errorf("%w: %q has no country code", ErrInvalidPhoneNumber, number)
+	default:
+		code, ok := callingCodes[region]
+		if !ok {
+			return "", fmt.Errorf("%w: unsupported region %q", ErrInvalidPhoneNumber, region)
+		}
+		if !keepsTrunkPrefix[region] {
+			n = strings.TrimPrefix(n, "0")
+		}
+		if code == "1" {
+			// North American numbers are sometimes written with a leading 1.
+			n = strings.TrimPrefix(n, "1")
+		}
+		n = code + n
+	}
+
+	// E.164 numbers have at most 15 digits, and no country code starts with 0.
+	if len(n) < 8 || len(n) > 15 || n[0] == '0' {
+		return "", fmt.Errorf("%w: %q", ErrInvalidPhoneNumber, number)
+	}
+	return "+" + n, nil
+}
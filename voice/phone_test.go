@@ -0,0 +1,68 @@
+package voice_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gradientlabs-ai/gradientlabs-go/voice"
+)
+
+func TestNormalizeE164(t *testing.T) {
+	testCases := map[string]struct {
+		number string
+		region string
+		want   string
+	}{
+		"already e164":                 {number: "+447700900123", want: "+447700900123"},
+		"spaces":                       {number: " +44 7700 900123 ", want: "+447700900123"},
+		"bracketed trunk":              {number: "+44 (0)20 7946 0000", want: "+442079460000"},
+		"double zero prefix":           {number: "0044 7700 900123", want: "+447700900123"},
+		"gb national":                  {number: "07700 900123", region: "GB", want: "+447700900123"},
+		"lower case region":            {number: "07700-900-123", region: "gb", want: "+447700900123"},
+		"us national":                  {number: "(415) 555-0132", region: "US", want: "+14155550132"},
+		"us leading one":               {number: "1-415-555-0132", region: "US", want: "+14155550132"},
+		"us dots":                      {number: "415.555.0132", region: "US", want: "+14155550132"},
+		"us international prefix":      {number: "011 44 7700 900123", region: "US", want: "+447700900123"},
+		"us double zero prefix":        {number: "0044 7700 900123", region: "US", want: "+447700900123"},
+		"ca international prefix":      {number: "011-33-1-23-45-67-89", region: "ca", want: "+33123456789"},
+		"italy keeps zero":             {number: "06 6982 1234", region: "IT", want: "+390669821234"},
+		"international ignores region": {number: "+33 1 23 45 67 89", region: "GB", want: "+33123456789"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := voice.NormalizeE164(tc.number, tc.region)
+			if err != nil {
+				t.Fatalf("NormalizeE164(%q, %q): %v", tc.number, tc.region, err)
+			}
+			if got != tc.want {
+				t.Errorf("NormalizeE164(%q, %q) = %q, want %q", tc.number, tc.region, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeE164Invalid(t *testing.T) {
+	testCases := map[string]struct {
+		number string
+		region string
+	}{
+		"letters":            {number: "+44 7700 CALLME"},
+		"plus in the middle": {number: "44+7700900123"},
+		"no region":          {number: "07700 900123"},
+		"unsupported region": {number: "07700 900123", region: "XX"},
+		"too short":          {number: "+44 123"},
+		"too long":           {number: "+44 1234 5678 9012 34"},
+		"zero country code":  {number: "+0 7700 900123"},
+		"empty":              {number: ""},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := voice.NormalizeE164(tc.number, tc.region)
+			if !errors.Is(err, voice.ErrInvalidPhoneNumber) {
+				t.Errorf("NormalizeE164(%q, %q) = %q, %v; want ErrInvalidPhoneNumber", tc.number, tc.region, got, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package voice
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	client "github.com/gradientlabs-ai/gradientlabs-go"
+)
+
+// ErrMalformedTranscript is returned when a transcript doesn't start with a
+// speaker label.
+var ErrMalformedTranscript = errors.New("voice: malformed transcript")
+
+// Turn is a single speaker's turn in a voice call transcript.
+type Turn struct {
+	// Speaker is the type of participant speaking.
+	Speaker client.ParticipantType
+
+	// Label is the speaker's label as written in the transcript (e.g.
+	// "Caller").
+	Label string
+
+	// Text is what the speaker said. Turns spanning several lines keep their
+	// line breaks.
+	Text string
+
+	// Offset is how far into the call the turn started, if the transcript
+	// includes timestamps (see Timed).
+	Offset time.Duration
+
+	// Timed is true if the transcript included a timestamp for the turn.
+	Timed bool
+
+	// Start and End are the byte offsets of the turn within the transcript.
+	Start, End int
+}
+
+// DefaultSpeakers maps the speaker labels used in transcripts (in lower case)
+// to participant types. It includes the wire value of every participant type
+// (e.g. "agent" for client.ParticipantTypeHumanAgent), so turns formatted
+// without a label parse back to the same speaker.
+var DefaultSpeakers = map[string]client.ParticipantType{
+	"customer":       client.ParticipantTypeCustomer,
+	"caller":         client.ParticipantTypeCustomer,
+	"user":           client.ParticipantTypeCustomer,
+	"ai agent":       client.ParticipantTypeAIAgent,
+	"ai":             client.ParticipantTypeAIAgent,
+	"assistant":      client.ParticipantTypeAIAgent,
+	"agent":          client.ParticipantTypeHumanAgent,
+	"human agent":    client.ParticipantTypeHumanAgent,
+	"human":          client.ParticipantTypeHumanAgent,
+	"operator":       client.ParticipantTypeHumanAgent,
+	"representative": client.ParticipantTypeHumanAgent,
+	"bot":            client.ParticipantTypeBot,
+	"ivr":            client.ParticipantTypeBot,
+	"system":         client.ParticipantTypeBot,
+}
+
+// linePattern matches a line that starts a turn, with an optional timestamp:
+//
+//	[00:01:05] Customer: I'd like to close my account.
+var linePattern = regexp.MustCompile(`^(?:\[(\d{1,2}(?::\d{2}){1,2}(?:\.\d{1,3})?)\][ \t]*)?([\p{L}][\p{L} _-]{0,30}?)[ \t]*:[ \t]?(.*)$`)
+
+// ParseTranscript splits a transcript (e.g. VoiceCallContext.Transcript) into
+// turns. Each turn starts with a line consisting of an optional timestamp and a
+// speaker label (see DefaultSpeakers), followed by a colon. Lines that don't
+// start with a known label are treated as a continuation of the previous turn.
+//
+// speakers optionally overrides DefaultSpeakers; its keys must be lower case.
+func ParseTranscript(transcript string, speakers map[string]client.ParticipantType) ([]Turn, error) {
+	if speakers == nil {
+		speakers = DefaultSpeakers
+	}
+
+	var (
+		turns []Turn
+		pos   int
+	)
+	for _, line := range strings.SplitAfter(transcript, "\n") {
+		start := pos
+		pos += len(line)
+
+		content := strings.TrimRight(line, "\r\n")
+		if strings.TrimSpace(content) == "" {
+			continue
+		}
+
+		if m := linePattern.FindStringSubmatch(content); m != nil {
+			if speaker, ok := speakers[strings.ToLower(m[2])]; ok {
+				t := Turn{
+					Speaker: speaker,
+					Label:   m[2],
+					Text:    m[3],
+					Start:   start,
+					End:     start + len(content),
+				}
+				if m[1] != "" {
+					offset, err := parseOffset(m[1])
+					if err != nil {
+						return nil, fmt.Errorf("%w: %v", ErrMalformedTranscript, err)
+					}
+					t.Offset, t.Timed = offset, true
+				}
+				turns = append(turns, t)
+				continue
+			}
+		}
+
+		if len(turns) == 0 {
+			return nil, fmt.Errorf("%w: expected a speaker label at the start of %q", ErrMalformedTranscript, content)
+		}
+		last := &turns[len(turns)-1]
+		last.Text += "\n" + content
+		last.End = start + len(content)
+	}
+	return turns, nil
+}
+
+// parseOffset parses a "mm:ss" or "hh:mm:ss" timestamp, with optional
+// milliseconds.
+func parseOffset(s string) (time.Duration, error) {
+	var ms time.Duration
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		frac := (s[i+1:] + "00")[:3]
+		n, err := strconv.Atoi(frac)
+		if err != nil {
+			return 0, err
+		}
+		ms = time.Duration(n) * time.Millisecond
+		s = s[:i]
+	}
+
+	var d time.Duration
+	for _, part := range strings.Split(s, ":") {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return 0, err
+		}
+		d = d*60 + time.Duration(n)
+	}
+	return d*time.Second + ms, nil
+}
+
+// FormatTranscript renders turns in the format read by ParseTranscript, so
+// that parsing the output gives back the same turns (other than their byte
+// offsets).
+func FormatTranscript(turns []Turn) string {
+	b := new(strings.Builder)
+	for i, t := range turns {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		if t.Timed {
+			b.WriteString("[" + formatOffset(t.Offset) + "] ")
+		}
+		label := t.Label
+		if label == "" {
+			label = string(t.Speaker)
+		}
+		b.WriteString(label + ": " + t.Text)
+	}
+	return b.String()
+}
+
+func formatOffset(d time.Duration) string {
+	h := int(d / time.Hour)
+	m := int(d % time.Hour / time.Minute)
+	s := int(d % time.Minute / time.Second)
+	ms := int(d % time.Second / time.Millisecond)
+
+	out := fmt.Sprintf("%02d:%02d", m, s)
+	if h > 0 {
+		out = fmt.Sprintf("%02d:%s", h, out)
+	}
+	if ms > 0 {
+		out += fmt.Sprintf(".%03d", ms)
+	}
+	return out
+}
+
+// Turns parses the call's transcript. Note: the transcript is only included
+// when ReadVoiceCallContextParams.IncludeLargeFields is set.
+func Turns(vc *client.VoiceCallContext) ([]Turn, error) {
+	return ParseTranscript(vc.Transcript, nil)
+}
+
+// Messages renders turns as messages, for display alongside the rest of a
+// conversation. Each message's ID is derived from its turn's position, and its
+// participant ID is the speaker's label. If the turns are timed, messages are
+// timestamped relative to the call's start time.
+func Messages(turns []Turn, callStarted time.Time) []*client.Message {
+	msgs := make([]*client.Message, len(turns))
+	for i, t := range turns {
+		msg := &client.Message{
+			ID:              fmt.Sprintf("voice-turn-%d", i+1),
+			Body:            t.Text,
+			ParticipantID:   t.Label,
+			ParticipantType: t.Speaker,
+		}
+		if t.Timed && !callStarted.IsZero() {
+			created := callStarted.Add(t.Offset)
+			msg.Created = &created
+		}
+		msgs[i] = msg
+	}
+	return msgs
+}
@@ -0,0 +1,181 @@
+package voice_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	client "github.com/gradientlabs-ai/gradientlabs-go"
+	"github.com/gradientlabs-ai/gradientlabs-go/voice"
+)
+
+// withoutOffsets clears the byte offsets of turns, which change when a
+// transcript is reformatted.
+func withoutOffsets(turns []voice.Turn) []voice.Turn {
+	out := make([]voice.Turn, len(turns))
+	for i, t := range turns {
+		t.Start, t.End = 0, 0
+		out[i] = t
+	}
+	return out
+}
+
+func TestParseTranscript(t *testing.T) {
+	testCases := map[string]struct {
+		transcript string
+		speakers   map[string]client.ParticipantType
+		want       []voice.Turn
+	}{
+		"untimed": {
+			transcript: "Customer: I'd like to close my account.\nAI Agent: I can help with that.",
+			want: []voice.Turn{
+				{Speaker: client.ParticipantTypeCustomer, Label: "Customer", Text: "I'd like to close my account."},
+				{Speaker: client.ParticipantTypeAIAgent, Label: "AI Agent", Text: "I can help with that."},
+			},
+		},
+		"timed": {
+			transcript: "[00:01] Caller: Hello?\n[1:02:03.5] Operator: Hi there\n[00:10.250]Bot:Please hold",
+			want: []voice.Turn{
+				{Speaker: client.ParticipantTypeCustomer, Label: "Caller", Text: "Hello?", Offset: time.Second, Timed: true},
+				{Speaker: client.ParticipantTypeHumanAgent, Label: "Operator", Text: "Hi there", Offset: time.Hour + 2*time.Minute + 3*time.Second + 500*time.Millisecond, Timed: true},
+				{Speaker: client.ParticipantTypeBot, Label: "Bot", Text: "Please hold", Offset: 10*time.Second + 250*time.Millisecond, Timed: true},
+			},
+		},
+		"multi-line turns": {
+			transcript: "Customer: My card\nwas stolen\n\n  yesterday\nAgent: Sorry to hear that.",
+			want: []voice.Turn{
+				{Speaker: client.ParticipantTypeCustomer, Label: "Customer", Text: "My card\nwas stolen\n  yesterday"},
+				{Speaker: client.ParticipantTypeHumanAgent, Label: "Agent", Text: "Sorry to hear that."},
+			},
+		},
+		"unknown labels continue the turn": {
+			transcript: "Customer: My reference is\nRef: 12345\nAI: Thanks.",
+			want: []voice.Turn{
+				{Speaker: client.ParticipantTypeCustomer, Label: "Customer", Text: "My reference is\nRef: 12345"},
+				{Speaker: client.ParticipantTypeAIAgent, Label: "AI", Text: "Thanks."},
+			},
+		},
+		"crlf": {
+			transcript: "Customer: Hi\r\nthere\r\nAI Agent: Hello\r\n",
+			want: []voice.Turn{
+				{Speaker: client.ParticipantTypeCustomer, Label: "Customer", Text: "Hi\nthere"},
+				{Speaker: client.ParticipantTypeAIAgent, Label: "AI Agent", Text: "Hello"},
+			},
+		},
+		"custom speakers": {
+			transcript: "Member: Hi\nConcierge: Hello",
+			speakers: map[string]client.ParticipantType{
+				"member":    client.ParticipantTypeCustomer,
+				"concierge": client.ParticipantTypeHumanAgent,
+			},
+			want: []voice.Turn{
+				{Speaker: client.ParticipantTypeCustomer, Label: "Member", Text: "Hi"},
+				{Speaker: client.ParticipantTypeHumanAgent, Label: "Concierge", Text: "Hello"},
+			},
+		},
+		"empty": {
+			transcript: "\n\n",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			turns, err := voice.ParseTranscript(tc.transcript, tc.speakers)
+			if err != nil {
+				t.Fatalf("ParseTranscript: %v", err)
+			}
+			if got := withoutOffsets(turns); !reflect.DeepEqual(got, withoutOffsets(tc.want)) {
+				t.Fatalf("ParseTranscript =\n%+v\nwant:\n%+v", got, tc.want)
+			}
+			for _, turn := range turns {
+				if turn.Start < 0 || turn.End > len(tc.transcript) || turn.Start >= turn.End {
+					t.Errorf("turn %q has invalid offsets [%d, %d)", turn.Text, turn.Start, turn.End)
+				}
+			}
+
+			// Formatting and parsing again gives back the same turns.
+			again, err := voice.ParseTranscript(voice.FormatTranscript(turns), tc.speakers)
+			if err != nil {
+				t.Fatalf("parsing formatted transcript: %v", err)
+			}
+			if !reflect.DeepEqual(withoutOffsets(again), withoutOffsets(turns)) {
+				t.Errorf("round trip =\n%+v\nwant:\n%+v", again, turns)
+			}
+		})
+	}
+}
+
+func TestParseTranscriptOffsets(t *testing.T) {
+	transcript := "Customer: Hi\nthere\nAI: Hello"
+	turns, err := voice.ParseTranscript(transcript, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := transcript[turns[0].Start:turns[0].End]; got != "Customer: Hi\nthere" {
+		t.Errorf("first turn spans %q", got)
+	}
+	if got := transcript[turns[1].Start:turns[1].End]; got != "AI: Hello" {
+		t.Errorf("second turn spans %q", got)
+	}
+}
+
+func TestParseTranscriptMalformed(t *testing.T) {
+	for name, transcript := range map[string]string{
+		"no label":      "Hello, is anyone there?",
+		"unknown label": "Narrator: It was a dark and stormy night.",
+	} {
+		if _, err := voice.ParseTranscript(transcript, nil); !errors.Is(err, voice.ErrMalformedTranscript) {
+			t.Errorf("%s: error = %v", name, err)
+		}
+	}
+}
+
+func TestFormatTranscriptWithoutLabels(t *testing.T) {
+	turns := []voice.Turn{
+		{Speaker: client.ParticipantTypeCustomer, Text: "Hi"},
+		{Speaker: client.ParticipantTypeAIAgent, Text: "Hello", Offset: 90 * time.Second, Timed: true},
+		{Speaker: client.ParticipantTypeHumanAgent, Text: "Taking over", Offset: time.Hour, Timed: true},
+		{Speaker: client.ParticipantTypeBot, Text: "Goodbye"},
+	}
+
+	formatted := voice.FormatTranscript(turns)
+	want := "Customer: Hi\n[01:30] AI Agent: Hello\n[01:00:00] Agent: Taking over\nBot: Goodbye"
+	if formatted != want {
+		t.Errorf("FormatTranscript = %q, want %q", formatted, want)
+	}
+
+	parsed, err := voice.ParseTranscript(formatted, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, turn := range parsed {
+		if turn.Speaker != turns[i].Speaker {
+			t.Errorf("turn %d speaker = %q, want %q", i, turn.Speaker, turns[i].Speaker)
+		}
+	}
+}
+
+func TestMessages(t *testing.T) {
+	started := time.Date(2024, 6, 3, 10, 0, 0, 0, time.UTC)
+	turns := []voice.Turn{
+		{Speaker: client.ParticipantTypeCustomer, Label: "Caller", Text: "Hi", Offset: 5 * time.Second, Timed: true},
+		{Speaker: client.ParticipantTypeAIAgent, Label: "AI", Text: "Hello"},
+	}
+
+	msgs := voice.Messages(turns, started)
+	if len(msgs) != 2 {
+		t.Fatalf("got %d messages", len(msgs))
+	}
+	if m := msgs[0]; m.ID != "voice-turn-1" || m.ParticipantID != "Caller" || m.Created == nil || !m.Created.Equal(started.Add(5*time.Second)) {
+		t.Errorf("first message = %+v", m)
+	}
+	if m := msgs[1]; m.ParticipantType != client.ParticipantTypeAIAgent || m.Created != nil {
+		t.Errorf("second message = %+v", m)
+	}
+
+	vc := &client.VoiceCallContext{Transcript: "Customer: Hi"}
+	if turns, err := voice.Turns(vc); err != nil || len(turns) != 1 {
+		t.Errorf("Turns = %+v, %v", turns, err)
+	}
+}
@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -23,7 +22,7 @@ type ReadVoiceCallContextParams struct {
 // given phone number.
 //
 // Note: requires a Public API key.
-func (c *Client) ReadLatestVoiceCallContext(ctx context.Context, phoneNumber string, p *ReadVoiceCallContextParams) (*VoiceCallContext, error) {
+func (c *Client) ReadLatestVoiceCallContext(ctx context.Context, phoneNumber string, p *ReadVoiceCallContextParams, opts ...CallOption) (*VoiceCallContext, error) {
 	path := fmt.Sprintf("voice/latest-call-context/%s", phoneNumber)
 	if p != nil {
 		sep := "?"
@@ -36,18 +35,8 @@ func (c *Client) ReadLatestVoiceCallContext(ctx context.Context, phoneNumber str
 		}
 	}
 
-	rsp, err := c.makeRequest(ctx, http.MethodGet, path, nil)
-	if err != nil {
-		return nil, err
-	}
-	defer rsp.Body.Close()
-
-	if err := responseError(rsp); err != nil {
-		return nil, err
-	}
-
 	var result VoiceCallContext
-	if err := json.NewDecoder(rsp.Body).Decode(&result); err != nil {
+	if err := c.do(ctx, "ReadLatestVoiceCallContext", http.MethodGet, path, nil, &result, opts...); err != nil {
 		return nil, err
 	}
 	return &result, nil
@@ -255,6 +255,8 @@ func (c *Client) ParseWebhook(req *http.Request) (webhook *Webhook, token string
 	// Extract the optional sensitive token from the request header.
 	token = req.Header.Get(tokenHeader)
 
+	c.journalWebhook(req.Context(), &payload.Webhook)
+
 	return &payload.Webhook, token, nil
 }
 